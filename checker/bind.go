@@ -0,0 +1,164 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// bindError is an error with the bind address, this is classified separately
+// as it's the configuration which is wrong rather than the target
+type bindError struct {
+	code types.CheckError
+	err  error
+}
+
+func (e *bindError) Error() string {
+	return fmt.Sprintf("Bind address: %v", e.err)
+}
+
+func (e *bindError) Unwrap() error {
+	return e.err
+}
+
+// bindAddress returns the local address or interface connections are made
+// from, or an empty string if the system chooses. The check's bind address is
+// used instead of the agent's.
+func (c *Checker) bindAddress() string {
+	if c.Req.Options.BindAddress != "" {
+		return c.Req.Options.BindAddress
+	}
+
+	return agentConfig.BindAddress
+}
+
+// localIPs returns the local addresses to connect from. An interface may
+// have an address in each family, in which case the IPv4 address is first.
+func (c *Checker) localIPs(network string) ([]net.IP, error) {
+	bind := c.bindAddress()
+	if bind == "" {
+		return nil, nil
+	}
+
+	if ip := net.ParseIP(bind); ip != nil {
+		return []net.IP{ip}, nil
+	}
+
+	iface, err := net.InterfaceByName(bind)
+	if err != nil {
+		return nil, &bindError{code: types.BindAddressInvalid, err: err}
+	}
+
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil, &bindError{code: types.BindAddressInvalid, err: err}
+	}
+
+	var ipv4, ipv6 net.IP
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+
+		// Link local addresses can't reach most targets
+		if !ok || ipNet.IP.IsLinkLocalUnicast() {
+			continue
+		}
+
+		if ipNet.IP.To4() != nil {
+			if ipv4 == nil {
+				ipv4 = ipNet.IP
+			}
+		} else if ipv6 == nil {
+			ipv6 = ipNet.IP
+		}
+	}
+
+	var ips []net.IP
+	if ipv4 != nil && !strings.HasSuffix(network, "6") {
+		ips = append(ips, ipv4)
+	}
+	if ipv6 != nil && !strings.HasSuffix(network, "4") {
+		ips = append(ips, ipv6)
+	}
+
+	if len(ips) == 0 {
+		return nil, &bindError{code: types.BindAddressInvalid, err: fmt.Errorf("%s has no usable address", bind)}
+	}
+
+	return ips, nil
+}
+
+// dialFrom connects from the bind address if there is one. The dialer only
+// connects to addresses in the same family as the local address, so if the
+// target has no address in the family the next local address is tried. If
+// the bind address is an interface the socket is also bound to it, where the
+// system supports this.
+func (c *Checker) dialFrom(ctx context.Context, dialer *net.Dialer, network, addr string) (net.Conn, error) {
+	ips, err := c.localIPs(network)
+	if err != nil {
+		return nil, err
+	}
+
+	if ips == nil {
+		return dialer.DialContext(ctx, network, addr)
+	}
+
+	bind := c.bindAddress()
+
+	var conn net.Conn
+	for _, ip := range ips {
+		from := *dialer
+		from.LocalAddr = localAddr(network, ip)
+		if net.ParseIP(bind) == nil {
+			from.Control = bindToDevice(bind)
+		}
+
+		conn, err = from.DialContext(ctx, network, addr)
+		if !isNoSuitableAddress(err) {
+			break
+		}
+	}
+
+	return conn, err
+}
+
+func localAddr(network string, ip net.IP) net.Addr {
+	if strings.HasPrefix(network, "udp") {
+		return &net.UDPAddr{IP: ip}
+	}
+
+	return &net.TCPAddr{IP: ip}
+}
+
+func isNoSuitableAddress(err error) bool {
+	var addrErr *net.AddrError
+	return errors.As(err, &addrErr) && addrErr.Err == noSuitableAddress
+}
+
+// sourceAddress returns the local IP address of the connection
+func sourceAddress(conn net.Conn) string {
+	host, _, err := net.SplitHostPort(conn.LocalAddr().String())
+	if err != nil {
+		return ""
+	}
+
+	return host
+}
+
+// unwrapBindError classifies errors from the bind address
+func (c *Checker) unwrapBindError(err error) (UnwrappedError, bool) {
+	var bindErr *bindError
+	if errors.As(err, &bindErr) {
+		return UnwrappedError{Err: bindErr.code}, true
+	}
+
+	if c.bindAddress() != "" && errors.Is(err, syscall.EADDRNOTAVAIL) {
+		return UnwrappedError{Err: types.BindAddressUnavailable}, true
+	}
+
+	return UnwrappedError{}, false
+}
@@ -0,0 +1,30 @@
+//go:build linux
+
+package checker
+
+import (
+	"syscall"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// bindToDevice returns a dialer control function which binds sockets to the
+// interface, so connections leave through it even if the routing table would
+// send them elsewhere. This requires CAP_NET_RAW.
+func bindToDevice(iface string) func(network, address string, conn syscall.RawConn) error {
+	return func(_, _ string, conn syscall.RawConn) error {
+		var sockErr error
+		err := conn.Control(func(fd uintptr) {
+			sockErr = syscall.SetsockoptString(int(fd), syscall.SOL_SOCKET, syscall.SO_BINDTODEVICE, iface)
+		})
+		if err != nil {
+			return err
+		}
+
+		if sockErr != nil {
+			return &bindError{code: types.BindAddressUnavailable, err: sockErr}
+		}
+
+		return nil
+	}
+}
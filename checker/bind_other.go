@@ -0,0 +1,12 @@
+//go:build !linux
+
+package checker
+
+import "syscall"
+
+// bindToDevice returns nil, as sockets can't be bound to an interface on this
+// system. Connections are made from the interface's address, but may leave
+// through another interface depending on the routing table.
+func bindToDevice(iface string) func(network, address string, conn syscall.RawConn) error {
+	return nil
+}
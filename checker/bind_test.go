@@ -0,0 +1,145 @@
+package checker_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/miekg/dns"
+	a "github.com/stretchr/testify/assert"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// loopbackInterface returns the name of the loopback interface
+func loopbackInterface(t *testing.T) string {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagLoopback != 0 {
+			return iface.Name
+		}
+	}
+
+	t.Skip("no loopback interface")
+	return ""
+}
+
+func TestBindAddress(t *testing.T) {
+	var remoteAddr string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remoteAddr, _, _ = net.SplitHostPort(r.RemoteAddr)
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name   string
+		bind   string
+		source string
+		error  string
+	}{
+		{"none", "", "127.0.0.1", ""},
+		{"address", "127.0.0.1", "127.0.0.1", ""},
+		{"interface", loopbackInterface(t), "127.0.0.1", ""},
+		{"wrong family", "::1", "", types.NoSuitableAddress.ToString()},
+		{"unavailable", "192.0.2.10", "", types.BindAddressUnavailable.ToString()},
+		{"unknown interface", "nonexistent0", "", types.BindAddressInvalid.ToString()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remoteAddr = ""
+
+			req := buildCheck(server.URL)
+			req.Options.BindAddress = tt.bind
+
+			c := checker.Init(req)
+			c.Perform()
+
+			a.Equal(t, tt.error, c.Res.Error)
+			a.Equal(t, tt.source, c.Res.SourceAddress)
+			a.Equal(t, tt.source, remoteAddr)
+		})
+	}
+}
+
+func TestAgentBindAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	checker.Configure(checker.Config{BindAddress: "192.0.2.10"})
+	t.Cleanup(func() { checker.Configure(checker.Config{}) })
+
+	c := checker.Init(buildCheck(server.URL))
+	c.Perform()
+	a.Equal(t, types.BindAddressUnavailable.ToString(), c.Res.Error)
+
+	// The check's bind address is used instead
+	req := buildCheck(server.URL)
+	req.Options.BindAddress = "127.0.0.1"
+	c = checker.Init(req)
+	c.Perform()
+	a.Equal(t, "", c.Res.Error)
+	a.Equal(t, "127.0.0.1", c.Res.SourceAddress)
+}
+
+func TestBindAddressResolver(t *testing.T) {
+	// Any address in 127.0.0.0/8 can be used on Linux, but not on all systems
+	if conn, err := net.ListenPacket("udp", "127.0.0.2:0"); err != nil {
+		t.Skip("127.0.0.2 not available")
+	} else {
+		conn.Close()
+	}
+
+	var remoteAddr string
+	var resolverAddr atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remoteAddr, _, _ = net.SplitHostPort(r.RemoteAddr)
+	}))
+	t.Cleanup(server.Close)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	started := make(chan struct{})
+	nameserver := &dns.Server{
+		PacketConn:        conn,
+		NotifyStartedFunc: func() { close(started) },
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+			host, _, _ := net.SplitHostPort(w.RemoteAddr().String())
+			resolverAddr.Store(host)
+
+			res := new(dns.Msg)
+			res.SetReply(req)
+			if req.Question[0].Qtype == dns.TypeA {
+				res.Answer = append(res.Answer, &dns.A{
+					Hdr: dns.RR_Header{Name: req.Question[0].Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 60},
+					A:   net.ParseIP("127.0.0.1"),
+				})
+			}
+			w.WriteMsg(res)
+		}),
+	}
+	go nameserver.ActivateAndServe()
+	t.Cleanup(func() { nameserver.Shutdown() })
+	<-started
+
+	req := buildCheck("http://bound.test:" + server.URL[len("http://127.0.0.1:"):])
+	req.Options.BindAddress = "127.0.0.2"
+	req.Options.Resolver = types.Resolver{Nameservers: []string{conn.LocalAddr().String()}}
+
+	c := checker.Init(req)
+	c.Perform()
+
+	a.Equal(t, "", c.Res.Error)
+	a.Equal(t, "127.0.0.2", resolverAddr.Load())
+	a.Equal(t, "127.0.0.2", remoteAddr)
+}
@@ -0,0 +1,186 @@
+package checker
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+var (
+	errNoCertificates = errors.New("No certificates found")
+)
+
+// CAPool contains additional certificate authorities trusted when verifying
+// server certificates
+type CAPool struct {
+	Roots         []*x509.Certificate
+	Intermediates []*x509.Certificate
+}
+
+// ParseCAPool parses PEM encoded certificates. Self-signed certificates are
+// trusted as roots, and the others are used as intermediates.
+func ParseCAPool(data []byte) (*CAPool, error) {
+	pool := &CAPool{}
+
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		if isSelfSigned(cert) {
+			pool.Roots = append(pool.Roots, cert)
+		} else {
+			pool.Intermediates = append(pool.Intermediates, cert)
+		}
+	}
+
+	if len(pool.Roots) == 0 && len(pool.Intermediates) == 0 {
+		return nil, errNoCertificates
+	}
+
+	return pool, nil
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignatureFrom(cert) == nil
+}
+
+// verifiedChains records the chains verified with additional intermediates,
+// by the leaf certificate, so each connection's own chain is used
+type verifiedChains struct {
+	mu     sync.Mutex
+	chains map[string][][]*x509.Certificate
+}
+
+func (v *verifiedChains) record(leaf *x509.Certificate, chains [][]*x509.Certificate) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.chains == nil {
+		v.chains = make(map[string][][]*x509.Certificate)
+	}
+	v.chains[string(leaf.Raw)] = chains
+}
+
+func (v *verifiedChains) lookup(leaf *x509.Certificate) [][]*x509.Certificate {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.chains[string(leaf.Raw)]
+}
+
+// configureCAs adds the agent and check certificate authorities to the
+// configuration. If there are none the system roots are used as normal.
+// Chains verified with additional intermediates are recorded in verified if
+// it's set, which is only done for the target.
+func (c *Checker) configureCAs(config *tls.Config, verified *verifiedChains) types.CheckError {
+	opts := c.Req.Options.TrustedCAs
+	replace := agentConfig.ReplaceSystemRoots || opts.ReplaceSystemRoots
+
+	pools := []*CAPool{}
+	if agentConfig.CAs != nil {
+		pools = append(pools, agentConfig.CAs)
+	}
+
+	if len(opts.PEM) > 0 {
+		pool, err := ParseCAPool(opts.PEM)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"Ref": c.Req.Ref,
+				"Err": err,
+			}).Debug("Invalid CA bundle")
+			return types.CABundleInvalid
+		}
+
+		pools = append(pools, pool)
+	}
+
+	if len(pools) == 0 && !replace {
+		return ""
+	}
+
+	roots := x509.NewCertPool()
+	if !replace {
+		if system, err := x509.SystemCertPool(); err == nil {
+			roots = system
+		}
+	}
+
+	var intermediates []*x509.Certificate
+	for _, pool := range pools {
+		for _, cert := range pool.Roots {
+			roots.AddCert(cert)
+		}
+
+		intermediates = append(intermediates, pool.Intermediates...)
+	}
+
+	config.RootCAs = roots
+
+	// The standard verification only uses the intermediates sent by the
+	// server, so verify the chain ourselves if we have extra intermediates
+	if len(intermediates) > 0 && !config.InsecureSkipVerify {
+		serverName := config.ServerName
+		if serverName == "" {
+			serverName = c.Req.URL.Hostname()
+		}
+
+		config.InsecureSkipVerify = true
+		config.VerifyConnection = func(state tls.ConnectionState) error {
+			// The state has the name sent with SNI, which is the host when
+			// following redirects, but IP addresses aren't sent so the name
+			// would be empty and the host wouldn't be verified
+			name := state.ServerName
+			if name == "" {
+				name = serverName
+			}
+
+			opts := x509.VerifyOptions{
+				DNSName:       name,
+				Roots:         roots,
+				Intermediates: x509.NewCertPool(),
+			}
+
+			for _, cert := range intermediates {
+				opts.Intermediates.AddCert(cert)
+			}
+
+			for _, cert := range state.PeerCertificates[1:] {
+				opts.Intermediates.AddCert(cert)
+			}
+
+			chains, err := state.PeerCertificates[0].Verify(opts)
+			if err != nil {
+				return err
+			}
+
+			// Standard verification was skipped, so the state doesn't have
+			// the chains for pinning and revocation checks
+			if verified != nil {
+				verified.record(state.PeerCertificates[0], chains)
+			}
+
+			return nil
+		}
+	}
+
+	return ""
+}
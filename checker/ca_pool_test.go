@@ -0,0 +1,139 @@
+package checker_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	a "github.com/stretchr/testify/assert"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+func TestTrustedCAs(t *testing.T) {
+	root := issueCert(t, caTemplate("Private Root"), nil, nil)
+	intermediate := issueCert(t, caTemplate("Private Intermediate"), nil, root)
+	leaf := issueCert(t, certTemplate(), nil, intermediate)
+
+	rootPEM, _ := root.PEM(t)
+	intermediatePEM, _ := intermediate.PEM(t)
+	bundlePEM := append(append([]byte{}, rootPEM...), intermediatePEM...)
+
+	// Complete chain, and one where the server doesn't send the intermediate
+	complete := startTLSServer(t, leaf.TLSCertificate(intermediate), nil)
+	incomplete := startTLSServer(t, leaf.TLSCertificate(), nil)
+
+	tests := []struct {
+		name  string
+		url   string
+		cas   types.TrustedCAs
+		error string
+	}{
+		{"system roots", complete.URL, types.TrustedCAs{}, types.CertUntrustedAuthority.ToString()},
+		{"root", complete.URL, types.TrustedCAs{PEM: rootPEM}, ""},
+		{"replace system roots", complete.URL, types.TrustedCAs{PEM: rootPEM, ReplaceSystemRoots: true}, ""},
+		{"missing intermediate", incomplete.URL, types.TrustedCAs{PEM: rootPEM}, types.CertIncompleteChain.ToString()},
+		{"supplied intermediate", incomplete.URL, types.TrustedCAs{PEM: bundlePEM}, ""},
+		{"invalid", complete.URL, types.TrustedCAs{PEM: []byte("not a certificate")}, types.CABundleInvalid.ToString()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := buildCheck(tt.url)
+			req.Options.TrustedCAs = tt.cas
+
+			c := checker.Init(req)
+			c.Perform()
+
+			a.Equal(t, tt.error, c.Res.Error)
+		})
+	}
+}
+
+func TestTrustedIntermediates(t *testing.T) {
+	root := issueCert(t, caTemplate("Private Root"), nil, nil)
+	intermediate := issueCert(t, caTemplate("Private Intermediate"), nil, root)
+	rootPEM, _ := root.PEM(t)
+	intermediatePEM, _ := intermediate.PEM(t)
+	bundlePEM := append(append([]byte{}, rootPEM...), intermediatePEM...)
+
+	// Valid for localhost only, so connecting to the IP address must fail
+	template := certTemplate()
+	template.IPAddresses = nil
+	leaf := issueCert(t, template, nil, intermediate)
+	server := startTLSServer(t, leaf.TLSCertificate(), nil)
+
+	req := buildCheck(server.URL)
+	req.Options.TrustedCAs = types.TrustedCAs{PEM: bundlePEM}
+	c := checker.Init(req)
+	c.Perform()
+	a.Equal(t, types.CertWrongHost.ToString(), c.Res.Error)
+
+	// The chain verified with the supplied intermediate is used for pins
+	req = buildCheck("https://localhost:" + server.URL[len("https://127.0.0.1:"):])
+	req.Options.TrustedCAs = types.TrustedCAs{PEM: bundlePEM}
+	req.Options.CertPins = types.CertPins{SPKISHA256: [][]byte{sha256Sum(intermediate.Cert.RawSubjectPublicKeyInfo)}}
+	c = checker.Init(req)
+	c.Perform()
+	a.Equal(t, "", c.Res.Error)
+}
+
+func TestAgentTrustedCAs(t *testing.T) {
+	root := issueCert(t, caTemplate("Private Root"), nil, nil)
+	intermediate := issueCert(t, caTemplate("Private Intermediate"), nil, root)
+	leaf := issueCert(t, certTemplate(), nil, intermediate)
+
+	rootPEM, _ := root.PEM(t)
+	intermediatePEM, _ := intermediate.PEM(t)
+
+	pool, err := checker.ParseCAPool(append(rootPEM, intermediatePEM...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.Len(t, pool.Roots, 1)
+	a.Len(t, pool.Intermediates, 1)
+
+	checker.Configure(checker.Config{CAs: pool, ReplaceSystemRoots: true})
+	t.Cleanup(func() { checker.Configure(checker.Config{}) })
+
+	server := startTLSServer(t, leaf.TLSCertificate(), nil)
+
+	c := checker.Init(buildCheck(server.URL))
+	c.Perform()
+
+	a.Equal(t, "", c.Res.Error)
+	a.True(t, c.Res.Success())
+}
+
+func TestTrustedIntermediatesRedirect(t *testing.T) {
+	root := issueCert(t, caTemplate("Private Root"), nil, nil)
+	intermediateA := issueCert(t, caTemplate("Intermediate A"), nil, root)
+	intermediateB := issueCert(t, caTemplate("Intermediate B"), nil, root)
+	rootPEM, _ := root.PEM(t)
+	intermediateAPEM, _ := intermediateA.PEM(t)
+	intermediateBPEM, _ := intermediateB.PEM(t)
+	bundlePEM := append(append(append([]byte{}, rootPEM...), intermediateAPEM...), intermediateBPEM...)
+
+	// A redirects to B, which redirects back to A on the pooled connection
+	var serverB *httptest.Server
+	serverA := startTLSServer(t, issueCert(t, certTemplate(), nil, intermediateA).TLSCertificate(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			http.Redirect(w, r, serverB.URL, http.StatusFound)
+		}
+	}))
+	serverB = startTLSServer(t, issueCert(t, certTemplate(), nil, intermediateB).TLSCertificate(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, serverA.URL+"/done", http.StatusFound)
+	}))
+
+	// A's certificate is checked against its own chain, not B's
+	req := buildCheck(serverA.URL)
+	req.Options.FollowRedirects = true
+	req.Options.TrustedCAs = types.TrustedCAs{PEM: bundlePEM}
+	req.Options.CertPins = types.CertPins{SPKISHA256: [][]byte{sha256Sum(intermediateA.Cert.RawSubjectPublicKeyInfo)}}
+
+	c := checker.Init(req)
+	c.Perform()
+	a.Equal(t, "", c.Res.Error)
+	a.Equal(t, serverA.URL+"/done", c.Res.URL.String())
+}
@@ -0,0 +1,111 @@
+package checker
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"strings"
+	"time"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+const day = 24 * time.Hour
+
+// signatureAlgorithms maps the names used in policies to the algorithms,
+// the names match x509.SignatureAlgorithm.String()
+var signatureAlgorithms = map[string]x509.SignatureAlgorithm{
+	"MD2-RSA":       x509.MD2WithRSA,
+	"MD5-RSA":       x509.MD5WithRSA,
+	"SHA1-RSA":      x509.SHA1WithRSA,
+	"SHA256-RSA":    x509.SHA256WithRSA,
+	"SHA384-RSA":    x509.SHA384WithRSA,
+	"SHA512-RSA":    x509.SHA512WithRSA,
+	"DSA-SHA1":      x509.DSAWithSHA1,
+	"DSA-SHA256":    x509.DSAWithSHA256,
+	"ECDSA-SHA1":    x509.ECDSAWithSHA1,
+	"ECDSA-SHA256":  x509.ECDSAWithSHA256,
+	"ECDSA-SHA384":  x509.ECDSAWithSHA384,
+	"ECDSA-SHA512":  x509.ECDSAWithSHA512,
+	"SHA256-RSAPSS": x509.SHA256WithRSAPSS,
+	"SHA384-RSAPSS": x509.SHA384WithRSAPSS,
+	"SHA512-RSAPSS": x509.SHA512WithRSAPSS,
+	"Ed25519":       x509.PureEd25519,
+}
+
+// checkCertPolicy verifies the certificate against the policy in the check
+// options. Warnings are added to the result, and the first policy violation
+// is returned.
+func (c *Checker) checkCertPolicy(cert *x509.Certificate) types.CheckError {
+	policy := c.Req.Options.CertPolicy
+
+	var forbidden []x509.SignatureAlgorithm
+	for _, name := range policy.ForbiddenSignatureAlgorithms {
+		algorithm, ok := signatureAlgorithms[name]
+		if !ok {
+			return types.CertPolicyInvalid
+		}
+		forbidden = append(forbidden, algorithm)
+	}
+
+	if policy.ExpiryWarningDays > 0 {
+		warnAt := cert.NotAfter.Add(-time.Duration(policy.ExpiryWarningDays) * day)
+		if time.Now().After(warnAt) {
+			c.addWarning(types.CertExpiringSoon)
+		}
+	}
+
+	if !keySizeAllowed(cert, policy) {
+		return types.CertWeakKey
+	}
+
+	for _, algorithm := range forbidden {
+		if cert.SignatureAlgorithm == algorithm {
+			return types.CertWeakSignature
+		}
+	}
+
+	if policy.RequiredIssuer != "" && !issuedBy(cert, policy.RequiredIssuer) {
+		return types.CertWrongIssuer
+	}
+
+	if policy.MaxValidityDays > 0 {
+		validity := cert.NotAfter.Sub(cert.NotBefore)
+		if validity > time.Duration(policy.MaxValidityDays)*day {
+			return types.CertValidityTooLong
+		}
+	}
+
+	return ""
+}
+
+func keySizeAllowed(cert *x509.Certificate, policy types.CertPolicy) bool {
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return key.N.BitLen() >= policy.MinRSAKeySize
+
+	case *ecdsa.PublicKey:
+		return key.Curve.Params().BitSize >= policy.MinECDSAKeySize
+
+	default:
+		return true
+	}
+}
+
+func issuedBy(cert *x509.Certificate, issuer string) bool {
+	if strings.EqualFold(cert.Issuer.CommonName, issuer) {
+		return true
+	}
+
+	for _, org := range cert.Issuer.Organization {
+		if strings.EqualFold(org, issuer) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Checker) addWarning(warning types.CheckError) {
+	c.Res.Warnings = append(c.Res.Warnings, warning.ToString())
+}
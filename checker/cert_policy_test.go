@@ -0,0 +1,124 @@
+package checker_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	a "github.com/stretchr/testify/assert"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+func TestCertPolicy(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expiring := certTemplate()
+	expiring.NotAfter = time.Now().Add(10 * 24 * time.Hour)
+
+	tests := []struct {
+		name     string
+		cert     *testCert
+		policy   types.CertPolicy
+		success  bool
+		error    string
+		warnings []string
+	}{
+		{
+			name:    "no policy",
+			cert:    issueCert(t, certTemplate(), nil, ca),
+			success: true,
+		},
+		{
+			name:     "expiring soon",
+			cert:     issueCert(t, expiring, nil, ca),
+			policy:   types.CertPolicy{ExpiryWarningDays: 14},
+			success:  true,
+			warnings: []string{types.CertExpiringSoon.ToString()},
+		},
+		{
+			name:    "not expiring soon",
+			cert:    issueCert(t, expiring, nil, ca),
+			policy:  types.CertPolicy{ExpiryWarningDays: 7},
+			success: true,
+		},
+		{
+			name:    "weak rsa key",
+			cert:    issueCert(t, certTemplate(), rsaKey, ca),
+			policy:  types.CertPolicy{MinRSAKeySize: 4096},
+			success: false,
+			error:   types.CertWeakKey.ToString(),
+		},
+		{
+			name:    "weak ecdsa key",
+			cert:    issueCert(t, certTemplate(), nil, ca),
+			policy:  types.CertPolicy{MinRSAKeySize: 4096, MinECDSAKeySize: 384},
+			success: false,
+			error:   types.CertWeakKey.ToString(),
+		},
+		{
+			name:    "forbidden signature",
+			cert:    issueCert(t, certTemplate(), nil, ca),
+			policy:  types.CertPolicy{ForbiddenSignatureAlgorithms: []string{"SHA1-RSA", "ECDSA-SHA256"}},
+			success: false,
+			error:   types.CertWeakSignature.ToString(),
+		},
+		{
+			name:    "allowed signature",
+			cert:    issueCert(t, certTemplate(), nil, ca),
+			policy:  types.CertPolicy{ForbiddenSignatureAlgorithms: []string{"SHA1-RSA", "ECDSA-SHA1"}},
+			success: true,
+		},
+		{
+			name:    "unknown signature algorithm",
+			cert:    issueCert(t, certTemplate(), nil, ca),
+			policy:  types.CertPolicy{ForbiddenSignatureAlgorithms: []string{"SHA1WithRSA"}},
+			success: false,
+			error:   types.CertPolicyInvalid.ToString(),
+		},
+		{
+			name:    "required issuer",
+			cert:    issueCert(t, certTemplate(), nil, ca),
+			policy:  types.CertPolicy{RequiredIssuer: "WatchSumo Test"},
+			success: true,
+		},
+		{
+			name:    "wrong issuer",
+			cert:    issueCert(t, certTemplate(), nil, ca),
+			policy:  types.CertPolicy{RequiredIssuer: "Let's Encrypt"},
+			success: false,
+			error:   types.CertWrongIssuer.ToString(),
+		},
+		{
+			name:    "validity too long",
+			cert:    issueCert(t, certTemplate(), nil, ca),
+			policy:  types.CertPolicy{MaxValidityDays: 30},
+			success: false,
+			error:   types.CertValidityTooLong.ToString(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := startTLSServer(t, tt.cert.TLSCertificate(ca), nil)
+
+			req := buildCheck(server.URL)
+			req.Options.IgnoreTLSErrors = true
+			req.Options.CertPolicy = tt.policy
+
+			c := checker.Init(req)
+			c.Perform()
+
+			a.Equal(t, tt.success, c.Success)
+			a.Equal(t, tt.error, c.Res.Error)
+			a.Equal(t, tt.warnings, c.Res.Warnings)
+		})
+	}
+}
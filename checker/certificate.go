@@ -10,6 +10,54 @@ import (
 	"github.com/lucaspiller/watchsumo-checker/types"
 )
 
+// checkCertificate performs the optional checks on the certificate presented
+// by the server, and returns the first failure
+func (c *Checker) checkCertificate(state *tls.ConnectionState) types.CheckError {
+	cert := state.PeerCertificates[0]
+
+	if len(state.VerifiedChains) == 0 {
+		if chains := c.verifiedChains.lookup(cert); chains != nil {
+			verified := *state
+			verified.VerifiedChains = chains
+			state = &verified
+		}
+	}
+
+	c.detectCertChange(cert)
+
+	if err := c.checkCertPins(state); err != "" {
+		return err
+	}
+
+	if err := c.checkCertPolicy(cert); err != "" {
+		return err
+	}
+
+	if minSCTs := c.Req.Options.CertPolicy.MinSCTs; c.Req.Options.CheckSCTs || minSCTs > 0 {
+		c.Res.Certificate.SCTs = extractSCTs(state)
+
+		if countSCTLogs(c.Res.Certificate.SCTs) < minSCTs {
+			return types.CertInsufficientSCTs
+		}
+	}
+
+	if c.Req.Options.CheckRevocation {
+		status, source := c.checkRevocation(state)
+		c.Res.Certificate.RevocationStatus = status
+		c.Res.Certificate.RevocationSource = source
+
+		switch status {
+		case types.RevocationRevoked:
+			return types.CertRevoked
+
+		case types.RevocationUnknown:
+			c.addWarning(types.CertRevocationUnknown)
+		}
+	}
+
+	return ""
+}
+
 func certInfoFromTLSConnectionState(tls *tls.ConnectionState) *types.CertInfo {
 	return certInfoFromCert(tls.PeerCertificates[0])
 }
@@ -28,6 +76,7 @@ func certInfoFromCert(cert *x509.Certificate) *types.CertInfo {
 		ValidTo:           cert.NotAfter,
 		Algorithm:         int(cert.SignatureAlgorithm),
 		FingerprintSHA256: extractFingerprintSHA256(cert),
+		SPKISHA256:        extractSPKISHA256(cert),
 	}
 }
 
@@ -70,3 +119,8 @@ func extractFingerprintSHA256(cert *x509.Certificate) []byte {
 	signature := sha256.Sum256(cert.Raw)
 	return signature[:]
 }
+
+func extractSPKISHA256(cert *x509.Certificate) []byte {
+	signature := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return signature[:]
+}
@@ -1,13 +1,7 @@
 package checker
 
 import (
-	"context"
-	"crypto/tls"
-	"errors"
 	"fmt"
-	"io/ioutil"
-	"net/http"
-	"net/http/httptrace"
 	"time"
 
 	"github.com/lucaspiller/watchsumo-checker/metrics"
@@ -15,12 +9,7 @@ import (
 )
 
 const (
-	maxTimeout   = 30 * time.Second
-	maxRedirects = 5
-)
-
-var (
-	errMaxRedirects = errors.New("Redirect limited exceeded")
+	maxTimeout = 30 * time.Second
 )
 
 // Checker engine
@@ -32,6 +21,17 @@ type Checker struct {
 	Res *types.CheckResult
 
 	start time.Time
+
+	// Whether the server requested a client certificate
+	clientCertRequested bool
+
+	// Chains verified with the additional intermediates, as these aren't
+	// in the connection state
+	verifiedChains verifiedChains
+
+	// Set for each family of a dual-stack check, the metrics are recorded
+	// once for the overall result
+	skipMetrics bool
 }
 
 // Init initializers the checker
@@ -49,164 +49,64 @@ func Init(req *types.CheckRequest) *Checker {
 
 // Perform performs a check against a service.
 func (c *Checker) Perform() bool {
-	// Verify scheme is correct
-	if c.Req.URL.Scheme != "http" && c.Req.URL.Scheme != "https" {
-		message := fmt.Sprintf("Unsupported schema %s", c.Req.URL.Scheme)
-		return c.handleFailure(message, types.UnsupportedSchema.ToString())
-	}
+	// Secrets may have been echoed by the server, or used in URLs
+	defer c.redactResult()
 
-	// Create transport
-	// The transport is used for all requests for this check, so
-	// if there are any Keep-Alives the connection will be reused.
-	transport := http.DefaultTransport.(*http.Transport).Clone()
-	defer transport.CloseIdleConnections()
-
-	// Ignore TLS errors
-	if c.Req.Options.IgnoreTLSErrors {
-		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	// Check each address family separately
+	if c.Req.Options.IPFamily == types.IPFamilyBoth {
+		return c.performBothFamilies()
 	}
 
-	// Create HTTP client
-	client := &http.Client{
-		Transport: transport,
-
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			// Enable/disable redirects
-			if !c.Req.Options.FollowRedirects {
-				return http.ErrUseLastResponse
-			}
+	// Find the implementation for the check type
+	name := c.checkType()
 
-			// Check if redirect limit has been exceeded
-			if len(via) > maxRedirects {
-				return errMaxRedirects
-			}
-
-			return nil
-		},
-	}
-	defer client.CloseIdleConnections()
-
-	// Convert duration
-	timeout := c.Req.Timeout
-	if c.Req.Timeout > maxTimeout {
-		timeout = maxTimeout
-	}
-	client.Timeout = timeout
-
-	// Create request
-	req, err := http.NewRequest(c.Req.Method, c.Req.URL.String(), nil)
-	if err != nil {
-		// Fallback to GET if a HEAD request fails
-		if c.Req.Method == "HEAD" && c.Req.Options.GetFallback {
-			return c.performGetFallback()
+	checkType, ok := lookupCheckType(name)
+	if !ok {
+		if c.Req.Type != "" {
+			message := fmt.Sprintf("Unsupported check type %s", name)
+			return c.handleFailure(message, types.UnsupportedCheckType.ToString())
 		}
 
-		return c.handleError("Unable to create request", err)
-	}
-
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/71.0.3578.80 Safari/537.36 (compatible; WatchSumo; +https://www.watchsumo.com)")
-	req.Header.Set("Accept", "text/html,*/*;q=0.5")
-	req.Header.Set("Accept-Charset", "utf-8,iso-8859-1;q=0.5")
-
-	// Instrument the request, and extract timings at various points
-	var t0, t1, t2, t3, t4, t5, t6, t7, t8 time.Time
-
-	// Create trace
-	trace := &httptrace.ClientTrace{
-		DNSStart:             func(_ httptrace.DNSStartInfo) { t0 = time.Now() },
-		DNSDone:              func(_ httptrace.DNSDoneInfo) { t1 = time.Now() },
-		ConnectStart:         func(_, _ string) { t2 = time.Now() },
-		ConnectDone:          func(_, _ string, _ error) { t3 = time.Now() },
-		TLSHandshakeStart:    func() { t4 = time.Now() },
-		TLSHandshakeDone:     func(_ tls.ConnectionState, _ error) { t5 = time.Now() },
-		GotConn:              func(_ httptrace.GotConnInfo) { t6 = time.Now() },
-		WroteHeaders:         func() { t7 = time.Now() },
-		GotFirstResponseByte: func() { t8 = time.Now() },
+		message := fmt.Sprintf("Unsupported schema %s", name)
+		return c.handleFailure(message, types.UnsupportedSchema.ToString())
 	}
 
-	req = req.WithContext(httptrace.WithClientTrace(context.Background(), trace))
-
-	// Perform request
-	resp, err := client.Do(req)
-	if err != nil {
-		// Fallback to GET if a HEAD request fails
-		if c.Req.Method == "HEAD" && c.Req.Options.GetFallback {
-			return c.performGetFallback()
-		}
+	return checkType.Perform(c)
+}
 
-		return c.handleError("Error making request", err)
+// timeout returns the request timeout, limited to the maximum. Requests
+// without a timeout use the maximum, so connections and queries with a
+// deadline don't expire immediately.
+func (c *Checker) timeout() time.Duration {
+	if c.Req.Timeout <= 0 || c.Req.Timeout > maxTimeout {
+		return maxTimeout
 	}
 
-	// Read body
-	respBody, err := ioutil.ReadAll(resp.Body)
-	defer resp.Body.Close()
-	if err != nil {
-		// Fallback to GET if a HEAD request fails
-		if c.Req.Method == "HEAD" && c.Req.Options.GetFallback {
-			return c.performGetFallback()
-		}
+	return c.Req.Timeout
+}
 
-		return c.handleError("Error reading response body", err)
-	}
+func (c *Checker) handleSuccess() bool {
+	return c.handleSuccessSince(c.start)
+}
 
-	t9 := time.Now() // after body has been fully read
-	if t0.IsZero() {
-		// we skipped the DNS lookup (it's an IP)
-		t0 = t2
+// handleSuccessSince records a successful check, with the total time measured
+// from start rather than the start of the check
+func (c *Checker) handleSuccessSince(start time.Time) bool {
+	if start.IsZero() {
+		start = c.start
 	}
 
-	dns := t1.Sub(t0).Truncate(time.Millisecond)
-	connecting := t3.Sub(t2).Truncate(time.Millisecond)
+	now := time.Now()
+	total := now.Sub(start).Truncate(time.Millisecond)
 
-	// TLS handshake may have been skipped (existing TLS connection reused?)
-	var tls time.Duration
-	if !t4.IsZero() && !t5.IsZero() {
-		tls = t5.Sub(t4).Truncate(time.Millisecond)
-	}
+	c.recordMetrics(types.StatusUp, total)
 
-	sending := t7.Sub(t6).Truncate(time.Millisecond)
-	waiting := t8.Sub(t7).Truncate(time.Millisecond)
-	receiving := t9.Sub(t8).Truncate(time.Millisecond)
-	total := t9.Sub(t1).Truncate(time.Millisecond)
-
-	c.Res.Timing = &types.RequestTiming{
-		DNS:        &dns,
-		Connecting: &connecting,
-		TLS:        &tls,
-		Sending:    &sending,
-		Waiting:    &waiting,
-		Receiving:  &receiving,
-	}
 	c.Res.Time = &total
-	c.Res.Timestamp = &t9
-
-	if resp.TLS != nil {
-		c.Res.Certificate = certInfoFromTLSConnectionState(resp.TLS)
-	}
-
-	c.Res.Proto = resp.Proto
-	c.Res.StatusText = resp.Status
-	c.Res.StatusCode = resp.StatusCode
-	c.Res.URL = resp.Request.URL // Update url if we were redirected
-	c.Res.Headers = resp.Header
-	c.Res.Body = string(respBody)
-
-	// Check status code
-	if resp.StatusCode != 200 && resp.StatusCode != 203 {
-		// Fallback to GET if a HEAD request fails
-		if c.Req.Method == "HEAD" && c.Req.Options.GetFallback {
-			return c.performGetFallback()
-		}
-
-		return c.handleFailure("Unsuccessful status code", fmt.Sprintf("%d", resp.StatusCode))
-	}
+	c.Res.Timestamp = &now
 
 	c.Res.Status = types.StatusUp
 	c.Success = true
 
-	metrics.AddUp()
-	metrics.AddCheckTime(total)
-
 	return true
 }
 
@@ -214,8 +114,7 @@ func (c *Checker) handleError(message string, err error) bool {
 	now := time.Now()
 	total := now.Sub(c.start)
 
-	metrics.AddDown()
-	metrics.AddCheckTime(total)
+	c.recordMetrics(types.StatusDown, total)
 
 	c.Res.Time = &total
 	c.Res.Timestamp = &now
@@ -233,8 +132,7 @@ func (c *Checker) handleFailure(message, errorcode string) bool {
 	now := time.Now()
 	total := now.Sub(c.start)
 
-	metrics.AddDown()
-	metrics.AddCheckTime(total)
+	c.recordMetrics(types.StatusDown, total)
 
 	c.Res.Time = &total
 	c.Res.Timestamp = &now
@@ -246,6 +144,41 @@ func (c *Checker) handleFailure(message, errorcode string) bool {
 	return false
 }
 
+// handleUnknown records a result where the target responded, but its status
+// couldn't be determined
+func (c *Checker) handleUnknown(message, errorcode string) bool {
+	now := time.Now()
+	total := now.Sub(c.start)
+
+	c.recordMetrics(types.StatusUnknown, total)
+
+	c.Res.Time = &total
+	c.Res.Timestamp = &now
+
+	c.Res.Error = errorcode
+	c.Res.Status = types.StatusUnknown
+	c.Success = false
+
+	return false
+}
+
+// recordMetrics records the result of the check in the agent metrics
+func (c *Checker) recordMetrics(status types.CheckStatus, total time.Duration) {
+	if c.skipMetrics {
+		return
+	}
+
+	switch status {
+	case types.StatusUp:
+		metrics.AddUp()
+	case types.StatusUnknown:
+		metrics.AddUnknown()
+	default:
+		metrics.AddDown()
+	}
+	metrics.AddCheckTime(total)
+}
+
 func (c *Checker) performGetFallback() bool {
 	c.Req.Method = "GET"
 	c.Res = &types.CheckResult{
@@ -0,0 +1,72 @@
+package checker
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// LoadClientCertificates loads named client certificates from a directory.
+// Each certificate is stored in <name>.crt with the key in <name>.key.
+func LoadClientCertificates(dir string) (map[string]tls.Certificate, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	certs := map[string]tls.Certificate{}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".crt" {
+			continue
+		}
+
+		name := strings.TrimSuffix(file.Name(), ".crt")
+		cert, err := tls.LoadX509KeyPair(filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key"))
+		if err != nil {
+			return nil, err
+		}
+
+		certs[name] = cert
+	}
+
+	return certs, nil
+}
+
+// clientCertificate returns the client certificate for the check, or nil if
+// the check doesn't use one
+func (c *Checker) clientCertificate() (*tls.Certificate, types.CheckError) {
+	opts := c.Req.Options.ClientCertificate
+
+	if opts.Name != "" {
+		cert, ok := agentConfig.ClientCertificates[opts.Name]
+		if !ok {
+			log.WithFields(log.Fields{
+				"Ref":  c.Req.Ref,
+				"Name": opts.Name,
+			}).Warn("Unknown client certificate")
+			return nil, types.ClientCertInvalid
+		}
+
+		return &cert, ""
+	}
+
+	if len(opts.CertPEM) > 0 || len(opts.KeyPEM) > 0 {
+		cert, err := tls.X509KeyPair(opts.CertPEM, opts.KeyPEM)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"Ref": c.Req.Ref,
+				"Err": err,
+			}).Debug("Invalid client certificate")
+			return nil, types.ClientCertInvalid
+		}
+
+		return &cert, ""
+	}
+
+	return nil, ""
+}
@@ -0,0 +1,90 @@
+package checker_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	a "github.com/stretchr/testify/assert"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+func TestClientCertificate(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+	serverCert := issueCert(t, certTemplate(), nil, ca)
+
+	clientCA := issueCert(t, caTemplate("Client CA"), nil, nil)
+	clientTemplate := certTemplate()
+	clientTemplate.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	clientCert := issueCert(t, clientTemplate, nil, clientCA)
+	clientCertPEM, clientKeyPEM := clientCert.PEM(t)
+
+	otherCA := issueCert(t, caTemplate("Other CA"), nil, nil)
+	otherCert := issueCert(t, clientTemplate, nil, otherCA)
+	otherCertPEM, otherKeyPEM := otherCert.PEM(t)
+
+	// Named certificates stored on the agent
+	dir := t.TempDir()
+	ioutil.WriteFile(filepath.Join(dir, "internal.crt"), clientCertPEM, 0600)
+	ioutil.WriteFile(filepath.Join(dir, "internal.key"), clientKeyPEM, 0600)
+
+	certs, err := checker.LoadClientCertificates(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checker.Configure(checker.Config{ClientCertificates: certs})
+	t.Cleanup(func() { checker.Configure(checker.Config{}) })
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCA.Cert)
+
+	tests := []struct {
+		name    string
+		cert    types.ClientCertificate
+		success bool
+		error   string
+	}{
+		{"none", types.ClientCertificate{}, false, types.ClientCertRejected.ToString()},
+		{"inline", types.ClientCertificate{CertPEM: clientCertPEM, KeyPEM: clientKeyPEM}, true, ""},
+		{"named", types.ClientCertificate{Name: "internal"}, true, ""},
+		{"untrusted", types.ClientCertificate{CertPEM: otherCertPEM, KeyPEM: otherKeyPEM}, false, types.ClientCertRejected.ToString()},
+		{"unknown name", types.ClientCertificate{Name: "missing"}, false, types.ClientCertInvalid.ToString()},
+		{"invalid", types.ClientCertificate{CertPEM: clientCertPEM, KeyPEM: otherKeyPEM}, false, types.ClientCertInvalid.ToString()},
+	}
+
+	for _, version := range []uint16{tls.VersionTLS12, tls.VersionTLS13} {
+		server := httptest.NewUnstartedServer(nil)
+		server.TLS = &tls.Config{
+			Certificates: []tls.Certificate{serverCert.TLSCertificate(ca)},
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			ClientCAs:    clientCAs,
+			MaxVersion:   version,
+		}
+		server.StartTLS()
+		defer server.Close()
+
+		for _, tt := range tests {
+			t.Run(tls.VersionName(version)+" "+tt.name, func(t *testing.T) {
+				req := buildCheck(server.URL + "/status/404")
+				req.Options.IgnoreTLSErrors = true
+				req.Options.ClientCertificate = tt.cert
+
+				c := checker.Init(req)
+				c.Perform()
+
+				// The server returns 404, so a successful handshake fails
+				// with the status code
+				if tt.success {
+					a.Equal(t, "404", c.Res.Error)
+				} else {
+					a.Equal(t, tt.error, c.Res.Error)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package checker
+
+import (
+	"crypto/tls"
+
+	"github.com/miekg/dns"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// Config is the agent wide configuration used by all checks
+type Config struct {
+	// Certificate Transparency logs trusted when verifying SCTs
+	CTLogs []*CTLog
+
+	// Named client certificates which checks can reference
+	ClientCertificates map[string]tls.Certificate
+
+	// Named secrets, such as credentials, which checks can reference
+	Secrets map[string]string
+
+	// Proxy used by all checks, unless the check has its own
+	Proxy types.Proxy
+
+	// Local IP address or network interface connections are made from,
+	// unless the check has its own
+	BindAddress string
+
+	// Additional certificate authorities trusted by all checks
+	CAs *CAPool
+
+	// Only trust the additional certificate authorities, not the system roots
+	ReplaceSystemRoots bool
+
+	// Nameservers used instead of the system resolver, unless the check has
+	// its own
+	Resolver types.Resolver
+
+	// DS or DNSKEY records where DNSSEC validation starts, instead of the
+	// root zone trust anchors
+	TrustAnchors []dns.RR
+}
+
+var (
+	agentConfig Config
+)
+
+// Configure sets the agent wide configuration, this should be called before
+// any checks are performed
+func Configure(c Config) {
+	agentConfig = c
+	redactor = newRedactor(c.Secrets)
+}
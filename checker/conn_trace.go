@@ -0,0 +1,104 @@
+package checker
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http/httptrace"
+	"time"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// connTrace records the time spent looking up the address, connecting, and
+// performing the TLS handshake, for checks which make their own connection
+type connTrace struct {
+	dnsStart, dnsDone         time.Time
+	connectStart, connectDone time.Time
+	tlsStart, tlsDone         time.Time
+
+	proxy *proxyTrace
+}
+
+// withConnTrace returns a context which records the connection when dialing
+// with it
+func withConnTrace(ctx context.Context) (context.Context, *connTrace) {
+	t := &connTrace{}
+	ctx, t.proxy = withProxyTrace(ctx)
+
+	trace := &httptrace.ClientTrace{
+		DNSStart:          func(_ httptrace.DNSStartInfo) { t.dnsStart = time.Now() },
+		DNSDone:           func(_ httptrace.DNSDoneInfo) { t.dnsDone = time.Now() },
+		ConnectStart:      func(_, _ string) { t.connectStart = time.Now() },
+		ConnectDone:       func(_, _ string, _ error) { t.connectDone = time.Now() },
+		TLSHandshakeStart: func() { t.tlsStart = time.Now() },
+		TLSHandshakeDone:  func(_ tls.ConnectionState, _ error) { t.tlsDone = time.Now() },
+	}
+
+	return httptrace.WithClientTrace(ctx, trace), t
+}
+
+// handshake performs the TLS handshake on the connection, recording the time
+// taken
+func (t *connTrace) handshake(conn net.Conn, config *tls.Config) (*tls.Conn, error) {
+	t.tlsStart = time.Now()
+	tlsConn := tls.Client(conn, config)
+	if err := tlsConn.Handshake(); err != nil {
+		return nil, err
+	}
+	t.tlsDone = time.Now()
+
+	return tlsConn, nil
+}
+
+// tlsDuration returns the time taken by the TLS handshake, or zero if there
+// wasn't one
+func (t *connTrace) tlsDuration() time.Duration {
+	if t.tlsStart.IsZero() || t.tlsDone.IsZero() {
+		return 0
+	}
+
+	return t.tlsDone.Sub(t.tlsStart)
+}
+
+// start returns the time the total is measured from, after the DNS lookup
+// the same as for HTTP checks
+func (t *connTrace) start() time.Time {
+	if !t.dnsDone.IsZero() {
+		return t.dnsDone
+	}
+
+	return t.connectStart
+}
+
+// ready returns the time the connection was ready to send the request, after
+// the TLS handshake if there was one
+func (t *connTrace) ready() time.Time {
+	if !t.tlsDone.IsZero() {
+		return t.tlsDone
+	}
+
+	return t.connectDone
+}
+
+// timing returns the timings of the connection. The remaining phases depend
+// on the protocol, so are filled in by the check.
+func (t *connTrace) timing() *types.RequestTiming {
+	dnsStart, dnsDone := t.dnsStart, t.dnsDone
+	if dnsStart.IsZero() {
+		// we skipped the DNS lookup (it's an IP, or the address was overridden)
+		dnsStart = t.connectStart
+		dnsDone = t.connectStart
+	}
+
+	dns := dnsDone.Sub(dnsStart).Truncate(time.Millisecond)
+	connecting := t.connectDone.Sub(t.connectStart).Truncate(time.Millisecond)
+	tlsTime := t.tlsDuration().Truncate(time.Millisecond)
+
+	return &types.RequestTiming{
+		DNS:        &dns,
+		Connecting: &connecting,
+		Proxy:      t.proxy.timing(),
+		TLS:        &tlsTime,
+	}
+}
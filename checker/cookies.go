@@ -0,0 +1,103 @@
+package checker
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"time"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// recordingJar records the cookies set by each response in the result, and
+// if enabled stores them to be sent with later requests
+type recordingJar struct {
+	checker *Checker
+
+	// Underlying jar, nil if cookies aren't stored
+	jar http.CookieJar
+}
+
+// newCookieJar creates a jar for the check, with the cookies from the request
+func (c *Checker) newCookieJar(enabled bool) (http.CookieJar, types.CheckError) {
+	res := &recordingJar{checker: c}
+
+	if enabled {
+		cookies, err := c.requestCookies()
+		if err != nil {
+			return nil, types.SecretNotFound
+		}
+
+		res.jar, _ = cookiejar.New(nil)
+		res.jar.SetCookies(c.Req.URL, cookies)
+	}
+
+	return res, ""
+}
+
+func (j *recordingJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	for _, cookie := range cookies {
+		j.checker.Res.Cookies = append(j.checker.Res.Cookies, setCookieInfo(u, cookie))
+	}
+
+	if j.jar != nil {
+		j.jar.SetCookies(u, cookies)
+	}
+}
+
+func (j *recordingJar) Cookies(u *url.URL) []*http.Cookie {
+	if j.jar == nil {
+		return nil
+	}
+
+	return j.jar.Cookies(u)
+}
+
+// requestCookies returns the cookies to send with the request, with secrets
+// in the values replaced
+func (c *Checker) requestCookies() ([]*http.Cookie, error) {
+	var res []*http.Cookie
+
+	for _, cookie := range c.Req.Options.Cookies {
+		value, err := expandSecrets(cookie.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, &http.Cookie{
+			Name:  cookie.Name,
+			Value: value,
+			Path:  "/",
+		})
+	}
+
+	return res, nil
+}
+
+func setCookieInfo(u *url.URL, cookie *http.Cookie) types.SetCookie {
+	res := types.SetCookie{
+		URL:      u.String(),
+		Name:     cookie.Name,
+		Domain:   cookie.Domain,
+		Path:     cookie.Path,
+		MaxAge:   cookie.MaxAge,
+		Secure:   cookie.Secure,
+		HTTPOnly: cookie.HttpOnly,
+	}
+
+	if !cookie.Expires.IsZero() {
+		expires := cookie.Expires.In(time.UTC)
+		res.Expires = &expires
+	}
+
+	switch cookie.SameSite {
+	case http.SameSiteLaxMode:
+		res.SameSite = "Lax"
+	case http.SameSiteStrictMode:
+		res.SameSite = "Strict"
+	case http.SameSiteNoneMode:
+		res.SameSite = "None"
+	}
+
+	return res
+}
@@ -0,0 +1,99 @@
+package checker_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	a "github.com/stretchr/testify/assert"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// startConsentServer starts a server which redirects to a consent page until
+// the consent cookie is sent, which loops if cookies aren't stored
+func startConsentServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/consent", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "consent", Value: "yes", Path: "/", MaxAge: 3600, HttpOnly: true, SameSite: http.SameSiteLaxMode})
+		http.Redirect(w, r, "/", http.StatusFound)
+	})
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if _, err := r.Cookie("consent"); err != nil {
+			http.Redirect(w, r, "/consent", http.StatusFound)
+			return
+		}
+
+		if cookie, err := r.Cookie("region"); err == nil {
+			w.Header().Set("X-Region", cookie.Value)
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestCookieJar(t *testing.T) {
+	server := startConsentServer(t)
+
+	req := buildCheck(server.URL)
+	req.Options.CookieJar = true
+
+	c := checker.Init(req)
+	c.Perform()
+
+	a.Equal(t, "", c.Res.Error)
+	a.Equal(t, 200, c.Res.StatusCode)
+	a.Equal(t, server.URL+"/", c.Res.URL.String())
+
+	if a.Len(t, c.Res.Cookies, 1) {
+		cookie := c.Res.Cookies[0]
+		a.Equal(t, server.URL+"/consent", cookie.URL)
+		a.Equal(t, "consent", cookie.Name)
+		a.Equal(t, "/", cookie.Path)
+		a.Equal(t, 3600, cookie.MaxAge)
+		a.Nil(t, cookie.Expires)
+		a.True(t, cookie.HTTPOnly)
+		a.False(t, cookie.Secure)
+		a.Equal(t, "Lax", cookie.SameSite)
+	}
+}
+
+func TestCookieJarDisabled(t *testing.T) {
+	server := startConsentServer(t)
+
+	req := buildCheck(server.URL)
+
+	c := checker.Init(req)
+	c.Perform()
+
+	a.Equal(t, types.MaxRedirects.ToString(), c.Res.Error)
+
+	// Cookies set by redirects are still recorded
+	a.NotEmpty(t, c.Res.Cookies)
+}
+
+func TestRequestCookies(t *testing.T) {
+	server := startConsentServer(t)
+
+	cookies := []types.Cookie{{Name: "consent", Value: "yes"}, {Name: "region", Value: "eu"}}
+
+	for _, jar := range []bool{false, true} {
+		req := buildCheck(server.URL)
+		req.Options.CookieJar = jar
+		req.Options.Cookies = cookies
+
+		c := checker.Init(req)
+		c.Perform()
+
+		a.Equal(t, "", c.Res.Error)
+		a.Equal(t, []string{"eu"}, c.Res.Headers["X-Region"])
+		a.Empty(t, c.Res.Cookies)
+		a.Empty(t, c.Res.Request.Headers.Values("Cookie"))
+	}
+}
@@ -0,0 +1,112 @@
+package checker
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dialContext connects to the target, recording the connection in the result
+func (c *Checker) dialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	conn, err := c.dial(ctx, network, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Res.SourceAddress = sourceAddress(conn)
+
+	// Record which family was used, which isn't otherwise obvious if the
+	// host has both IPv4 and IPv6 addresses
+	if c.proxy() == nil {
+		c.Res.Family = familyOfAddr(conn.RemoteAddr())
+	}
+
+	return conn, nil
+}
+
+// dial connects to the address, sending the connection to a different
+// address if there is a matching resolve override, and resolving the host
+// with the custom resolver if there is one. If there is a proxy the
+// connection is made through it, and if there is a bind address the
+// connection is made from it.
+func (c *Checker) dial(ctx context.Context, network, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{
+		Timeout:   c.timeout(),
+		KeepAlive: 30 * time.Second,
+	}
+
+	network = c.network(network)
+	addr = c.resolveOverride(addr)
+
+	if proxy := c.proxy(); proxy != nil {
+		return c.dialProxy(ctx, dialer, proxy, network, addr)
+	}
+
+	return c.dialResolved(ctx, dialer, network, addr)
+}
+
+// dialDirect connects to a host other than the target. The connection is
+// made through the proxy and from the bind address, as these are how the
+// agent reaches the network, but the check's resolve overrides, resolver and
+// IP family don't apply.
+func (c *Checker) dialDirect(ctx context.Context, network, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{
+		Timeout:   c.timeout(),
+		KeepAlive: 30 * time.Second,
+	}
+
+	if proxy := c.proxy(); proxy != nil {
+		return c.dialProxy(ctx, dialer, proxy, network, addr)
+	}
+
+	return c.dialFrom(ctx, dialer, network, addr)
+}
+
+// dialResolved resolves the host with the custom resolver if there is one,
+// and connects to the addresses in turn
+func (c *Checker) dialResolved(ctx context.Context, dialer *net.Dialer, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || net.ParseIP(host) != nil || !c.customResolver() {
+		return c.dialFrom(ctx, dialer, network, addr)
+	}
+
+	ips, err := c.lookupHost(ctx, network, host)
+	if err != nil {
+		return nil, &net.OpError{Op: "dial", Net: network, Err: err}
+	}
+
+	var conn net.Conn
+	for _, ip := range ips {
+		conn, err = c.dialFrom(ctx, dialer, network, net.JoinHostPort(ip, port))
+		if err == nil {
+			return conn, nil
+		}
+	}
+
+	return nil, err
+}
+
+// resolveOverride returns the address to connect to instead of addr, this
+// only changes where we connect, so the Host header and SNI are unaffected
+func (c *Checker) resolveOverride(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+
+	for _, override := range c.Req.Options.ResolveOverrides {
+		if !strings.EqualFold(override.Host, host) {
+			continue
+		}
+
+		if override.Port != 0 && strconv.Itoa(override.Port) != port {
+			continue
+		}
+
+		return net.JoinHostPort(override.Address, port)
+	}
+
+	return addr
+}
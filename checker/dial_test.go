@@ -0,0 +1,73 @@
+package checker_test
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	a "github.com/stretchr/testify/assert"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+func TestResolveOverride(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+	template := certTemplate()
+	template.DNSNames = []string{"origin.example.test"}
+	template.IPAddresses = nil
+	cert := issueCert(t, template, nil, ca)
+	caPEM, _ := ca.PEM(t)
+
+	var host, serverName string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host = r.Host
+		serverName = r.TLS.ServerName
+	}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{cert.TLSCertificate()}}
+	server.StartTLS()
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverURL.Port())
+	checkURL := "https://origin.example.test:" + serverURL.Port() + "/"
+
+	tests := []struct {
+		name      string
+		overrides []types.ResolveOverride
+		success   bool
+	}{
+		{"any port", []types.ResolveOverride{{Host: "origin.example.test", Address: "127.0.0.1"}}, true},
+		{"matching port", []types.ResolveOverride{{Host: "ORIGIN.example.test", Port: port, Address: "127.0.0.1"}}, true},
+		{"other port", []types.ResolveOverride{{Host: "origin.example.test", Port: port + 1, Address: "127.0.0.1"}}, false},
+		{"other host", []types.ResolveOverride{{Host: "www.example.test", Address: "127.0.0.1"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host, serverName = "", ""
+
+			req := buildCheck(checkURL)
+			req.Options.TrustedCAs = types.TrustedCAs{PEM: caPEM}
+			req.Options.ResolveOverrides = tt.overrides
+
+			c := checker.Init(req)
+			c.Perform()
+
+			a.Equal(t, tt.success, c.Success)
+			if tt.success {
+				a.Equal(t, "", c.Res.Error)
+				a.Equal(t, "origin.example.test:"+serverURL.Port(), host)
+				a.Equal(t, "origin.example.test", serverName)
+				a.Equal(t, time.Duration(0), *c.Res.Timing.DNS)
+				a.True(t, *c.Res.Time < time.Second)
+			} else {
+				a.NotEqual(t, "", c.Res.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,403 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+const (
+	defaultDNSRecordType = "A"
+)
+
+var (
+	// Record types which can be checked
+	dnsRecordTypes = map[string]uint16{
+		"A":     dns.TypeA,
+		"AAAA":  dns.TypeAAAA,
+		"CNAME": dns.TypeCNAME,
+		"MX":    dns.TypeMX,
+		"TXT":   dns.TypeTXT,
+		"NS":    dns.TypeNS,
+		"CAA":   dns.TypeCAA,
+		"SOA":   dns.TypeSOA,
+	}
+)
+
+// performDNSCheck queries DNS records for the host, rather than connecting
+// to it
+func (c *Checker) performDNSCheck() bool {
+	opts := c.Req.Options.DNSCheck
+
+	recordType := strings.ToUpper(opts.Type)
+	if recordType == "" {
+		recordType = defaultDNSRecordType
+	}
+
+	qtype, ok := dnsRecordTypes[recordType]
+	if !ok {
+		return c.handleFailure("Invalid record type", types.DNSInvalidRecordType.ToString())
+	}
+
+	if err := ValidateResolver(c.resolver()); err != nil {
+		return c.handleFailure("Invalid resolver configuration", types.ResolverInvalid.ToString())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout())
+	defer cancel()
+
+	name := dns.Fqdn(c.Req.URL.Hostname())
+	report := &types.DNSReport{
+		Name: strings.TrimSuffix(name, "."),
+		Type: recordType,
+	}
+	c.Res.DNSReport = report
+
+	start := time.Now()
+	resp, ns, err := c.query(ctx, name, qtype)
+	dnsTime := time.Since(start).Truncate(time.Millisecond)
+	c.Res.Timing = &types.RequestTiming{DNS: &dnsTime}
+
+	if err != nil {
+		return c.handleError("DNS query failed", err)
+	}
+
+	report.Resolver = ns.String()
+	report.Records = recordValues(resp.Answer, qtype)
+
+	switch {
+	case resp.Rcode == dns.RcodeNameError:
+		return c.handleFailure("Domain not found", types.NxDomain.ToString())
+
+	case resp.Rcode != dns.RcodeSuccess:
+		return c.handleFailure("DNS query failed", types.DNSError.ToString())
+
+	case len(report.Records) == 0:
+		return c.handleFailure("No records found", types.DNSNoRecords.ToString())
+	}
+
+	if opts.DNSSEC {
+		if err := c.validateDNSSEC(ctx, name, qtype, report); err != "" {
+			return c.handleFailure("DNSSEC validation failed", err.ToString())
+		}
+	}
+
+	if !recordsMatch(report.Records, opts.Expected, opts.ExactMatch) {
+		return c.handleFailure("Records don't match", types.DNSRecordMismatch.ToString())
+	}
+
+	if opts.CheckNameservers || opts.CheckSOASerial {
+		if err := c.checkAuthoritativeNameservers(ctx, name, qtype, report); err != "" {
+			return c.handleFailure("Authoritative nameserver check failed", err.ToString())
+		}
+	}
+
+	return c.handleSuccess()
+}
+
+// query sends the question to the resolver's nameservers, or the system
+// nameservers, in turn until one answers without a server failure
+func (c *Checker) query(ctx context.Context, name string, qtype uint16) (*dns.Msg, *nameserver, error) {
+	msg := new(dns.Msg)
+	msg.SetQuestion(name, qtype)
+
+	return c.queryMsg(ctx, msg)
+}
+
+func (c *Checker) queryMsg(ctx context.Context, msg *dns.Msg) (*dns.Msg, *nameserver, error) {
+	nameservers := c.resolver().Nameservers
+	if len(nameservers) == 0 {
+		nameservers = systemNameservers()
+	}
+
+	name := msg.Question[0].Name
+	dnsErr := &net.DNSError{Name: name, Err: "no nameservers"}
+
+	// A nameserver which fails or refuses to answer may not be able to reach
+	// the zone, so the next is tried. If none answer, the failure is returned
+	// rather than an error.
+	var failed *dns.Msg
+	var failedNS *nameserver
+
+	for _, s := range nameservers {
+		ns, err := parseNameserver(s)
+		if err != nil {
+			continue
+		}
+
+		resp, err := c.exchange(ctx, ns, msg.Copy(), c.resolverTimeout())
+		if err != nil {
+			dnsErr = &net.DNSError{Name: name, Server: ns.String(), Err: err.Error(), IsTimeout: isTimeout(err)}
+			continue
+		}
+
+		if resp.Rcode == dns.RcodeServerFailure || resp.Rcode == dns.RcodeRefused {
+			if failed == nil {
+				failed, failedNS = resp, ns
+			}
+			continue
+		}
+
+		return resp, ns, nil
+	}
+
+	if failed != nil {
+		return failed, failedNS, nil
+	}
+
+	return nil, nil, dnsErr
+}
+
+// checkAuthoritativeNameservers queries each authoritative nameserver
+// directly, and checks they give the same answer and SOA serial
+func (c *Checker) checkAuthoritativeNameservers(ctx context.Context, name string, qtype uint16, report *types.DNSReport) types.CheckError {
+	opts := c.Req.Options.DNSCheck
+
+	servers := []types.DNSNameserverReport{}
+	for _, addr := range opts.Nameservers {
+		servers = append(servers, types.DNSNameserverReport{Name: addr, Address: addr})
+	}
+
+	if len(servers) == 0 {
+		var err error
+		if servers, err = c.authoritativeNameservers(ctx, name); err != nil {
+			return c.unwrapError(err).Err
+		}
+	}
+
+	for i := range servers {
+		server := &servers[i]
+		if server.Error != "" {
+			continue
+		}
+
+		ns := &nameserver{protocol: "udp", addr: server.Address}
+
+		if opts.CheckNameservers {
+			resp, err := c.exchange(ctx, ns, authoritativeQuestion(name, qtype), c.resolverTimeout())
+			if err != nil {
+				server.Error = c.unwrapError(err).Err.ToString()
+				continue
+			}
+
+			server.Records = recordValues(resp.Answer, qtype)
+		}
+
+		if opts.CheckSOASerial {
+			resp, err := c.exchange(ctx, ns, authoritativeQuestion(zoneOf(name, nil), dns.TypeSOA), c.resolverTimeout())
+			if err != nil {
+				server.Error = c.unwrapError(err).Err.ToString()
+				continue
+			}
+
+			for _, rr := range append(resp.Answer, resp.Ns...) {
+				if soa, ok := rr.(*dns.SOA); ok {
+					server.Serial = soa.Serial
+					break
+				}
+			}
+		}
+	}
+
+	report.Nameservers = servers
+
+	for _, server := range servers {
+		if server.Error != "" {
+			return types.DNSNameserverUnreachable
+		}
+	}
+
+	for _, server := range servers[1:] {
+		if opts.CheckNameservers && !recordsMatch(server.Records, servers[0].Records, true) {
+			return types.DNSNameserverMismatch
+		}
+
+		if opts.CheckSOASerial && server.Serial != servers[0].Serial {
+			return types.DNSSerialMismatch
+		}
+	}
+
+	return ""
+}
+
+// authoritativeNameservers finds the nameservers for the zone containing
+// the name, and their addresses
+func (c *Checker) authoritativeNameservers(ctx context.Context, name string) ([]types.DNSNameserverReport, error) {
+	resp, _, err := c.query(ctx, name, dns.TypeSOA)
+	if err != nil {
+		return nil, err
+	}
+
+	zone := zoneOf(name, resp)
+
+	resp, _, err = c.query(ctx, zone, dns.TypeNS)
+	if err != nil {
+		return nil, err
+	}
+
+	var servers []types.DNSNameserverReport
+	for _, host := range recordValues(resp.Answer, dns.TypeNS) {
+		server := types.DNSNameserverReport{
+			Name:    host,
+			Address: c.nameserverAddress(ctx, host),
+		}
+
+		if server.Address == "" {
+			server.Error = types.DNSError.ToString()
+		}
+
+		servers = append(servers, server)
+	}
+
+	if len(servers) == 0 {
+		return nil, &net.DNSError{Name: zone, Err: "no nameservers", IsNotFound: true}
+	}
+
+	return servers, nil
+}
+
+// nameserverAddress looks up the address of the nameserver in the address
+// family of the check, preferring IPv4 if either can be used. An empty string
+// is returned if it doesn't have an address.
+func (c *Checker) nameserverAddress(ctx context.Context, host string) string {
+	var qtypes []uint16
+	switch c.Req.Options.IPFamily {
+	case types.IPFamilyIPv4:
+		qtypes = []uint16{dns.TypeA}
+	case types.IPFamilyIPv6:
+		qtypes = []uint16{dns.TypeAAAA}
+	default:
+		qtypes = []uint16{dns.TypeA, dns.TypeAAAA}
+	}
+
+	for _, qtype := range qtypes {
+		resp, _, err := c.query(ctx, dns.Fqdn(host), qtype)
+		if err != nil {
+			continue
+		}
+
+		if values := recordValues(resp.Answer, qtype); len(values) > 0 {
+			return net.JoinHostPort(values[0], "53")
+		}
+	}
+
+	return ""
+}
+
+// zoneOf returns the zone containing the name, from the SOA record in the
+// response. If there isn't one the name is assumed to be the zone.
+func zoneOf(name string, resp *dns.Msg) string {
+	if resp != nil {
+		for _, rr := range append(resp.Answer, resp.Ns...) {
+			if soa, ok := rr.(*dns.SOA); ok {
+				return soa.Hdr.Name
+			}
+		}
+	}
+
+	return name
+}
+
+func authoritativeQuestion(name string, qtype uint16) *dns.Msg {
+	msg := new(dns.Msg)
+	msg.SetQuestion(name, qtype)
+	msg.RecursionDesired = false
+
+	return msg
+}
+
+// recordValues returns the values of the records of the type, in
+// presentation format without trailing dots
+func recordValues(rrs []dns.RR, qtype uint16) []string {
+	var values []string
+
+	for _, rr := range rrs {
+		if rr.Header().Rrtype != qtype {
+			continue
+		}
+
+		var value string
+		switch rr := rr.(type) {
+		case *dns.A:
+			value = rr.A.String()
+		case *dns.AAAA:
+			value = rr.AAAA.String()
+		case *dns.CNAME:
+			value = trimDot(rr.Target)
+		case *dns.MX:
+			value = fmt.Sprintf("%d %s", rr.Preference, trimDot(rr.Mx))
+		case *dns.TXT:
+			value = strings.Join(rr.Txt, "")
+		case *dns.NS:
+			value = trimDot(rr.Ns)
+		case *dns.CAA:
+			value = fmt.Sprintf("%d %s %s", rr.Flag, rr.Tag, rr.Value)
+		case *dns.SOA:
+			value = strings.Join([]string{
+				trimDot(rr.Ns),
+				trimDot(rr.Mbox),
+				strconv.FormatUint(uint64(rr.Serial), 10),
+				strconv.FormatUint(uint64(rr.Refresh), 10),
+				strconv.FormatUint(uint64(rr.Retry), 10),
+				strconv.FormatUint(uint64(rr.Expire), 10),
+				strconv.FormatUint(uint64(rr.Minttl), 10),
+			}, " ")
+		default:
+			value = strings.TrimPrefix(rr.String(), rr.Header().String())
+		}
+
+		values = append(values, value)
+	}
+
+	sort.Strings(values)
+
+	return values
+}
+
+// recordsMatch checks the expected values are in the records, ignoring
+// case. If exact is set there must be no other records.
+func recordsMatch(records, expected []string, exact bool) bool {
+	found := map[string]bool{}
+	for _, record := range records {
+		found[strings.ToLower(record)] = true
+	}
+
+	wanted := map[string]bool{}
+	for _, value := range expected {
+		value = strings.ToLower(trimDot(value))
+		if !found[value] {
+			return false
+		}
+
+		wanted[value] = true
+	}
+
+	return !exact || len(wanted) == len(found)
+}
+
+func trimDot(name string) string {
+	return strings.TrimSuffix(name, ".")
+}
+
+// systemNameservers returns the nameservers from resolv.conf
+func systemNameservers() []string {
+	config, err := dns.ClientConfigFromFile("/etc/resolv.conf")
+	if err != nil {
+		return nil
+	}
+
+	var nameservers []string
+	for _, server := range config.Servers {
+		nameservers = append(nameservers, "udp://"+net.JoinHostPort(server, config.Port))
+	}
+
+	return nameservers
+}
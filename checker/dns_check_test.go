@@ -0,0 +1,307 @@
+package checker_test
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/miekg/dns"
+	a "github.com/stretchr/testify/assert"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// testZone is the zone served by startZoneServer, the serial is appended
+var testZone = []string{
+	"example.test. 60 IN NS ns1.example.test.",
+	"example.test. 60 IN NS ns2.example.test.",
+	"example.test. 60 IN MX 10 mail.example.test.",
+	"example.test. 60 IN MX 20 backup.example.test.",
+	`example.test. 60 IN TXT "v=spf1 " "-all"`,
+	`example.test. 60 IN CAA 0 issue "letsencrypt.org"`,
+	"www.example.test. 60 IN A 192.0.2.1",
+	"www.example.test. 60 IN A 192.0.2.2",
+	"www.example.test. 60 IN AAAA 2001:db8::1",
+	"alias.example.test. 60 IN CNAME www.example.test.",
+	"ns1.example.test. 60 IN A 127.0.0.1",
+	"ns2.example.test. 60 IN A 127.0.0.1",
+}
+
+// startZoneServer starts an authoritative server for example.test, with the
+// records and SOA serial, returning its address
+func startZoneServer(t *testing.T, serial string, records ...string) string {
+	t.Helper()
+
+	soa, err := dns.NewRR("example.test. 60 IN SOA ns1.example.test. hostmaster.example.test. " + serial + " 3600 600 86400 60")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zone := []dns.RR{soa}
+	for _, record := range records {
+		rr, err := dns.NewRR(record)
+		if err != nil {
+			t.Fatal(err)
+		}
+		zone = append(zone, rr)
+	}
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	started := make(chan struct{})
+	server := &dns.Server{
+		PacketConn:        conn,
+		NotifyStartedFunc: func() { close(started) },
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+			res := new(dns.Msg)
+			res.SetReply(req)
+			res.Authoritative = true
+
+			q := req.Question[0]
+			exists := false
+			for _, rr := range zone {
+				if !strings.EqualFold(rr.Header().Name, q.Name) {
+					continue
+				}
+
+				exists = true
+				if rr.Header().Rrtype == q.Qtype {
+					res.Answer = append(res.Answer, rr)
+				}
+			}
+
+			if !exists {
+				res.Rcode = dns.RcodeNameError
+			}
+			if len(res.Answer) == 0 {
+				res.Ns = append(res.Ns, soa)
+			}
+
+			w.WriteMsg(res)
+		}),
+	}
+
+	go server.ActivateAndServe()
+	t.Cleanup(func() { server.Shutdown() })
+	<-started
+
+	return conn.LocalAddr().String()
+}
+
+func TestDNSCheck(t *testing.T) {
+	nameserver := startZoneServer(t, "2024010101", testZone...)
+
+	tests := []struct {
+		name  string
+		host  string
+		check types.DNSCheck
+		error string
+	}{
+		{"default type", "www", types.DNSCheck{}, ""},
+		{"a", "www", types.DNSCheck{Type: "A", Expected: []string{"192.0.2.1"}}, ""},
+		{"a exact", "www", types.DNSCheck{Type: "a", Expected: []string{"192.0.2.2", "192.0.2.1"}, ExactMatch: true}, ""},
+		{"a not exact", "www", types.DNSCheck{Type: "A", Expected: []string{"192.0.2.1"}, ExactMatch: true}, types.DNSRecordMismatch.ToString()},
+		{"a mismatch", "www", types.DNSCheck{Type: "A", Expected: []string{"192.0.2.3"}}, types.DNSRecordMismatch.ToString()},
+		{"aaaa", "www", types.DNSCheck{Type: "AAAA", Expected: []string{"2001:db8::1"}}, ""},
+		{"cname", "alias", types.DNSCheck{Type: "CNAME", Expected: []string{"WWW.example.test."}}, ""},
+		{"mx", "", types.DNSCheck{Type: "MX", Expected: []string{"10 mail.example.test", "20 backup.example.test"}}, ""},
+		{"txt", "", types.DNSCheck{Type: "TXT", Expected: []string{"v=spf1 -all"}}, ""},
+		{"ns", "", types.DNSCheck{Type: "NS", Expected: []string{"ns1.example.test", "ns2.example.test"}}, ""},
+		{"caa", "", types.DNSCheck{Type: "CAA", Expected: []string{"0 issue letsencrypt.org"}}, ""},
+		{"soa", "", types.DNSCheck{Type: "SOA", Expected: []string{"ns1.example.test hostmaster.example.test 2024010101 3600 600 86400 60"}}, ""},
+		{"no records", "alias", types.DNSCheck{Type: "MX"}, types.DNSNoRecords.ToString()},
+		{"nxdomain", "missing", types.DNSCheck{}, types.NxDomain.ToString()},
+		{"invalid type", "www", types.DNSCheck{Type: "SRV"}, types.DNSInvalidRecordType.ToString()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host := "example.test"
+			if tt.host != "" {
+				host = tt.host + "." + host
+			}
+
+			req := buildCheck("dns://" + host)
+			req.Options.Resolver = types.Resolver{Nameservers: []string{nameserver}}
+			req.Options.DNSCheck = tt.check
+
+			c := checker.Init(req)
+			c.Perform()
+
+			a.Equal(t, tt.error, c.Res.Error)
+
+			if tt.error == "" {
+				a.Equal(t, host, c.Res.DNSReport.Name)
+				a.Equal(t, "udp://"+nameserver, c.Res.DNSReport.Resolver)
+				a.NotEmpty(t, c.Res.DNSReport.Records)
+				a.NotNil(t, c.Res.Timing.DNS)
+			}
+		})
+	}
+
+	req := buildCheck("dns://www.example.test")
+	req.Options.Resolver = types.Resolver{Nameservers: []string{nameserver}}
+
+	c := checker.Init(req)
+	c.Perform()
+	a.Equal(t, []string{"192.0.2.1", "192.0.2.2"}, c.Res.DNSReport.Records)
+}
+
+func TestDNSCheckFailover(t *testing.T) {
+	nameserver := startZoneServer(t, "2024010101", testZone...)
+
+	// Nameservers which fail every query with the response code
+	failing := func(rcode int) string {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		started := make(chan struct{})
+		server := &dns.Server{
+			PacketConn:        conn,
+			NotifyStartedFunc: func() { close(started) },
+			Handler: dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+				res := new(dns.Msg)
+				res.SetRcode(req, rcode)
+				w.WriteMsg(res)
+			}),
+		}
+
+		go server.ActivateAndServe()
+		t.Cleanup(func() { server.Shutdown() })
+		<-started
+
+		return conn.LocalAddr().String()
+	}
+	servfail := failing(dns.RcodeServerFailure)
+	refused := failing(dns.RcodeRefused)
+
+	tests := []struct {
+		name        string
+		nameservers []string
+		resolver    string
+		error       string
+	}{
+		{"servfail", []string{servfail, nameserver}, nameserver, ""},
+		{"refused", []string{refused, nameserver}, nameserver, ""},
+		{"all failed", []string{servfail, refused}, servfail, types.DNSError.ToString()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := buildCheck("dns://www.example.test")
+			req.Options.Resolver = types.Resolver{Nameservers: tt.nameservers}
+
+			c := checker.Init(req)
+			c.Perform()
+
+			a.Equal(t, tt.error, c.Res.Error)
+			a.Equal(t, "udp://"+tt.resolver, c.Res.DNSReport.Resolver)
+		})
+	}
+}
+
+func TestDNSCheckNameservers(t *testing.T) {
+	primary := startZoneServer(t, "2024010102", testZone...)
+	secondary := startZoneServer(t, "2024010102", testZone...)
+	stale := startZoneServer(t, "2024010101", testZone...)
+	different := startZoneServer(t, "2024010102", append(testZone, "www.example.test. 60 IN A 192.0.2.3")...)
+
+	// Nothing listening
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unreachable := conn.LocalAddr().String()
+	conn.Close()
+
+	tests := []struct {
+		name        string
+		nameservers []string
+		records     bool
+		serial      bool
+		error       string
+	}{
+		{"in sync", []string{primary, secondary}, true, true, ""},
+		{"different records", []string{primary, different}, true, false, types.DNSNameserverMismatch.ToString()},
+		{"different records serial only", []string{primary, different}, false, true, ""},
+		{"stale serial", []string{primary, stale}, true, true, types.DNSSerialMismatch.ToString()},
+		{"stale serial records only", []string{primary, stale}, true, false, ""},
+		{"unreachable", []string{primary, unreachable}, true, true, types.DNSNameserverUnreachable.ToString()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := buildCheck("dns://www.example.test")
+			req.Options.Resolver = types.Resolver{Nameservers: []string{primary}}
+			req.Options.DNSCheck = types.DNSCheck{
+				Type:             "A",
+				CheckNameservers: tt.records,
+				CheckSOASerial:   tt.serial,
+				Nameservers:      tt.nameservers,
+			}
+
+			c := checker.Init(req)
+			c.Perform()
+
+			a.Equal(t, tt.error, c.Res.Error)
+			a.Len(t, c.Res.DNSReport.Nameservers, len(tt.nameservers))
+		})
+	}
+
+	req := buildCheck("dns://www.example.test")
+	req.Options.Resolver = types.Resolver{Nameservers: []string{primary}}
+	req.Options.DNSCheck = types.DNSCheck{
+		CheckSOASerial: true,
+		Nameservers:    []string{primary, stale},
+	}
+
+	c := checker.Init(req)
+	c.Perform()
+	a.Equal(t, uint32(2024010102), c.Res.DNSReport.Nameservers[0].Serial)
+	a.Equal(t, uint32(2024010101), c.Res.DNSReport.Nameservers[1].Serial)
+}
+
+func TestDNSCheckNameserverAddresses(t *testing.T) {
+	// ns2 only has an IPv6 address
+	zone := append([]string{}, testZone[:len(testZone)-1]...)
+	zone = append(zone, "ns2.example.test. 60 IN AAAA ::1")
+	server := startZoneServer(t, "2024010101", zone...)
+
+	tests := []struct {
+		name      string
+		family    types.IPFamily
+		addresses []string
+	}{
+		{"any", types.IPFamilyAny, []string{"127.0.0.1:53", "[::1]:53"}},
+		{"ipv4", types.IPFamilyIPv4, []string{"127.0.0.1:53", ""}},
+		{"ipv6", types.IPFamilyIPv6, []string{"", "[::1]:53"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := buildCheck("dns://www.example.test")
+			req.Options.Resolver = types.Resolver{Nameservers: []string{server}}
+			req.Options.DNSCheck = types.DNSCheck{Type: "A", CheckSOASerial: true}
+			req.Options.IPFamily = tt.family
+
+			c := checker.Init(req)
+			c.Perform()
+
+			if a.NotNil(t, c.Res.DNSReport) && a.Len(t, c.Res.DNSReport.Nameservers, 2) {
+				for i, server := range c.Res.DNSReport.Nameservers {
+					a.Equal(t, tt.addresses[i], server.Address)
+
+					if tt.addresses[i] == "" {
+						a.Equal(t, types.DNSError.ToString(), server.Error)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,505 @@
+package checker
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+const (
+	dnssecSecure   = "secure"
+	dnssecInsecure = "insecure"
+	dnssecBogus    = "bogus"
+
+	// NSEC3 flag for unsigned delegations which aren't covered, see RFC 5155
+	nsec3OptOut = 0x01
+
+	// Maximum number of CNAME records followed when validating
+	maxCNAMEChain = 8
+)
+
+var (
+	// Root zone trust anchors, https://data.iana.org/root-anchors/
+	rootTrustAnchors = []string{
+		". IN DS 20326 8 2 E06D44B80B8F1D39A95C0B0D7C65D08458E880409BBC683457104237C7F8EC8D",
+		". IN DS 38696 8 2 683D2D0ACB8C9B712A1948B27F741219298D0A450D612C483AF444A4C0FB2B16",
+	}
+)
+
+// ParseTrustAnchors parses DS or DNSKEY records in zone file format, which
+// are trusted as the start of the DNSSEC chain of trust for their zone
+func ParseTrustAnchors(r io.Reader) ([]dns.RR, error) {
+	var anchors []dns.RR
+
+	parser := dns.NewZoneParser(r, ".", "")
+	for rr, ok := parser.Next(); ok; rr, ok = parser.Next() {
+		switch rr.(type) {
+		case *dns.DS, *dns.DNSKEY:
+			anchors = append(anchors, rr)
+		}
+	}
+
+	if err := parser.Err(); err != nil {
+		return nil, err
+	}
+
+	return anchors, nil
+}
+
+// LoadTrustAnchors loads the trust anchors from a file
+func LoadTrustAnchors(path string) ([]dns.RR, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseTrustAnchors(f)
+}
+
+// trustAnchors returns the trust anchors for the check, the agent, or the
+// root zone in that order
+func (c *Checker) trustAnchors() ([]dns.RR, error) {
+	if anchors := c.Req.Options.DNSCheck.TrustAnchors; len(anchors) > 0 {
+		return ParseTrustAnchors(strings.NewReader(strings.Join(anchors, "\n")))
+	}
+
+	if len(agentConfig.TrustAnchors) > 0 {
+		return agentConfig.TrustAnchors, nil
+	}
+
+	return ParseTrustAnchors(strings.NewReader(strings.Join(rootTrustAnchors, "\n")))
+}
+
+// dnssecValidator walks the chain of trust from a trust anchor to the
+// records being checked. Queries are sent to the resolver with checking
+// disabled, so the signatures are validated here rather than trusting the
+// resolver.
+type dnssecValidator struct {
+	c      *Checker
+	ctx    context.Context
+	now    time.Time
+	report *types.DNSSECReport
+}
+
+// validateDNSSEC validates the records for the name, recording the status
+// and the signatures which were checked
+func (c *Checker) validateDNSSEC(ctx context.Context, name string, qtype uint16, report *types.DNSReport) types.CheckError {
+	report.DNSSEC = &types.DNSSECReport{}
+
+	anchors, err := c.trustAnchors()
+	if err != nil {
+		report.DNSSEC.Status = dnssecBogus
+		return types.DNSSECInvalidTrustAnchor
+	}
+
+	v := &dnssecValidator{
+		c:      c,
+		ctx:    ctx,
+		now:    time.Now(),
+		report: report.DNSSEC,
+	}
+
+	status, checkErr := v.validate(name, qtype, anchors)
+	report.DNSSEC.Status = status
+
+	if checkErr == "" {
+		c.checkSignatureExpiry(report.DNSSEC)
+	}
+
+	return checkErr
+}
+
+// checkSignatureExpiry warns if a signature in the chain expires soon
+func (c *Checker) checkSignatureExpiry(report *types.DNSSECReport) {
+	days := c.Req.Options.DNSCheck.SignatureExpiryWarningDays
+	if days <= 0 {
+		return
+	}
+
+	warnAt := time.Now().Add(time.Duration(days) * day)
+	for _, sig := range report.Signatures {
+		if sig.Expiration.Before(warnAt) {
+			c.addWarning(types.DNSSECSignatureExpiringSoon)
+			return
+		}
+	}
+}
+
+// validate validates the records for the name, following CNAME records. The
+// answer is only secure if every name in the chain is.
+func (v *dnssecValidator) validate(name string, qtype uint16, anchors []dns.RR) (string, types.CheckError) {
+	for i := 0; i <= maxCNAMEChain; i++ {
+		status, target, checkErr := v.validateName(name, qtype, anchors)
+		if checkErr != "" || target == "" {
+			return status, checkErr
+		}
+
+		name = target
+	}
+
+	// Too long, or a loop
+	return dnssecBogus, types.DNSSECBogus
+}
+
+// validateName validates the records for a single name. If the name is an
+// alias, the CNAME record is validated and its target returned.
+func (v *dnssecValidator) validateName(name string, qtype uint16, anchors []dns.RR) (string, string, types.CheckError) {
+	// Start from the closest trust anchor
+	zone, trusted := closestTrustAnchor(name, anchors)
+	if zone == "" {
+		return dnssecInsecure, "", types.DNSSECInsecure
+	}
+
+	keys, checkErr := v.zoneKeys(zone, trusted)
+	if checkErr != "" {
+		return dnssecBogus, "", checkErr
+	}
+
+	// Walk down to the name, following each secure delegation
+	labels := dns.SplitDomainName(name)
+	for i := len(labels) - dns.CountLabel(zone) - 1; i >= 0; i-- {
+		child := dns.Fqdn(strings.Join(labels[i:], "."))
+
+		resp, err := v.query(child, dns.TypeDS)
+		if err != nil {
+			return dnssecBogus, "", v.c.unwrapError(err).Err
+		}
+
+		ds, sigs := rrset(resp.Answer, child, dns.TypeDS)
+		if len(ds) > 0 {
+			if checkErr := v.verifyRRset(ds, sigs, zone, keys); checkErr != "" {
+				return dnssecBogus, "", checkErr
+			}
+
+			if keys, checkErr = v.zoneKeys(child, ds); checkErr != "" {
+				return dnssecBogus, "", checkErr
+			}
+
+			zone = child
+			continue
+		}
+
+		// No DS record, so either this isn't a zone cut, or it's an
+		// unsigned delegation
+		cut, err := v.isZoneCut(child)
+		if err != nil {
+			return dnssecBogus, "", v.c.unwrapError(err).Err
+		}
+
+		if !cut {
+			continue
+		}
+
+		// The absence of the DS record must be signed by the parent
+		if err := v.verifyDenial(resp, child, zone, keys); err != "" {
+			return dnssecBogus, "", err
+		}
+
+		return dnssecInsecure, "", types.DNSSECInsecure
+	}
+
+	// Finally verify the records themselves
+	resp, err := v.query(name, qtype)
+	if err != nil {
+		return dnssecBogus, "", v.c.unwrapError(err).Err
+	}
+
+	records, sigs := rrset(resp.Answer, name, qtype)
+	target := ""
+	if len(records) == 0 {
+		records, sigs = rrset(resp.Answer, name, dns.TypeCNAME)
+		if len(records) > 0 {
+			target = records[0].(*dns.CNAME).Target
+		}
+	}
+
+	if err := v.verifyRRset(records, sigs, zone, keys); err != "" {
+		return dnssecBogus, "", err
+	}
+
+	return dnssecSecure, target, ""
+}
+
+// zoneKeys fetches the DNSKEY records for the zone, and checks they are
+// signed by a key matching one of the trusted DS or DNSKEY records
+func (v *dnssecValidator) zoneKeys(zone string, trusted []dns.RR) ([]*dns.DNSKEY, types.CheckError) {
+	resp, err := v.query(zone, dns.TypeDNSKEY)
+	if err != nil {
+		return nil, v.c.unwrapError(err).Err
+	}
+
+	records, sigs := rrset(resp.Answer, zone, dns.TypeDNSKEY)
+
+	var keys, secureEntryPoints []*dns.DNSKEY
+	for _, rr := range records {
+		key := rr.(*dns.DNSKEY)
+		keys = append(keys, key)
+
+		if keyMatchesAnchor(key, trusted) {
+			secureEntryPoints = append(secureEntryPoints, key)
+		}
+	}
+
+	if len(secureEntryPoints) == 0 {
+		log.WithFields(log.Fields{
+			"Ref":  v.c.Req.Ref,
+			"Zone": zone,
+		}).Debug("No DNSKEY matches the DS records")
+
+		return nil, types.DNSSECBogus
+	}
+
+	if err := v.verifyRRset(records, sigs, zone, secureEntryPoints); err != "" {
+		return nil, err
+	}
+
+	return keys, ""
+}
+
+// verifyRRset checks the records have a valid signature by one of the keys
+func (v *dnssecValidator) verifyRRset(records []dns.RR, sigs []*dns.RRSIG, signer string, keys []*dns.DNSKEY) types.CheckError {
+	if len(records) == 0 || len(sigs) == 0 {
+		return types.DNSSECBogus
+	}
+
+	expired := false
+	for _, sig := range sigs {
+		if !strings.EqualFold(sig.SignerName, signer) {
+			continue
+		}
+
+		for _, key := range keys {
+			if key.KeyTag() != sig.KeyTag || key.Algorithm != sig.Algorithm {
+				continue
+			}
+
+			if err := sig.Verify(key, records); err != nil {
+				continue
+			}
+
+			v.report.Signatures = append(v.report.Signatures, types.DNSSECSignature{
+				Name:       strings.TrimSuffix(sig.Hdr.Name, "."),
+				Type:       dns.TypeToString[sig.TypeCovered],
+				Signer:     strings.TrimSuffix(sig.SignerName, "."),
+				KeyTag:     sig.KeyTag,
+				Inception:  time.Unix(int64(sig.Inception), 0).UTC(),
+				Expiration: time.Unix(int64(sig.Expiration), 0).UTC(),
+			})
+
+			if sig.ValidityPeriod(v.now) {
+				return ""
+			}
+
+			if v.now.Unix() > int64(sig.Expiration) {
+				expired = true
+			}
+		}
+	}
+
+	if expired {
+		return types.DNSSECExpired
+	}
+
+	return types.DNSSECBogus
+}
+
+// verifyDenial checks a negative response proves the delegation to name has
+// no DS record, see RFC 4035 section 5.2 and RFC 5155 section 8.9. The NSEC
+// or NSEC3 records must be signed by the zone, and either match the name
+// with NS but not DS in the type bitmap, or for NSEC3 opt-out cover it.
+func (v *dnssecValidator) verifyDenial(resp *dns.Msg, name, zone string, keys []*dns.DNSKEY) types.CheckError {
+	var nsecs []*dns.NSEC
+	var nsec3s []*dns.NSEC3
+
+	for _, qtype := range []uint16{dns.TypeNSEC, dns.TypeNSEC3} {
+		owners := map[string]bool{}
+		for _, rr := range resp.Ns {
+			if rr.Header().Rrtype == qtype {
+				owners[rr.Header().Name] = true
+			}
+		}
+
+		for owner := range owners {
+			records, sigs := rrset(resp.Ns, owner, qtype)
+			if err := v.verifyRRset(records, sigs, zone, keys); err != "" {
+				return err
+			}
+
+			for _, rr := range records {
+				switch rr := rr.(type) {
+				case *dns.NSEC:
+					nsecs = append(nsecs, rr)
+				case *dns.NSEC3:
+					nsec3s = append(nsec3s, rr)
+				}
+			}
+		}
+	}
+
+	for _, nsec := range nsecs {
+		if strings.EqualFold(nsec.Hdr.Name, name) {
+			if deniesDS(nsec.TypeBitMap) {
+				return ""
+			}
+
+			return types.DNSSECBogus
+		}
+	}
+
+	if nsec3DeniesDS(nsec3s, name, zone) {
+		return ""
+	}
+
+	return types.DNSSECBogus
+}
+
+// deniesDS checks the type bitmap is for a delegation without a DS record.
+// The SOA is only at the apex, so with it the record is from the child zone.
+func deniesDS(bitmap []uint16) bool {
+	delegation := false
+
+	for _, rrtype := range bitmap {
+		switch rrtype {
+		case dns.TypeDS, dns.TypeSOA, dns.TypeCNAME:
+			return false
+		case dns.TypeNS:
+			delegation = true
+		}
+	}
+
+	return delegation
+}
+
+// nsec3DeniesDS checks an NSEC3 record matches the name and denies the DS
+// record, or that the closest encloser is matched and the next closer name
+// is covered by an opt-out record
+func nsec3DeniesDS(nsec3s []*dns.NSEC3, name, zone string) bool {
+	matches := func(name string) *dns.NSEC3 {
+		for _, nsec3 := range nsec3s {
+			if nsec3.Match(name) {
+				return nsec3
+			}
+		}
+
+		return nil
+	}
+
+	if nsec3 := matches(name); nsec3 != nil {
+		return deniesDS(nsec3.TypeBitMap)
+	}
+
+	labels := dns.SplitDomainName(name)
+	for i := 1; i <= len(labels)-dns.CountLabel(zone); i++ {
+		if matches(dns.Fqdn(strings.Join(labels[i:], "."))) == nil {
+			continue
+		}
+
+		nextCloser := dns.Fqdn(strings.Join(labels[i-1:], "."))
+		for _, nsec3 := range nsec3s {
+			if nsec3.Flags&nsec3OptOut != 0 && nsec3.Cover(nextCloser) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return false
+}
+
+// isZoneCut checks if the name is the apex of a zone
+func (v *dnssecValidator) isZoneCut(name string) (bool, error) {
+	resp, err := v.query(name, dns.TypeSOA)
+	if err != nil {
+		return false, err
+	}
+
+	soa, _ := rrset(resp.Answer, name, dns.TypeSOA)
+	return len(soa) > 0, nil
+}
+
+// query asks for the DNSSEC records, with checking disabled so bogus
+// records are returned
+func (v *dnssecValidator) query(name string, qtype uint16) (*dns.Msg, error) {
+	msg := new(dns.Msg)
+	msg.SetQuestion(name, qtype)
+	msg.SetEdns0(4096, true)
+	msg.CheckingDisabled = true
+
+	resp, _, err := v.c.queryMsg(v.ctx, msg)
+	return resp, err
+}
+
+// rrset returns the records of the type for the name, and their signatures
+func rrset(rrs []dns.RR, name string, qtype uint16) ([]dns.RR, []*dns.RRSIG) {
+	var records []dns.RR
+	var sigs []*dns.RRSIG
+
+	for _, rr := range rrs {
+		if !strings.EqualFold(rr.Header().Name, name) {
+			continue
+		}
+
+		if sig, ok := rr.(*dns.RRSIG); ok && sig.TypeCovered == qtype {
+			sigs = append(sigs, sig)
+		} else if rr.Header().Rrtype == qtype {
+			records = append(records, rr)
+		}
+	}
+
+	return records, sigs
+}
+
+// closestTrustAnchor returns the zone of the trust anchor closest to the
+// name, and the anchors for that zone
+func closestTrustAnchor(name string, anchors []dns.RR) (string, []dns.RR) {
+	zone := ""
+	for _, anchor := range anchors {
+		owner := dns.Fqdn(anchor.Header().Name)
+		if dns.IsSubDomain(owner, name) && dns.CountLabel(owner) >= dns.CountLabel(zone) {
+			zone = owner
+		}
+	}
+
+	if zone == "" {
+		return "", nil
+	}
+
+	var trusted []dns.RR
+	for _, anchor := range anchors {
+		if strings.EqualFold(dns.Fqdn(anchor.Header().Name), zone) {
+			trusted = append(trusted, anchor)
+		}
+	}
+
+	return zone, trusted
+}
+
+// keyMatchesAnchor checks if the key is one of the trusted DNSKEY records,
+// or matches one of the trusted DS records
+func keyMatchesAnchor(key *dns.DNSKEY, trusted []dns.RR) bool {
+	for _, rr := range trusted {
+		switch anchor := rr.(type) {
+		case *dns.DNSKEY:
+			if anchor.PublicKey == key.PublicKey && anchor.Algorithm == key.Algorithm {
+				return true
+			}
+
+		case *dns.DS:
+			ds := key.ToDS(anchor.DigestType)
+			if ds != nil && ds.KeyTag == anchor.KeyTag && strings.EqualFold(ds.Digest, anchor.Digest) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
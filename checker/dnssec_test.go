@@ -0,0 +1,295 @@
+package checker_test
+
+import (
+	"crypto"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	a "github.com/stretchr/testify/assert"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// testSignedZone is a zone with a single ECDSA key used to sign everything
+type testSignedZone struct {
+	Name string
+	Key  *dns.DNSKEY
+	priv crypto.Signer
+}
+
+func newSignedZone(t *testing.T, name string) *testSignedZone {
+	key := &dns.DNSKEY{
+		Hdr:       dns.RR_Header{Name: name, Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET, Ttl: 60},
+		Flags:     257,
+		Protocol:  3,
+		Algorithm: dns.ECDSAP256SHA256,
+	}
+
+	priv, err := key.Generate(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testSignedZone{Name: name, Key: key, priv: priv.(crypto.Signer)}
+}
+
+// Sign signs the records, valid for the period
+func (z *testSignedZone) Sign(t *testing.T, rrset []dns.RR, inception, expiration time.Time) *dns.RRSIG {
+	sig := &dns.RRSIG{
+		Hdr:        dns.RR_Header{Name: rrset[0].Header().Name, Rrtype: dns.TypeRRSIG, Class: dns.ClassINET, Ttl: 60},
+		Algorithm:  z.Key.Algorithm,
+		KeyTag:     z.Key.KeyTag(),
+		SignerName: z.Name,
+		Inception:  uint32(inception.Unix()),
+		Expiration: uint32(expiration.Unix()),
+	}
+
+	if err := sig.Sign(z.priv, rrset); err != nil {
+		t.Fatal(err)
+	}
+
+	return sig
+}
+
+// DS returns the DS record for the zone's key
+func (z *testSignedZone) DS() *dns.DS {
+	return z.Key.ToDS(dns.SHA256)
+}
+
+// testRecordStore answers queries from a set of records, keyed by name and
+// type
+type testRecordStore map[string][]dns.RR
+
+func (s testRecordStore) Add(rrs ...dns.RR) {
+	for _, rr := range rrs {
+		rrtype := rr.Header().Rrtype
+		if sig, ok := rr.(*dns.RRSIG); ok {
+			rrtype = sig.TypeCovered
+		}
+
+		key := strings.ToLower(rr.Header().Name) + "/" + dns.TypeToString[rrtype]
+		s[key] = append(s[key], rr)
+	}
+}
+
+func (s testRecordStore) ServeDNS(w dns.ResponseWriter, req *dns.Msg) {
+	res := new(dns.Msg)
+	res.SetReply(req)
+
+	q := req.Question[0]
+	name := strings.ToLower(q.Name)
+
+	// Follow CNAME records like a recursive resolver
+	target := name
+	for i := 0; i < 8 && len(s[target+"/"+dns.TypeToString[q.Qtype]]) == 0; i++ {
+		cname := s[target+"/CNAME"]
+		if len(cname) == 0 {
+			break
+		}
+
+		res.Answer = append(res.Answer, cname...)
+		for _, rr := range cname {
+			if rr, ok := rr.(*dns.CNAME); ok {
+				target = strings.ToLower(rr.Target)
+			}
+		}
+	}
+
+	res.Answer = append(res.Answer, s[target+"/"+dns.TypeToString[q.Qtype]]...)
+	if len(res.Answer) == 0 {
+		exists := false
+		for key := range s {
+			if strings.HasPrefix(key, name+"/") {
+				exists = true
+			}
+		}
+
+		if !exists {
+			res.Rcode = dns.RcodeNameError
+		}
+
+		res.Ns = s[name+"/NSEC"]
+	}
+
+	w.WriteMsg(res)
+}
+
+// nsec3Hash returns the NSEC3 owner label for the name, with no salt or
+// additional iterations
+func nsec3Hash(name string) string {
+	return dns.HashName(name, dns.SHA1, 0, "")
+}
+
+func mustRR(t *testing.T, s string) dns.RR {
+	rr, err := dns.NewRR(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rr
+}
+
+func TestDNSSEC(t *testing.T) {
+	now := time.Now()
+	inception := now.Add(-24 * time.Hour)
+	expiration := now.Add(30 * 24 * time.Hour)
+
+	parent := newSignedZone(t, "test.")
+	child := newSignedZone(t, "example.test.")
+
+	store := testRecordStore{}
+	sign := func(z *testSignedZone, rrs ...dns.RR) {
+		store.Add(rrs...)
+		store.Add(z.Sign(t, rrs, inception, expiration))
+	}
+
+	// Parent zone, with a secure delegation to example.test and an
+	// insecure one to insecure.test
+	sign(parent, parent.Key)
+	sign(parent, child.DS())
+	sign(parent, mustRR(t, "insecure.test. 60 IN NSEC later.test. NS RRSIG NSEC"))
+	store.Add(mustRR(t, "insecure.test. 60 IN SOA ns1.insecure.test. hostmaster.insecure.test. 1 3600 600 86400 60"))
+	store.Add(mustRR(t, "www.insecure.test. 60 IN A 192.0.2.1"))
+
+	// Child zone
+	sign(child, child.Key)
+	sign(child, mustRR(t, "example.test. 60 IN SOA ns1.example.test. hostmaster.example.test. 1 3600 600 86400 60"))
+	sign(child, mustRR(t, "www.example.test. 60 IN A 192.0.2.1"))
+	sign(child, mustRR(t, "www.example.test. 60 IN MX 10 mail.example.test."))
+
+	soon := mustRR(t, "soon.example.test. 60 IN A 192.0.2.1")
+	store.Add(soon, child.Sign(t, []dns.RR{soon}, inception, now.Add(3*24*time.Hour)))
+
+	old := mustRR(t, "old.example.test. 60 IN A 192.0.2.1")
+	store.Add(old, child.Sign(t, []dns.RR{old}, now.Add(-30*24*time.Hour), now.Add(-time.Hour)))
+
+	// Signature over different data
+	bad := mustRR(t, "bad.example.test. 60 IN A 192.0.2.1")
+	store.Add(bad, child.Sign(t, []dns.RR{mustRR(t, "bad.example.test. 60 IN A 192.0.2.2")}, inception, expiration))
+
+	store.Add(mustRR(t, "unsigned.example.test. 60 IN A 192.0.2.1"))
+
+	// Aliases, which are only secure if the target is
+	sign(child, mustRR(t, "alias.example.test. 60 IN CNAME www.example.test."))
+	sign(child, mustRR(t, "toinsecure.example.test. 60 IN CNAME www.insecure.test."))
+	sign(child, mustRR(t, "tounsigned.example.test. 60 IN CNAME unsigned.example.test."))
+
+	// Unsigned delegations where the denial of the DS record is signed, but
+	// doesn't prove it's absent
+	denials := map[string][]dns.RR{
+		// Another name's NSEC record
+		"other.test.": {mustRR(t, "a.test. 60 IN NSEC b.test. NS RRSIG NSEC")},
+		// The DS record exists
+		"withds.test.": {mustRR(t, "withds.test. 60 IN NSEC later.test. NS DS RRSIG NSEC")},
+		// Matching NSEC3 record
+		"nsec3.test.": {mustRR(t, nsec3Hash("nsec3.test.")+".test. 60 IN NSEC3 1 0 0 - "+nsec3Hash("later.test.")+" NS")},
+		// Opt-out NSEC3 record covering the name, and one matching the
+		// closest encloser
+		"optout.test.": {
+			mustRR(t, nsec3Hash("test.")+".test. 60 IN NSEC3 1 0 0 - "+nsec3Hash("later.test.")+" NS SOA RRSIG DNSKEY NSEC3PARAM"),
+			mustRR(t, "00000000000000000000000000000000.test. 60 IN NSEC3 1 1 0 - VVVVVVVVVVVVVVVVVVVVVVVVVVVVVVVV NS"),
+		},
+	}
+	for zone, rrs := range denials {
+		for _, rr := range rrs {
+			store[zone+"/NSEC"] = append(store[zone+"/NSEC"], rr, parent.Sign(t, []dns.RR{rr}, inception, expiration))
+		}
+		store.Add(mustRR(t, zone+" 60 IN SOA ns1."+zone+" hostmaster."+zone+" 1 3600 600 86400 60"))
+		store.Add(mustRR(t, "www."+zone+" 60 IN A 192.0.2.1"))
+	}
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	started := make(chan struct{})
+	server := &dns.Server{PacketConn: conn, Handler: store, NotifyStartedFunc: func() { close(started) }}
+	go server.ActivateAndServe()
+	defer server.Shutdown()
+	<-started
+
+	wrongDS := parent.DS()
+	wrongDS.Digest = strings.Repeat("00", 32)
+
+	tests := []struct {
+		name    string
+		host    string
+		qtype   string
+		anchors []string
+		status  string
+		error   string
+		warning bool
+	}{
+		{"secure", "www.example.test", "A", []string{parent.DS().String()}, "secure", "", false},
+		{"secure dnskey anchor", "www.example.test", "MX", []string{parent.Key.String()}, "secure", "", false},
+		{"child anchor", "www.example.test", "A", []string{child.DS().String()}, "secure", "", false},
+		{"expiring soon", "soon.example.test", "A", []string{parent.DS().String()}, "secure", "", true},
+		{"expired", "old.example.test", "A", []string{parent.DS().String()}, "bogus", types.DNSSECExpired.ToString(), false},
+		{"bad signature", "bad.example.test", "A", []string{parent.DS().String()}, "bogus", types.DNSSECBogus.ToString(), false},
+		{"missing signature", "unsigned.example.test", "A", []string{parent.DS().String()}, "bogus", types.DNSSECBogus.ToString(), false},
+		{"cname", "alias.example.test", "A", []string{parent.DS().String()}, "secure", "", false},
+		{"cname to insecure zone", "toinsecure.example.test", "A", []string{parent.DS().String()}, "insecure", types.DNSSECInsecure.ToString(), false},
+		{"cname to unsigned record", "tounsigned.example.test", "A", []string{parent.DS().String()}, "bogus", types.DNSSECBogus.ToString(), false},
+		{"wrong trust anchor", "www.example.test", "A", []string{wrongDS.String()}, "bogus", types.DNSSECBogus.ToString(), false},
+		{"insecure delegation", "www.insecure.test", "A", []string{parent.DS().String()}, "insecure", types.DNSSECInsecure.ToString(), false},
+		{"insecure delegation nsec3", "www.nsec3.test", "A", []string{parent.DS().String()}, "insecure", types.DNSSECInsecure.ToString(), false},
+		{"insecure delegation opt-out", "www.optout.test", "A", []string{parent.DS().String()}, "insecure", types.DNSSECInsecure.ToString(), false},
+		{"denial for another name", "www.other.test", "A", []string{parent.DS().String()}, "bogus", types.DNSSECBogus.ToString(), false},
+		{"denial with ds", "www.withds.test", "A", []string{parent.DS().String()}, "bogus", types.DNSSECBogus.ToString(), false},
+		{"no trust anchor", "www.example.test", "A", []string{strings.Replace(parent.DS().String(), "test.", "other.", 1)}, "insecure", types.DNSSECInsecure.ToString(), false},
+		{"invalid trust anchor", "www.example.test", "A", []string{"test. 60 IN DS invalid"}, "bogus", types.DNSSECInvalidTrustAnchor.ToString(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := buildCheck("dns://" + tt.host)
+			req.Options.Resolver = types.Resolver{Nameservers: []string{conn.LocalAddr().String()}}
+			req.Options.DNSCheck = types.DNSCheck{
+				Type:         tt.qtype,
+				DNSSEC:       true,
+				TrustAnchors: tt.anchors,
+
+				SignatureExpiryWarningDays: 7,
+			}
+
+			c := checker.Init(req)
+			c.Perform()
+
+			a.Equal(t, tt.error, c.Res.Error)
+			a.Equal(t, tt.status, c.Res.DNSReport.DNSSEC.Status)
+
+			if tt.warning {
+				a.Equal(t, []string{types.DNSSECSignatureExpiringSoon.ToString()}, c.Res.Warnings)
+			} else {
+				a.Empty(t, c.Res.Warnings)
+			}
+
+			if tt.status == "secure" {
+				a.NotEmpty(t, c.Res.DNSReport.DNSSEC.Signatures)
+			}
+		})
+	}
+
+	// Agent trust anchors
+	anchors, err := checker.ParseTrustAnchors(strings.NewReader(parent.Key.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checker.Configure(checker.Config{TrustAnchors: anchors})
+	t.Cleanup(func() { checker.Configure(checker.Config{}) })
+
+	req := buildCheck("dns://www.example.test")
+	req.Options.Resolver = types.Resolver{Nameservers: []string{conn.LocalAddr().String()}}
+	req.Options.DNSCheck = types.DNSCheck{DNSSEC: true}
+
+	c := checker.Init(req)
+	c.Perform()
+	a.Equal(t, "", c.Res.Error)
+	a.Equal(t, "secure", c.Res.DNSReport.DNSSEC.Status)
+}
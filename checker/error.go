@@ -1,7 +1,9 @@
 package checker
 
 import (
+	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"io"
 	"net"
 	"net/url"
@@ -14,6 +16,18 @@ import (
 	t "github.com/lucaspiller/watchsumo-checker/types"
 )
 
+// TLS alerts relating to certificates, see RFC 8446 section 6
+const (
+	alertHandshakeFailure       = 40
+	alertBadCertificate         = 42
+	alertUnsupportedCertificate = 43
+	alertCertificateRevoked     = 44
+	alertCertificateExpired     = 45
+	alertCertificateUnknown     = 46
+	alertUnknownCA              = 48
+	alertCertificateRequired    = 116
+)
+
 // UnwrappedError unwraps an error
 type UnwrappedError struct {
 	Err t.CheckError
@@ -27,11 +41,37 @@ func (e *UnwrappedError) ToString() string {
 }
 
 func (c *Checker) unwrapError(err error) UnwrappedError {
+	if unwrapped, ok := c.unwrapBindError(err); ok {
+		return unwrapped
+	}
+
+	if unwrapped, ok := unwrapProxyError(err); ok {
+		return unwrapped
+	}
+
 	switch err := err.(type) {
 	case *url.Error:
 		return c.unwrapURLError(err)
 
+	case *net.OpError:
+		return c.unwrapNetOpError(err)
+
+	case *net.DNSError:
+		return unwrapDNSError(err)
+
 	default:
+		if unwrapped, ok := c.unwrapTLSError(err); ok {
+			return unwrapped
+		}
+
+		if err == io.EOF {
+			return UnwrappedError{Err: t.Closed}
+		}
+
+		if err, ok := err.(net.Error); ok && err.Timeout() {
+			return UnwrappedError{Err: t.Timeout}
+		}
+
 		// Use reflection for non exported errors >_<
 		switch reflect.TypeOf(err).String() {
 		case "*http.httpError":
@@ -45,69 +85,132 @@ func (c *Checker) unwrapError(err error) UnwrappedError {
 }
 
 func (c *Checker) unwrapURLError(err *url.Error) UnwrappedError {
+	if unwrapped, ok := c.unwrapTLSError(err.Err); ok {
+		return unwrapped
+	}
+
 	switch err := err.Err.(type) {
+	case *net.OpError:
+		return c.unwrapNetOpError(err)
+
+	default:
+		if err == io.EOF {
+			return UnwrappedError{Err: t.Closed}
+		}
+
+		if err.Error() == errMaxRedirects.Error() {
+			return UnwrappedError{Err: t.MaxRedirects}
+		}
+
+		var authErr *authError
+		if errors.As(err, &authErr) {
+			return UnwrappedError{Err: authErr.code}
+		}
+
+		// Connection errors may be wrapped by the transport
+		var opErr *net.OpError
+		if errors.As(err, &opErr) {
+			return c.unwrapNetOpError(opErr)
+		}
+
+		if unwrapped, ok := c.unwrapQUICError(err); ok {
+			return unwrapped
+		}
+
+		// Use reflection for non exported errors >_<
+		switch reflect.TypeOf(err).String() {
+		case "*http.httpError":
+			v := reflect.ValueOf(err).Elem()
+			return c.unwrapHTTPError(v.FieldByName("err").String(), v.FieldByName("timeout").Bool())
+
+		default:
+			return c.unhandledError(err, "url.Error")
+		}
+	}
+}
+
+// unwrapTLSError classifies certificate and TLS protocol errors, these are
+// returned from both net/http and direct TLS connections
+func (c *Checker) unwrapTLSError(err error) (UnwrappedError, bool) {
+	switch err := err.(type) {
+	case *tls.CertificateVerificationError:
+		if unwrapped, ok := c.unwrapTLSError(err.Err); ok {
+			return unwrapped, true
+		}
+
+		return UnwrappedError{Err: t.TLSAlert, Cert: firstCert(err.UnverifiedCertificates)}, true
+
 	case x509.CertificateInvalidError:
 		if err.Reason == x509.Expired {
-			return UnwrappedError{Err: t.CertExpired, Cert: err.Cert}
+			return UnwrappedError{Err: t.CertExpired, Cert: err.Cert}, true
 		}
 
 		log.WithFields(log.Fields{
 			"reason": err.Reason,
 		}).Info("Unhandled error x509.CertificateInvalidError")
 
-		return UnwrappedError{Err: t.TLSAlert, Cert: err.Cert}
+		return UnwrappedError{Err: t.TLSAlert, Cert: err.Cert}, true
 
 	case x509.UnknownAuthorityError:
 		if err.Cert.IsCA {
-			return UnwrappedError{Err: t.CertUntrustedAuthority, Cert: err.Cert}
+			return UnwrappedError{Err: t.CertUntrustedAuthority, Cert: err.Cert}, true
 		}
 
 		// Self-signed certificates usually have the SubjectKeyId equal to
 		// AuthorityKeyId, this isn't always true though
 		if reflect.DeepEqual(err.Cert.SubjectKeyId, err.Cert.AuthorityKeyId) {
-			return UnwrappedError{Err: t.CertSelfSigned, Cert: err.Cert}
+			return UnwrappedError{Err: t.CertSelfSigned, Cert: err.Cert}, true
 		}
 
-		return UnwrappedError{Err: t.CertIncompleteChain, Cert: err.Cert}
+		return UnwrappedError{Err: t.CertIncompleteChain, Cert: err.Cert}, true
 
 	case x509.HostnameError:
-		return UnwrappedError{Err: t.CertWrongHost, Cert: err.Certificate}
+		return UnwrappedError{Err: t.CertWrongHost, Cert: err.Certificate}, true
 
-	case *net.OpError:
-		return c.unwrapNetOpError(err)
+	case tls.AlertError, tls.RecordHeaderError:
+		return UnwrappedError{Err: t.TLSAlert}, true
 
 	default:
-		if err == io.EOF {
-			return UnwrappedError{Err: t.Closed}
-		}
-
-		if err.Error() == errMaxRedirects.Error() {
-			return UnwrappedError{Err: t.MaxRedirects}
-		}
-
 		// Use reflection for non exported errors >_<
 		switch reflect.TypeOf(err).String() {
-		case "*http.httpError":
-			v := reflect.ValueOf(err).Elem()
-			return c.unwrapHTTPError(v.FieldByName("err").String(), v.FieldByName("timeout").Bool())
-
 		case "*tls.permamentError", "*tls.permanentError":
-			return UnwrappedError{Err: t.TLSAlert}
+			// Alerts received after the handshake are wrapped (TLS 1.3)
+			var opErr *net.OpError
+			if errors.As(err, &opErr) {
+				return c.unwrapNetOpError(opErr), true
+			}
+
+			return UnwrappedError{Err: t.TLSAlert}, true
+
+		case "tls.alert":
+			return UnwrappedError{Err: t.TLSAlert}, true
 
 		default:
-			return c.unhandledError(err, "url.Error")
+			return UnwrappedError{}, false
 		}
 	}
 }
 
 func (c *Checker) unwrapNetOpError(err *net.OpError) UnwrappedError {
+	if err.Timeout() {
+		return UnwrappedError{Err: t.Timeout}
+	}
+
+	// Alert sent by the server
+	if err.Op == "remote error" {
+		return c.unwrapRemoteAlert(err.Err)
+	}
+
 	switch err := err.Err.(type) {
-	case *net.DNSError:
-		if err.IsNotFound {
-			return UnwrappedError{Err: t.NxDomain}
+	case *net.AddrError:
+		if err.Err == noSuitableAddress {
+			return UnwrappedError{Err: t.NoSuitableAddress}
 		}
 
-		return UnwrappedError{Err: t.DNSError}
+		return c.unhandledError(err, "net.AddrError")
+
+	case *net.DNSError:
+		return unwrapDNSError(err)
 
 	case *os.SyscallError:
 		if err.Err.Error() == syscall.ECONNRESET.Error() {
@@ -125,14 +228,49 @@ func (c *Checker) unwrapNetOpError(err *net.OpError) UnwrappedError {
 		return c.unhandledError(err, "os.SyscallError")
 
 	default:
-		// Use reflection for non exported errors >_<
-		switch reflect.TypeOf(err).String() {
-		case "tls.alert":
-			return UnwrappedError{Err: t.TLSAlert}
+		if unwrapped, ok := c.unwrapTLSError(err); ok {
+			return unwrapped
+		}
 
-		default:
-			return c.unhandledError(err, "net.OpError")
+		return c.unhandledError(err, "net.OpError")
+	}
+}
+
+func unwrapDNSError(err *net.DNSError) UnwrappedError {
+	if err.IsTimeout {
+		return UnwrappedError{Err: t.Timeout}
+	}
+
+	if err.IsNotFound {
+		return UnwrappedError{Err: t.NxDomain}
+	}
+
+	return UnwrappedError{Err: t.DNSError}
+}
+
+// unwrapRemoteAlert classifies a TLS alert sent by the server. Alerts about
+// certificates can only refer to the client certificate, as the server
+// doesn't verify its own. Servers using TLS 1.2 may also send a generic
+// handshake failure if the certificate is missing.
+func (c *Checker) unwrapRemoteAlert(err error) UnwrappedError {
+	if reflect.TypeOf(err).String() != "tls.alert" {
+		return UnwrappedError{Err: t.TLSAlert}
+	}
+
+	switch reflect.ValueOf(err).Uint() {
+	case alertBadCertificate, alertUnsupportedCertificate, alertCertificateRevoked,
+		alertCertificateExpired, alertCertificateUnknown, alertUnknownCA, alertCertificateRequired:
+		return UnwrappedError{Err: t.ClientCertRejected}
+
+	case alertHandshakeFailure:
+		if c.clientCertRequested {
+			return UnwrappedError{Err: t.ClientCertRejected}
 		}
+
+		return UnwrappedError{Err: t.TLSAlert}
+
+	default:
+		return UnwrappedError{Err: t.TLSAlert}
 	}
 }
 
@@ -144,6 +282,14 @@ func (c *Checker) unwrapHTTPError(message string, timeout bool) UnwrappedError {
 	return UnwrappedError{Err: t.UnknownError}
 }
 
+func firstCert(certs []*x509.Certificate) *x509.Certificate {
+	if len(certs) == 0 {
+		return nil
+	}
+
+	return certs[0]
+}
+
 func (c *Checker) unhandledError(err error, parent string) UnwrappedError {
 	log.WithFields(log.Fields{
 		"Ref":    c.Req.Ref,
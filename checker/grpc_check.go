@@ -0,0 +1,168 @@
+package checker
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+var (
+	errConnectionUsed = errors.New("Connection already used")
+)
+
+// performGRPCCheck calls the standard health service, and checks the
+// service is serving
+func (c *Checker) performGRPCCheck() bool {
+	opts := c.Req.Options.GRPCCheck
+	useTLS := opts.TLS || c.Req.URL.Scheme == "grpcs"
+
+	if err := ValidateResolver(c.resolver()); err != nil {
+		return c.handleFailure("Invalid resolver configuration", types.ResolverInvalid.ToString())
+	}
+
+	config, configErr := c.tlsConfig()
+	if configErr != "" {
+		return c.handleFailure("Invalid TLS configuration", configErr.ToString())
+	}
+	config.ServerName = c.Req.URL.Hostname()
+	config.NextProtos = []string{"h2"}
+
+	defaultPort := "80"
+	if useTLS {
+		defaultPort = defaultTLSPort
+	}
+	addr := hostPort(c.Req.URL, defaultPort)
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout())
+	defer cancel()
+
+	// Instrument the connection, and extract timings at various points
+	ctx, trace := withConnTrace(ctx)
+
+	// The connection is established here rather than by grpc, so errors and
+	// timings are the same as for other checks
+	conn, err := c.dialContext(ctx, "tcp", addr)
+	if err != nil {
+		return c.handleError("Error connecting", err)
+	}
+	defer conn.Close()
+
+	var state *tls.ConnectionState
+	if useTLS {
+		deadline, _ := ctx.Deadline()
+		conn.SetDeadline(deadline)
+
+		tlsConn, err := trace.handshake(conn, config)
+		if err != nil {
+			return c.handleError("Error performing TLS handshake", err)
+		}
+
+		conn.SetDeadline(time.Time{})
+
+		s := tlsConn.ConnectionState()
+		state = &s
+		conn = tlsConn
+
+		c.Res.Certificate = certInfoFromTLSConnectionState(state)
+		c.Res.Proto = tls.VersionName(state.Version)
+	}
+
+	// grpc may try to reconnect, but only the first connection is allowed
+	var used int32
+	dialer := func(_ context.Context, _ string) (net.Conn, error) {
+		if !atomic.CompareAndSwapInt32(&used, 0, 1) {
+			return nil, errConnectionUsed
+		}
+
+		return conn, nil
+	}
+
+	// TLS has already been negotiated, so the transport is insecure from the
+	// point of view of grpc
+	client, err := grpc.DialContext(ctx, "passthrough:///"+addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(dialer),
+		grpc.WithAuthority(c.Req.URL.Host),
+		grpc.WithUserAgent(userAgent),
+	)
+	if err != nil {
+		return c.handleError("Error creating client", err)
+	}
+	defer client.Close()
+
+	report := &types.GRPCReport{
+		Service: opts.Service,
+	}
+	c.Res.GRPC = report
+
+	callStart := time.Now()
+	resp, err := healthpb.NewHealthClient(client).Check(ctx, &healthpb.HealthCheckRequest{Service: opts.Service})
+	callDone := time.Now()
+
+	report.Code = status.Code(err).String()
+
+	// The call is a single request and response, so only the total time is
+	// known
+	var sending, receiving time.Duration
+	waiting := callDone.Sub(callStart).Truncate(time.Millisecond)
+
+	c.Res.Timing = trace.timing()
+	c.Res.Timing.Sending = &sending
+	c.Res.Timing.Waiting = &waiting
+	c.Res.Timing.Receiving = &receiving
+
+	if err != nil {
+		return c.handleGRPCError(err)
+	}
+
+	report.Status = resp.Status.String()
+
+	switch resp.Status {
+	case healthpb.HealthCheckResponse_SERVING:
+	case healthpb.HealthCheckResponse_NOT_SERVING:
+		return c.handleFailure("Service not serving", types.GRPCNotServing.ToString())
+	default:
+		// The server doesn't know the status yet, e.g. it's still starting
+		return c.handleUnknown("Service status unknown", types.GRPCHealthUnknown.ToString())
+	}
+
+	// Perform optional checks on the certificate
+	if state != nil {
+		if err := c.checkCertificate(state); err != "" {
+			return c.handleFailure("Certificate check failed", err.ToString())
+		}
+	}
+
+	return c.handleSuccessSince(trace.start())
+}
+
+// handleGRPCError classifies the status code of a failed call
+func (c *Checker) handleGRPCError(err error) bool {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return c.handleFailure("Service not found", types.GRPCServiceNotFound.ToString())
+
+	case codes.Unimplemented:
+		return c.handleFailure("Health service not implemented", types.GRPCUnimplemented.ToString())
+
+	case codes.DeadlineExceeded:
+		return c.handleFailure("Call timed out", types.Timeout.ToString())
+
+	case codes.Unavailable:
+		return c.handleFailure("Service unavailable", types.GRPCUnavailable.ToString())
+
+	default:
+		return c.handleFailure("Call failed", types.GRPCError.ToString())
+	}
+}
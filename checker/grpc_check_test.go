@@ -0,0 +1,147 @@
+package checker_test
+
+import (
+	"crypto/tls"
+	"net"
+	"testing"
+
+	a "github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// startGRPCServer starts a gRPC server, optionally with the health service
+// and TLS, returning its address
+func startGRPCServer(t *testing.T, healthServer *health.Server, config *tls.Config) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var opts []grpc.ServerOption
+	if config != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(config)))
+	}
+
+	server := grpc.NewServer(opts...)
+	if healthServer != nil {
+		healthpb.RegisterHealthServer(server, healthServer)
+	}
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String()
+}
+
+func TestGRPCCheck(t *testing.T) {
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("checker.Serving", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("checker.NotServing", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthServer.SetServingStatus("checker.Unknown", healthpb.HealthCheckResponse_UNKNOWN)
+
+	addr := startGRPCServer(t, healthServer, nil)
+	unimplemented := startGRPCServer(t, nil, nil)
+
+	tests := []struct {
+		name         string
+		url          string
+		service      string
+		status       types.CheckStatus
+		error        string
+		healthStatus string
+		code         string
+	}{
+		{"server", "grpc://" + addr, "", types.StatusUp, "", "SERVING", "OK"},
+		{"serving", "grpc://" + addr, "checker.Serving", types.StatusUp, "", "SERVING", "OK"},
+		{"not serving", "grpc://" + addr, "checker.NotServing", types.StatusDown, types.GRPCNotServing.ToString(), "NOT_SERVING", "OK"},
+		{"unknown", "grpc://" + addr, "checker.Unknown", types.StatusUnknown, types.GRPCHealthUnknown.ToString(), "UNKNOWN", "OK"},
+		{"not found", "grpc://" + addr, "checker.Missing", types.StatusDown, types.GRPCServiceNotFound.ToString(), "", "NotFound"},
+		{"unimplemented", "grpc://" + unimplemented, "", types.StatusDown, types.GRPCUnimplemented.ToString(), "", "Unimplemented"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := buildCheck(tt.url)
+			req.Options.GRPCCheck = types.GRPCCheck{Service: tt.service}
+
+			c := checker.Init(req)
+			c.Perform()
+
+			a.Equal(t, tt.error, c.Res.Error)
+			a.Equal(t, tt.status, c.Res.Status)
+			a.Equal(t, tt.error == "", c.Success)
+			a.Equal(t, tt.service, c.Res.GRPC.Service)
+			a.Equal(t, tt.healthStatus, c.Res.GRPC.Status)
+			a.Equal(t, tt.code, c.Res.GRPC.Code)
+			a.NotNil(t, c.Res.Timing.Waiting)
+		})
+	}
+}
+
+func TestGRPCCheckConnectionRefused(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	req := buildCheck("grpc://" + addr)
+
+	c := checker.Init(req)
+	c.Perform()
+	a.Equal(t, types.ConnectionRefused.ToString(), c.Res.Error)
+}
+
+func TestGRPCCheckTLS(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+	cert := issueCert(t, certTemplate(), nil, ca)
+	caPEM, _ := ca.PEM(t)
+
+	addr := startGRPCServer(t, health.NewServer(), &tls.Config{Certificates: []tls.Certificate{cert.TLSCertificate(ca)}})
+
+	for _, tt := range []struct {
+		url   string
+		check types.GRPCCheck
+	}{
+		{"grpcs://" + addr, types.GRPCCheck{}},
+		{"grpc://" + addr, types.GRPCCheck{TLS: true}},
+	} {
+		t.Run(tt.url, func(t *testing.T) {
+			req := buildCheck(tt.url)
+			req.Options.GRPCCheck = tt.check
+			req.Options.TrustedCAs = types.TrustedCAs{PEM: caPEM}
+
+			c := checker.Init(req)
+			c.Perform()
+
+			a.Equal(t, "", c.Res.Error)
+			a.Equal(t, "SERVING", c.Res.GRPC.Status)
+			a.Equal(t, "TLS 1.3", c.Res.Proto)
+			a.Equal(t, cert.Cert.SerialNumber.Bytes(), c.Res.Certificate.Serial)
+
+			// Certificate errors are classified the same as for HTTPS
+			req = buildCheck(tt.url)
+			req.Options.GRPCCheck = tt.check
+
+			c = checker.Init(req)
+			c.Perform()
+			a.Equal(t, types.CertUntrustedAuthority.ToString(), c.Res.Error)
+		})
+	}
+
+	// Plaintext connection to a TLS server
+	req := buildCheck("grpc://" + addr)
+
+	c := checker.Init(req)
+	c.Perform()
+	a.Equal(t, types.GRPCUnavailable.ToString(), c.Res.Error)
+}
@@ -0,0 +1,129 @@
+package checker_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type testCert struct {
+	Cert *x509.Certificate
+	Key  crypto.Signer
+}
+
+// TLSCertificate returns the certificate and chain for use by a server
+func (c *testCert) TLSCertificate(chain ...*testCert) tls.Certificate {
+	res := tls.Certificate{
+		Certificate: [][]byte{c.Cert.Raw},
+		PrivateKey:  c.Key,
+		Leaf:        c.Cert,
+	}
+
+	for _, cert := range chain {
+		res.Certificate = append(res.Certificate, cert.Cert.Raw)
+	}
+
+	return res
+}
+
+// PEM returns the PEM encoded certificate and private key
+func (c *testCert) PEM(t *testing.T) ([]byte, []byte) {
+	key, err := x509.MarshalPKCS8PrivateKey(c.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.Cert.Raw})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key})
+
+	return certPEM, keyPEM
+}
+
+// certTemplate returns a template for a certificate valid for localhost
+func certTemplate() *x509.Certificate {
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+}
+
+// caTemplate returns a template for a certificate authority
+func caTemplate(name string) *x509.Certificate {
+	template := certTemplate()
+	template.Subject = pkix.Name{CommonName: name, Organization: []string{"WatchSumo Test"}}
+	template.DNSNames = nil
+	template.IPAddresses = nil
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = nil
+	template.NotAfter = time.Now().Add(365 * 24 * time.Hour)
+
+	return template
+}
+
+// issueCert signs the template with the parent, or self-signs it if the
+// parent is nil. If key is nil a P-256 key is generated.
+func issueCert(t *testing.T, template *x509.Certificate, key crypto.Signer, parent *testCert) *testCert {
+	t.Helper()
+
+	if key == nil {
+		var err error
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	parentCert, parentKey := template, key
+	if parent != nil {
+		parentCert, parentKey = parent.Cert, parent.Key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, key.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testCert{Cert: cert, Key: key}
+}
+
+// startTLSServer starts a HTTPS server presenting the certificate
+func startTLSServer(t *testing.T, cert tls.Certificate, handler http.Handler) *httptest.Server {
+	t.Helper()
+
+	if handler == nil {
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	}
+
+	server := httptest.NewUnstartedServer(handler)
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	return server
+}
@@ -0,0 +1,535 @@
+package checker
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+const (
+	// Tokens are fetched again shortly before they expire, so they don't
+	// expire during the check
+	tokenExpiryMargin = 30 * time.Second
+)
+
+// authError is an error authenticating, returned from the transport
+type authError struct {
+	code    types.CheckError
+	message string
+}
+
+func (e *authError) Error() string {
+	return e.message
+}
+
+// credentials are the username and password from the secret store
+type credentials struct {
+	username string
+	password string
+}
+
+// authTransport authenticates requests to the host being checked
+type authTransport struct {
+	base    http.RoundTripper
+	checker *Checker
+
+	// Transport for the token endpoint, which is a different host so the
+	// target's connection settings don't apply
+	tokenTransport *http.Transport
+
+	auth        types.HTTPAuth
+	credentials credentials
+
+	// Last Digest challenge received, so later requests can authenticate
+	// without another round trip
+	mu         sync.Mutex
+	challenge  *digestChallenge
+	nonceCount int
+}
+
+// authenticatedTransport wraps the transport to authenticate requests, if the
+// check uses authentication
+func (c *Checker) authenticatedTransport(base http.RoundTripper) (http.RoundTripper, types.CheckError) {
+	auth := c.Req.Options.HTTPAuth
+
+	switch auth.Scheme {
+	case types.AuthNone:
+		return base, ""
+
+	case types.AuthBasic, types.AuthDigest:
+
+	case types.AuthOAuth2:
+		if u, err := url.Parse(auth.TokenURL); err != nil || u.Host == "" {
+			return nil, types.AuthInvalid
+		}
+
+	default:
+		return nil, types.AuthInvalid
+	}
+
+	creds, err := c.lookupCredentials(auth.Credentials)
+	if err != "" {
+		return nil, err
+	}
+
+	res := &authTransport{
+		base:        base,
+		checker:     c,
+		auth:        auth,
+		credentials: creds,
+	}
+
+	if auth.Scheme == types.AuthOAuth2 {
+		res.tokenTransport = c.newTokenTransport()
+	}
+
+	return res, ""
+}
+
+// newTokenTransport creates the transport for requests to the token endpoint.
+// These use the system's trusted CAs and the default protocols rather than
+// the target's settings.
+func (c *Checker) newTokenTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = c.dialDirect
+
+	if c.proxy() != nil {
+		transport.Proxy = c.plainHTTPProxy
+	}
+
+	return transport
+}
+
+// lookupCredentials returns the credentials with the name from the secret
+// store
+func (c *Checker) lookupCredentials(name string) (credentials, types.CheckError) {
+	secret, ok := lookupSecret(name)
+	if !ok {
+		log.WithFields(log.Fields{
+			"Ref":  c.Req.Ref,
+			"Name": name,
+		}).Warn("Unknown credentials")
+		return credentials{}, types.AuthCredentialsInvalid
+	}
+
+	parts := strings.SplitN(secret, ":", 2)
+	if len(parts) != 2 {
+		log.WithFields(log.Fields{
+			"Ref":  c.Req.Ref,
+			"Name": name,
+		}).Warn("Credentials aren't in the format username:password")
+		return credentials{}, types.AuthCredentialsInvalid
+	}
+
+	return credentials{username: parts[0], password: parts[1]}, ""
+}
+
+// statusError returns the error for an unsuccessful status code
+func (c *Checker) statusError(statusCode int) string {
+	if statusCode == http.StatusUnauthorized && c.Req.Options.HTTPAuth.Scheme != types.AuthNone {
+		return types.AuthFailed.ToString()
+	}
+
+	// Plain HTTP requests are sent to the proxy, so it responds directly
+	if statusCode == http.StatusProxyAuthRequired && c.proxy() != nil {
+		return types.ProxyAuthFailed.ToString()
+	}
+
+	return fmt.Sprintf("%d", statusCode)
+}
+
+// CloseIdleConnections closes the base transport's idle connections, so the
+// client can release them
+func (t *authTransport) CloseIdleConnections() {
+	if closer, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+
+	if t.tokenTransport != nil {
+		t.tokenTransport.CloseIdleConnections()
+	}
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Don't send credentials to other hosts after redirects
+	if req.URL.Host != t.checker.Req.URL.Host {
+		return t.base.RoundTrip(req)
+	}
+
+	switch t.auth.Scheme {
+	case types.AuthBasic:
+		req = req.Clone(req.Context())
+		req.SetBasicAuth(t.credentials.username, t.credentials.password)
+
+		return t.base.RoundTrip(req)
+
+	case types.AuthDigest:
+		return t.roundTripDigest(req)
+
+	default:
+		return t.roundTripOAuth2(req)
+	}
+}
+
+// roundTripDigest sends the request, and if the server responds with a
+// challenge sends it again with the response to the challenge
+func (t *authTransport) roundTripDigest(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Authenticate without a round trip if there's already a challenge
+	first := req
+	if t.challenge != nil {
+		first = t.digestRequest(req)
+	}
+
+	resp, err := t.base.RoundTrip(first)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	challenge, err := parseDigestChallenge(resp.Header.Values("WWW-Authenticate"))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	// The previous challenge was rejected and the nonce is the same, so the
+	// credentials are wrong
+	if t.challenge != nil && !challenge.stale && challenge.nonce == t.challenge.nonce {
+		return resp, nil
+	}
+
+	discardBody(resp)
+
+	if req.Body != nil && req.GetBody == nil {
+		return nil, &authError{code: types.AuthInvalid, message: "Request body can't be sent again for Digest authentication"}
+	}
+
+	t.challenge = challenge
+	t.nonceCount = 0
+
+	return t.base.RoundTrip(t.digestRequest(req))
+}
+
+// digestRequest returns a copy of the request with the response to the
+// challenge
+func (t *authTransport) digestRequest(req *http.Request) *http.Request {
+	res := req.Clone(req.Context())
+	if req.GetBody != nil {
+		res.Body, _ = req.GetBody()
+	}
+
+	t.nonceCount++
+	res.Header.Set("Authorization", t.challenge.authorization(t.credentials, req.Method, req.URL.RequestURI(), t.nonceCount))
+
+	return res
+}
+
+// roundTripOAuth2 sends the request with an access token
+func (t *authTransport) roundTripOAuth2(req *http.Request) (*http.Response, error) {
+	key := tokenCacheKey(t.auth)
+
+	token, err := t.accessToken(req.Context(), key)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := t.base.RoundTrip(req)
+
+	// The token may have been revoked, so fetch a new one for the next check
+	if err == nil && resp.StatusCode == http.StatusUnauthorized {
+		tokens.remove(key)
+	}
+
+	return resp, err
+}
+
+// accessToken returns a cached token, or fetches a new one from the token
+// endpoint. The request shares the check request's deadline, but not its
+// trace, so it isn't recorded in the check's timings.
+func (t *authTransport) accessToken(ctx context.Context, key string) (string, error) {
+	if token, ok := tokens.get(key); ok {
+		return token, nil
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(t.auth.Scopes) > 0 {
+		form.Set("scope", strings.Join(t.auth.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(untracedContext{ctx}, http.MethodPost, t.auth.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", &authError{code: types.AuthInvalid, message: "Invalid token URL"}
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+
+	// Client credentials are form encoded before Basic encoding, see RFC 6749
+	// section 2.3.1
+	req.SetBasicAuth(url.QueryEscape(t.credentials.username), url.QueryEscape(t.credentials.password))
+
+	// Errors connecting to the token endpoint aren't errors with the target
+	resp, err := t.tokenTransport.RoundTrip(req)
+	if err != nil {
+		return "", &authError{code: types.OAuth2TokenFailed, message: fmt.Sprintf("Error requesting token: %v", err)}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", &authError{code: types.OAuth2TokenFailed, message: fmt.Sprintf("Token endpoint returned %d", resp.StatusCode)}
+	}
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", &authError{code: types.OAuth2TokenFailed, message: "Invalid token response"}
+	}
+
+	if body.AccessToken == "" || !strings.EqualFold(body.TokenType, "bearer") {
+		return "", &authError{code: types.OAuth2TokenFailed, message: "Token response doesn't contain a bearer token"}
+	}
+
+	// Tokens without an expiry time aren't cached
+	if body.ExpiresIn > 0 {
+		tokens.set(key, body.AccessToken, time.Now().Add(time.Duration(body.ExpiresIn)*time.Second-tokenExpiryMargin))
+	}
+
+	return body.AccessToken, nil
+}
+
+// discardBody reads the rest of the body so the connection can be reused
+func discardBody(resp *http.Response) {
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+}
+
+// tokenCache caches OAuth2 access tokens for all checks
+type tokenCache struct {
+	mu     sync.Mutex
+	tokens map[string]cachedToken
+}
+
+type cachedToken struct {
+	value   string
+	expires time.Time
+}
+
+var (
+	tokens = &tokenCache{tokens: map[string]cachedToken{}}
+)
+
+func tokenCacheKey(auth types.HTTPAuth) string {
+	return strings.Join([]string{auth.TokenURL, auth.Credentials, strings.Join(auth.Scopes, " ")}, "\x00")
+}
+
+func (c *tokenCache) get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	token, ok := c.tokens[key]
+	if !ok || time.Now().After(token.expires) {
+		return "", false
+	}
+
+	return token.value, true
+}
+
+func (c *tokenCache) set(key, value string, expires time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.tokens[key] = cachedToken{value: value, expires: expires}
+}
+
+func (c *tokenCache) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.tokens, key)
+}
+
+// digestChallenge is a Digest challenge from the WWW-Authenticate header,
+// see RFC 7616
+type digestChallenge struct {
+	realm     string
+	nonce     string
+	opaque    string
+	algorithm string
+	qop       string
+	stale     bool
+}
+
+// parseDigestChallenge finds a supported Digest challenge in the headers
+func parseDigestChallenge(headers []string) (*digestChallenge, error) {
+	for _, header := range headers {
+		if len(header) < 7 || !strings.EqualFold(header[:7], "digest ") {
+			continue
+		}
+
+		params := parseAuthParams(header[7:])
+
+		challenge := &digestChallenge{
+			realm:     params["realm"],
+			nonce:     params["nonce"],
+			opaque:    params["opaque"],
+			algorithm: params["algorithm"],
+			stale:     strings.EqualFold(params["stale"], "true"),
+		}
+
+		if challenge.nonce == "" || challenge.hash() == nil {
+			continue
+		}
+
+		// Only auth is supported, not auth-int
+		if qop, ok := params["qop"]; ok {
+			for _, value := range strings.Split(qop, ",") {
+				if strings.TrimSpace(value) == "auth" {
+					challenge.qop = "auth"
+				}
+			}
+
+			if challenge.qop == "" {
+				continue
+			}
+		}
+
+		return challenge, nil
+	}
+
+	return nil, &authError{code: types.AuthChallengeInvalid, message: "No supported Digest challenge"}
+}
+
+// parseAuthParams parses comma separated name=value pairs, where the value
+// may be quoted
+func parseAuthParams(s string) map[string]string {
+	res := map[string]string{}
+
+	for {
+		s = strings.TrimLeft(s, " ,")
+
+		i := strings.IndexByte(s, '=')
+		if i <= 0 {
+			return res
+		}
+
+		name := strings.ToLower(strings.TrimSpace(s[:i]))
+		s = strings.TrimLeft(s[i+1:], " ")
+
+		var value strings.Builder
+		if strings.HasPrefix(s, `"`) {
+			i = 1
+			for ; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' && i+1 < len(s) {
+					i++
+				}
+				value.WriteByte(s[i])
+			}
+			if i < len(s) {
+				i++
+			}
+			s = s[i:]
+		} else {
+			i = strings.IndexByte(s, ',')
+			if i < 0 {
+				i = len(s)
+			}
+			value.WriteString(strings.TrimSpace(s[:i]))
+			s = s[i:]
+		}
+
+		res[name] = value.String()
+	}
+}
+
+// hash returns the hash function for the algorithm, or nil if it isn't
+// supported
+func (d *digestChallenge) hash() func() hash.Hash {
+	switch strings.ToUpper(strings.TrimSuffix(strings.ToLower(d.algorithm), "-sess")) {
+	case "", "MD5":
+		return md5.New
+	case "SHA-256":
+		return sha256.New
+	default:
+		return nil
+	}
+}
+
+// authorization returns the Authorization header responding to the challenge
+func (d *digestChallenge) authorization(creds credentials, method, uri string, nonceCount int) string {
+	newHash := d.hash()
+	h := func(s string) string {
+		hash := newHash()
+		hash.Write([]byte(s))
+		return hex.EncodeToString(hash.Sum(nil))
+	}
+
+	cnonce := make([]byte, 16)
+	rand.Read(cnonce)
+	cnonceHex := hex.EncodeToString(cnonce)
+	nc := fmt.Sprintf("%08x", nonceCount)
+
+	ha1 := h(creds.username + ":" + d.realm + ":" + creds.password)
+	if strings.HasSuffix(strings.ToLower(d.algorithm), "-sess") {
+		ha1 = h(ha1 + ":" + d.nonce + ":" + cnonceHex)
+	}
+	ha2 := h(method + ":" + uri)
+
+	var response string
+	if d.qop == "" {
+		response = h(ha1 + ":" + d.nonce + ":" + ha2)
+	} else {
+		response = h(strings.Join([]string{ha1, d.nonce, nc, cnonceHex, d.qop, ha2}, ":"))
+	}
+
+	params := []string{
+		"username=" + quoteString(creds.username),
+		"realm=" + quoteString(d.realm),
+		"nonce=" + quoteString(d.nonce),
+		"uri=" + quoteString(uri),
+		"response=" + quoteString(response),
+	}
+
+	if d.algorithm != "" {
+		params = append(params, "algorithm="+d.algorithm)
+	}
+
+	if d.opaque != "" {
+		params = append(params, "opaque="+quoteString(d.opaque))
+	}
+
+	if d.qop != "" {
+		params = append(params, "qop="+d.qop, "nc="+nc, "cnonce="+quoteString(cnonceHex))
+	}
+
+	return "Digest " + strings.Join(params, ", ")
+}
+
+// quoteString returns the string as a HTTP quoted-string
+func quoteString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
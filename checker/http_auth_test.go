@@ -0,0 +1,244 @@
+package checker_test
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	a "github.com/stretchr/testify/assert"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+var (
+	authParamPattern = regexp.MustCompile(`(\w+)=(?:"([^"]*)"|([^,\s]*))`)
+)
+
+// authServer is a server with endpoints requiring each authentication scheme
+type authServer struct {
+	*httptest.Server
+
+	// Number of Digest challenges and tokens issued
+	challenges int32
+	tokens     int32
+}
+
+func startAuthServer(t *testing.T) *authServer {
+	server := &authServer{}
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/basic", func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "alice" || password != "s3cr3t" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	})
+
+	mux.HandleFunc("/digest", func(w http.ResponseWriter, r *http.Request) {
+		algorithm := r.URL.Query().Get("algorithm")
+		if !validDigest(r, algorithm) {
+			atomic.AddInt32(&server.challenges, 1)
+			w.Header().Add("WWW-Authenticate", `Basic realm="test"`)
+			w.Header().Add("WWW-Authenticate", fmt.Sprintf(`Digest realm="test", qop="auth,auth-int", nonce="n0nc3", opaque="0paque", algorithm=%s`, algorithm))
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	})
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, _ := r.BasicAuth()
+		if clientID != "alice" || clientSecret != "s3cr3t" || r.FormValue("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		atomic.AddInt32(&server.tokens, 1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token": "t0k3n-%s", "token_type": "Bearer", "expires_in": 3600}`, r.FormValue("scope"))
+	})
+
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer t0k3n-read" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	})
+
+	server.Server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	checker.Configure(checker.Config{Secrets: map[string]string{
+		"alice":   "alice:s3cr3t",
+		"mallory": "mallory:guess",
+		"invalid": "alice",
+	}})
+	t.Cleanup(func() { checker.Configure(checker.Config{}) })
+
+	return server
+}
+
+// validDigest checks the response to the Digest challenge
+func validDigest(r *http.Request, algorithm string) bool {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "Digest ") {
+		return false
+	}
+
+	params := map[string]string{}
+	for _, match := range authParamPattern.FindAllStringSubmatch(header, -1) {
+		params[match[1]] = match[2] + match[3]
+	}
+
+	newHash := md5.New
+	if algorithm == "SHA-256" {
+		newHash = func() hash.Hash { return sha256.New() }
+	}
+	h := func(parts ...string) string {
+		hash := newHash()
+		hash.Write([]byte(strings.Join(parts, ":")))
+		return hex.EncodeToString(hash.Sum(nil))
+	}
+
+	ha1 := h("alice", "test", "s3cr3t")
+	ha2 := h(r.Method, r.URL.RequestURI())
+
+	return params["username"] == "alice" &&
+		params["opaque"] == "0paque" &&
+		params["uri"] == r.URL.RequestURI() &&
+		params["qop"] == "auth" &&
+		params["response"] == h(ha1, "n0nc3", params["nc"], params["cnonce"], "auth", ha2)
+}
+
+func TestHTTPAuth(t *testing.T) {
+	server := startAuthServer(t)
+
+	tests := []struct {
+		name  string
+		path  string
+		auth  types.HTTPAuth
+		error string
+	}{
+		{"none", "/basic", types.HTTPAuth{}, "401"},
+		{"basic", "/basic", types.HTTPAuth{Scheme: types.AuthBasic, Credentials: "alice"}, ""},
+		{"basic rejected", "/basic", types.HTTPAuth{Scheme: types.AuthBasic, Credentials: "mallory"}, types.AuthFailed.ToString()},
+		{"digest", "/digest", types.HTTPAuth{Scheme: types.AuthDigest, Credentials: "alice"}, ""},
+		{"digest md5", "/digest?algorithm=MD5", types.HTTPAuth{Scheme: types.AuthDigest, Credentials: "alice"}, ""},
+		{"digest sha-256", "/digest?algorithm=SHA-256", types.HTTPAuth{Scheme: types.AuthDigest, Credentials: "alice"}, ""},
+		{"digest rejected", "/digest", types.HTTPAuth{Scheme: types.AuthDigest, Credentials: "mallory"}, types.AuthFailed.ToString()},
+		{"digest unsupported algorithm", "/digest?algorithm=SHA-512-256", types.HTTPAuth{Scheme: types.AuthDigest, Credentials: "alice"}, types.AuthChallengeInvalid.ToString()},
+		{"digest no challenge", "/basic", types.HTTPAuth{Scheme: types.AuthDigest, Credentials: "alice"}, types.AuthChallengeInvalid.ToString()},
+		{"unknown credentials", "/basic", types.HTTPAuth{Scheme: types.AuthBasic, Credentials: "bob"}, types.AuthCredentialsInvalid.ToString()},
+		{"invalid credentials", "/basic", types.HTTPAuth{Scheme: types.AuthBasic, Credentials: "invalid"}, types.AuthCredentialsInvalid.ToString()},
+		{"invalid scheme", "/basic", types.HTTPAuth{Scheme: "ntlm", Credentials: "alice"}, types.AuthInvalid.ToString()},
+		{"oauth2", "/api", types.HTTPAuth{Scheme: types.AuthOAuth2, Credentials: "alice", TokenURL: server.URL + "/token", Scopes: []string{"read"}}, ""},
+		{"oauth2 scope rejected", "/api", types.HTTPAuth{Scheme: types.AuthOAuth2, Credentials: "alice", TokenURL: server.URL + "/token", Scopes: []string{"write"}}, types.AuthFailed.ToString()},
+		{"oauth2 token rejected", "/api", types.HTTPAuth{Scheme: types.AuthOAuth2, Credentials: "mallory", TokenURL: server.URL + "/token"}, types.OAuth2TokenFailed.ToString()},
+		{"oauth2 token endpoint refused", "/api", types.HTTPAuth{Scheme: types.AuthOAuth2, Credentials: "alice", TokenURL: "http://" + closedAddress(t) + "/token"}, types.OAuth2TokenFailed.ToString()},
+		{"oauth2 no token url", "/api", types.HTTPAuth{Scheme: types.AuthOAuth2, Credentials: "alice"}, types.AuthInvalid.ToString()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := buildCheck(server.URL + tt.path)
+			req.Options.HTTPAuth = tt.auth
+
+			c := checker.Init(req)
+			c.Perform()
+
+			a.Equal(t, tt.error, c.Res.Error)
+			a.Equal(t, tt.error == "", c.Success)
+		})
+	}
+}
+
+func TestHTTPAuthOAuth2TokenCache(t *testing.T) {
+	server := startAuthServer(t)
+	auth := types.HTTPAuth{Scheme: types.AuthOAuth2, Credentials: "alice", TokenURL: server.URL + "/token", Scopes: []string{"read"}}
+
+	for i := 0; i < 3; i++ {
+		req := buildCheck(server.URL + "/api")
+		req.Options.HTTPAuth = auth
+
+		c := checker.Init(req)
+		c.Perform()
+		a.Equal(t, "", c.Res.Error)
+	}
+
+	a.Equal(t, int32(1), atomic.LoadInt32(&server.tokens))
+}
+
+func TestHTTPAuthOAuth2TokenProtocol(t *testing.T) {
+	server := startAuthServer(t)
+
+	// The target only speaks h2c, which the token endpoint doesn't
+	api := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer t0k3n-read" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	api.Config.Protocols = new(http.Protocols)
+	api.Config.Protocols.SetUnencryptedHTTP2(true)
+	api.Start()
+	t.Cleanup(api.Close)
+
+	req := buildCheck(api.URL)
+	req.Options.HTTPProtocol = types.HTTPProtocolH2C
+	req.Options.HTTPAuth = types.HTTPAuth{Scheme: types.AuthOAuth2, Credentials: "alice", TokenURL: server.URL + "/token", Scopes: []string{"read"}}
+
+	c := checker.Init(req)
+	c.Perform()
+
+	a.Equal(t, "", c.Res.Error)
+	a.Equal(t, "HTTP/2.0", c.Res.Proto)
+	a.Equal(t, int32(1), atomic.LoadInt32(&server.tokens))
+}
+
+func TestHTTPAuthDigestTransaction(t *testing.T) {
+	server := startAuthServer(t)
+
+	req := buildCheck(server.URL)
+	req.Type = "transaction"
+	req.Options.HTTPAuth = types.HTTPAuth{Scheme: types.AuthDigest, Credentials: "alice"}
+	req.Options.Transaction = types.Transaction{Steps: []types.TransactionStep{
+		{URL: "/digest"},
+		{Method: "POST", URL: "/digest", Body: "data"},
+		{URL: "/digest?algorithm=MD5"},
+	}}
+
+	c := checker.Init(req)
+	c.Perform()
+
+	a.Equal(t, "", c.Res.Error)
+
+	// Later steps respond to the first challenge, without another round trip
+	a.Equal(t, int32(1), atomic.LoadInt32(&server.challenges))
+}
+
+func TestHTTPAuthRedirect(t *testing.T) {
+	var authorization atomic.Value
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization.Store(r.Header.Get("Authorization"))
+	}))
+	t.Cleanup(other.Close)
+
+	server := startAuthServer(t)
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, other.URL, http.StatusFound)
+	})
+
+	req := buildCheck(server.URL)
+	req.Options.HTTPAuth = types.HTTPAuth{Scheme: types.AuthBasic, Credentials: "alice"}
+
+	c := checker.Init(req)
+	c.Perform()
+
+	a.Equal(t, "", c.Res.Error)
+	a.Equal(t, "", authorization.Load())
+}
@@ -0,0 +1,325 @@
+package checker
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptrace"
+	"strings"
+	"time"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+const (
+	maxRedirects = 5
+
+	userAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/71.0.3578.80 Safari/537.36 (compatible; WatchSumo; +https://www.watchsumo.com)"
+)
+
+var (
+	errMaxRedirects = errors.New("Redirect limited exceeded")
+)
+
+// performHTTPCheck performs a HTTP request against the service
+func (c *Checker) performHTTPCheck() bool {
+	client, configErr := c.newHTTPClient()
+	if configErr != "" {
+		return c.handleFailure("Invalid configuration", configErr.ToString())
+	}
+	defer client.CloseIdleConnections()
+
+	// Template values and secrets are rendered for each request, so they
+	// aren't stored in the request
+	template := newRequestTemplate()
+
+	body, err := template.renderBody(c.Req.Body, noVariables)
+	if err != nil {
+		return c.handleFailure(err.Error(), templateErrorCode(err, types.TemplateInvalid).ToString())
+	}
+
+	u, err := template.renderURL(c.Req.URL)
+	if err != nil {
+		return c.handleFailure(err.Error(), templateErrorCode(err, types.TemplateInvalid).ToString())
+	}
+
+	// Create request
+	req, err := http.NewRequest(c.Req.Method, u.String(), strings.NewReader(body))
+	if err != nil {
+		// Fallback to GET if a HEAD request fails
+		if c.Req.Method == "HEAD" && c.Req.Options.GetFallback {
+			return c.performGetFallback()
+		}
+
+		return c.handleError("Unable to create request", err)
+	}
+
+	setDefaultHeaders(req)
+
+	for name, values := range c.Req.Headers {
+		req.Header.Del(name)
+
+		for _, value := range values {
+			value, err := template.render(value)
+			if err != nil {
+				return c.handleFailure(err.Error(), templateErrorCode(err, types.TemplateInvalid).ToString())
+			}
+
+			req.Header.Add(name, value)
+		}
+	}
+
+	// Cookie values may be secrets, so the request is recorded before
+	// they're added
+	c.recordRequest(req, body)
+
+	// Cookies are sent by the jar if it's enabled, so they're updated after
+	// redirects
+	if !c.Req.Options.CookieJar {
+		cookies, err := c.requestCookies()
+		if err != nil {
+			return c.handleFailure(err.Error(), types.SecretNotFound.ToString())
+		}
+
+		for _, cookie := range cookies {
+			req.AddCookie(cookie)
+		}
+	}
+
+	// Perform request
+	resp, err := c.doRequest(context.Background(), client, req)
+	if err != nil {
+		// Fallback to GET if a HEAD request fails
+		if c.Req.Method == "HEAD" && c.Req.Options.GetFallback {
+			return c.performGetFallback()
+		}
+
+		return c.handleError("Error making request", err)
+	}
+
+	total := resp.End.Sub(resp.Start).Truncate(time.Millisecond)
+
+	c.Res.Timing = resp.Timing
+	c.Res.Time = &total
+	c.Res.Timestamp = &resp.End
+	c.recordResponse(resp)
+
+	// Check status code
+	if resp.StatusCode != 200 && resp.StatusCode != 203 {
+		// Fallback to GET if a HEAD request fails
+		if c.Req.Method == "HEAD" && c.Req.Options.GetFallback {
+			return c.performGetFallback()
+		}
+
+		return c.handleFailure("Unsuccessful status code", c.statusError(resp.StatusCode))
+	}
+
+	if err := c.checkProtocol(resp.Response); err != "" {
+		return c.handleFailure("Required protocol not used", err.ToString())
+	}
+
+	// Perform optional checks on the certificate
+	if resp.TLS != nil {
+		if err := c.checkCertificate(resp.TLS); err != "" {
+			return c.handleFailure("Certificate check failed", err.ToString())
+		}
+	}
+
+	c.Res.Status = types.StatusUp
+	c.Success = true
+
+	c.recordMetrics(types.StatusUp, total)
+
+	return true
+}
+
+// newHTTPClient creates a client for the check. The transport is used for all
+// requests made with the client, so if there are any Keep-Alives the
+// connection will be reused.
+func (c *Checker) newHTTPClient() (*http.Client, types.CheckError) {
+	// Create transport
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = c.dialContext
+
+	// Configured proxies are used by the dialer, so other checks use them
+	// too. Otherwise the environment is respected.
+	if c.proxy() != nil {
+		transport.Proxy = c.forwardProxy
+	}
+
+	tlsConfig, configErr := c.tlsConfig()
+	if configErr != "" {
+		return nil, configErr
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	if err := c.configureProtocol(transport); err != "" {
+		return nil, err
+	}
+
+	if err := ValidateResolver(c.resolver()); err != nil {
+		return nil, types.ResolverInvalid
+	}
+
+	var base http.RoundTripper = transport
+	if c.Req.Options.HTTPProtocol == types.HTTPProtocolHTTP3 {
+		h3, configErr := c.http3Transport(tlsConfig)
+		if configErr != "" {
+			return nil, configErr
+		}
+		base = h3
+	}
+
+	roundTripper, configErr := c.authenticatedTransport(base)
+	if configErr != "" {
+		return nil, configErr
+	}
+
+	// Create HTTP client
+	client := &http.Client{
+		Transport: roundTripper,
+
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			// Enable/disable redirects
+			if !c.Req.Options.FollowRedirects {
+				return http.ErrUseLastResponse
+			}
+
+			// Check if redirect limit has been exceeded
+			if len(via) > maxRedirects {
+				return errMaxRedirects
+			}
+
+			return nil
+		},
+	}
+
+	client.Jar, configErr = c.newCookieJar(c.Req.Options.CookieJar)
+	if configErr != "" {
+		return nil, configErr
+	}
+
+	client.Timeout = c.timeout()
+
+	return client, ""
+}
+
+// setDefaultHeaders sets the headers sent with every request, these may be
+// overridden by the check
+func setDefaultHeaders(req *http.Request) {
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html,*/*;q=0.5")
+	req.Header.Set("Accept-Charset", "utf-8,iso-8859-1;q=0.5")
+}
+
+// tracedResponse is a response with the body read, and the timings of the
+// request
+type tracedResponse struct {
+	*http.Response
+
+	Body []byte
+
+	Timing *types.RequestTiming
+
+	// Time the request started and the body was fully read
+	Start time.Time
+	End   time.Time
+}
+
+// doRequest performs the request, reads the body, and extracts timings at
+// various points
+func (c *Checker) doRequest(ctx context.Context, client *http.Client, req *http.Request) (*tracedResponse, error) {
+	var t0, t1, t2, t3, t4, t5, t6, t7, t8 time.Time
+
+	// Create trace
+	trace := &httptrace.ClientTrace{
+		DNSStart:             func(_ httptrace.DNSStartInfo) { t0 = time.Now() },
+		DNSDone:              func(_ httptrace.DNSDoneInfo) { t1 = time.Now() },
+		ConnectStart:         func(_, _ string) { t2 = time.Now() },
+		ConnectDone:          func(_, _ string, _ error) { t3 = time.Now() },
+		TLSHandshakeStart:    func() { t4 = time.Now() },
+		TLSHandshakeDone:     func(_ tls.ConnectionState, _ error) { t5 = time.Now() },
+		GotConn:              func(_ httptrace.GotConnInfo) { t6 = time.Now() },
+		WroteHeaders:         func() { t7 = time.Now() },
+		GotFirstResponseByte: func() { t8 = time.Now() },
+	}
+
+	ctx, proxyTrace := withProxyTrace(ctx)
+
+	start := time.Now()
+	req = req.WithContext(httptrace.WithClientTrace(ctx, trace))
+
+	// Perform request
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read body
+	respBody, err := ioutil.ReadAll(resp.Body)
+	defer resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	t9 := time.Now() // after body has been fully read
+	if t0.IsZero() {
+		// we skipped the DNS lookup (it's an IP, the address was overridden,
+		// or an existing connection was reused)
+		t0 = t2
+		t1 = t2
+	}
+
+	dns := t1.Sub(t0).Truncate(time.Millisecond)
+	connecting := t3.Sub(t2).Truncate(time.Millisecond)
+
+	// TLS handshake may have been skipped (existing TLS connection reused?)
+	var tls time.Duration
+	if !t4.IsZero() && !t5.IsZero() {
+		tls = t5.Sub(t4).Truncate(time.Millisecond)
+	}
+
+	sending := t7.Sub(t6).Truncate(time.Millisecond)
+	waiting := t8.Sub(t7).Truncate(time.Millisecond)
+	receiving := t9.Sub(t8).Truncate(time.Millisecond)
+
+	// The overall time starts after the DNS lookup for consistency with
+	// earlier results, unless an existing connection was reused
+	if !t1.IsZero() {
+		start = t1
+	}
+
+	return &tracedResponse{
+		Response: resp,
+		Body:     respBody,
+		Timing: &types.RequestTiming{
+			DNS:        &dns,
+			Connecting: &connecting,
+			Proxy:      proxyTrace.timing(),
+			TLS:        &tls,
+			Sending:    &sending,
+			Waiting:    &waiting,
+			Receiving:  &receiving,
+		},
+		Start: start,
+		End:   t9,
+	}, nil
+}
+
+// recordResponse records the details of the response in the result
+func (c *Checker) recordResponse(resp *tracedResponse) {
+	if resp.TLS != nil {
+		c.Res.Certificate = certInfoFromTLSConnectionState(resp.TLS)
+		c.Res.ALPN = resp.TLS.NegotiatedProtocol
+	}
+	c.Res.AltSvc = strings.Join(resp.Header.Values("Alt-Svc"), ", ")
+
+	c.Res.Proto = resp.Proto
+	c.Res.StatusText = resp.Status
+	c.Res.StatusCode = resp.StatusCode
+	c.Res.URL = resp.Request.URL // Update url if we were redirected
+	c.Res.Headers = resp.Header
+	c.Res.Body = string(resp.Body)
+}
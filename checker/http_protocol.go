@@ -0,0 +1,175 @@
+package checker
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"syscall"
+
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// configureProtocol restricts the protocols the transport uses to the
+// preference for the check
+func (c *Checker) configureProtocol(transport *http.Transport) types.CheckError {
+	protocols := new(http.Protocols)
+
+	switch c.Req.Options.HTTPProtocol {
+	case types.HTTPProtocolAny:
+		return ""
+
+	case types.HTTPProtocolHTTP1:
+		protocols.SetHTTP1(true)
+
+		// Offer HTTP/1.1 explicitly, so the ALPN result is still reported
+		if transport.TLSClientConfig != nil {
+			transport.TLSClientConfig.NextProtos = []string{"http/1.1"}
+		}
+
+	case types.HTTPProtocolHTTP2:
+		// HTTP/1.1 is still offered, so the negotiated protocol is reported
+		// when HTTP/2 isn't supported
+		protocols.SetHTTP1(true)
+		protocols.SetHTTP2(true)
+
+	case types.HTTPProtocolH2C:
+		protocols.SetHTTP2(true)
+		protocols.SetUnencryptedHTTP2(true)
+
+	case types.HTTPProtocolHTTP3:
+		// Requests are made with the HTTP/3 transport instead
+		return ""
+
+	default:
+		return types.HTTPProtocolInvalid
+	}
+
+	transport.Protocols = protocols
+	return ""
+}
+
+// checkProtocol verifies the required protocol was used
+func (c *Checker) checkProtocol(resp *http.Response) types.CheckError {
+	switch c.Req.Options.HTTPProtocol {
+	case types.HTTPProtocolHTTP2, types.HTTPProtocolH2C:
+		if resp.ProtoMajor != 2 {
+			return types.HTTPProtocolNotNegotiated
+		}
+
+	case types.HTTPProtocolHTTP3:
+		if resp.ProtoMajor != 3 {
+			return types.HTTPProtocolNotNegotiated
+		}
+	}
+
+	return ""
+}
+
+// http3Transport returns the transport for HTTP/3 checks. QUIC runs over UDP,
+// so it can't be used through a proxy or for plain HTTP.
+func (c *Checker) http3Transport(tlsConfig *tls.Config) (*http3.Transport, types.CheckError) {
+	if c.proxy() != nil || c.Req.URL.Scheme != "https" {
+		return nil, types.HTTPProtocolInvalid
+	}
+
+	return &http3.Transport{
+		TLSClientConfig: tlsConfig,
+		Dial:            c.dialQUIC,
+	}, ""
+}
+
+// dialQUIC connects to the address with QUIC. The UDP socket is dialled the
+// same way as TCP connections, so resolve overrides, the resolver, the IP
+// family and the bind address all apply.
+func (c *Checker) dialQUIC(ctx context.Context, addr string, tlsConfig *tls.Config, config *quic.Config) (*quic.Conn, error) {
+	conn, err := c.dialContext(ctx, "udp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// The QUIC handshake includes the TLS handshake
+	trace := httptrace.ContextClientTrace(ctx)
+	if trace != nil && trace.TLSHandshakeStart != nil {
+		trace.TLSHandshakeStart()
+	}
+
+	quicConn, err := quic.Dial(ctx, connectedPacketConn{conn}, conn.RemoteAddr(), tlsConfig, config)
+
+	if trace != nil && trace.TLSHandshakeDone != nil {
+		var state tls.ConnectionState
+		if quicConn != nil {
+			state = quicConn.ConnectionState().TLS
+		}
+		trace.TLSHandshakeDone(state, err)
+	}
+
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	// quic-go doesn't close sockets it didn't create
+	go func() {
+		<-quicConn.Context().Done()
+		conn.Close()
+	}()
+
+	return quicConn, nil
+}
+
+// connectedPacketConn adapts a connected UDP socket for QUIC, which reads and
+// writes with the peer's address
+type connectedPacketConn struct {
+	net.Conn
+}
+
+func (c connectedPacketConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	n, err := c.Read(b)
+	return n, c.RemoteAddr(), err
+}
+
+func (c connectedPacketConn) WriteTo(b []byte, _ net.Addr) (int, error) {
+	return c.Write(b)
+}
+
+// SetReadBuffer, SetWriteBuffer and SyscallConn let quic-go enlarge the
+// socket's buffers. The UDP socket's other methods aren't exposed, as they
+// send to an address which isn't allowed on a connected socket.
+func (c connectedPacketConn) SetReadBuffer(bytes int) error {
+	return c.Conn.(*net.UDPConn).SetReadBuffer(bytes)
+}
+
+func (c connectedPacketConn) SetWriteBuffer(bytes int) error {
+	return c.Conn.(*net.UDPConn).SetWriteBuffer(bytes)
+}
+
+func (c connectedPacketConn) SyscallConn() (syscall.RawConn, error) {
+	return c.Conn.(*net.UDPConn).SyscallConn()
+}
+
+// unwrapQUICError classifies errors from HTTP/3 connections. Failed
+// handshakes wrap the TLS error.
+func (c *Checker) unwrapQUICError(err error) (UnwrappedError, bool) {
+	var certErr *tls.CertificateVerificationError
+	if errors.As(err, &certErr) {
+		return c.unwrapTLSError(certErr)
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return UnwrappedError{Err: types.Timeout}, true
+	}
+
+	var transportErr *quic.TransportError
+	if errors.As(err, &transportErr) && transportErr.ErrorCode.IsCryptoError() {
+		return UnwrappedError{Err: types.TLSAlert}, true
+	}
+
+	return UnwrappedError{}, false
+}
@@ -0,0 +1,173 @@
+package checker_test
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/quic-go/quic-go/http3"
+	a "github.com/stretchr/testify/assert"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+func TestHTTPProtocol(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+	cert := issueCert(t, certTemplate(), nil, ca)
+	caPEM, _ := ca.PEM(t)
+
+	// Server only supporting HTTP/1.1
+	h1 := startTLSServer(t, cert.TLSCertificate(ca), nil)
+
+	// Server supporting HTTP/2 without TLS
+	h2c := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	h2c.Config.Protocols = new(http.Protocols)
+	h2c.Config.Protocols.SetHTTP1(true)
+	h2c.Config.Protocols.SetUnencryptedHTTP2(true)
+	h2c.Start()
+	t.Cleanup(h2c.Close)
+
+	tests := []struct {
+		name     string
+		url      string
+		protocol types.HTTPProtocol
+		error    string
+		proto    string
+		alpn     string
+	}{
+		{"any h1", h1.URL, types.HTTPProtocolAny, "", "HTTP/1.1", "http/1.1"},
+		{"http1", h1.URL, types.HTTPProtocolHTTP1, "", "HTTP/1.1", "http/1.1"},
+		{"h2 not supported", h1.URL, types.HTTPProtocolHTTP2, types.HTTPProtocolNotNegotiated.ToString(), "HTTP/1.1", "http/1.1"},
+		{"h2c", h2c.URL, types.HTTPProtocolH2C, "", "HTTP/2.0", ""},
+		{"h2c not used", h2c.URL, types.HTTPProtocolHTTP1, "", "HTTP/1.1", ""},
+		{"h2 over plain HTTP", h2c.URL, types.HTTPProtocolHTTP2, types.HTTPProtocolNotNegotiated.ToString(), "HTTP/1.1", ""},
+		{"invalid", h1.URL, types.HTTPProtocol("spdy"), types.HTTPProtocolInvalid.ToString(), "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := buildCheck(tt.url)
+			req.Options.HTTPProtocol = tt.protocol
+			req.Options.TrustedCAs = types.TrustedCAs{PEM: caPEM}
+
+			c := checker.Init(req)
+			c.Perform()
+
+			a.Equal(t, tt.error, c.Res.Error)
+			a.Equal(t, tt.proto, c.Res.Proto)
+			a.Equal(t, tt.alpn, c.Res.ALPN)
+		})
+	}
+}
+
+func TestHTTPProtocolHTTP2(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+	cert := issueCert(t, certTemplate(), nil, ca)
+	caPEM, _ := ca.PEM(t)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Alt-Svc", `h3=":443"; ma=86400, h3-29=":443"; ma=86400`)
+	}))
+	server.EnableHTTP2 = true
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{cert.TLSCertificate(ca)},
+		NextProtos:   []string{"h2", "http/1.1"},
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name     string
+		protocol types.HTTPProtocol
+		proto    string
+		alpn     string
+	}{
+		{"any", types.HTTPProtocolAny, "HTTP/2.0", "h2"},
+		{"http1", types.HTTPProtocolHTTP1, "HTTP/1.1", "http/1.1"},
+		{"h2", types.HTTPProtocolHTTP2, "HTTP/2.0", "h2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := buildCheck(server.URL)
+			req.Options.HTTPProtocol = tt.protocol
+			req.Options.TrustedCAs = types.TrustedCAs{PEM: caPEM}
+
+			c := checker.Init(req)
+			c.Perform()
+
+			a.Equal(t, "", c.Res.Error)
+			a.Equal(t, tt.proto, c.Res.Proto)
+			a.Equal(t, tt.alpn, c.Res.ALPN)
+			a.Equal(t, `h3=":443"; ma=86400, h3-29=":443"; ma=86400`, c.Res.AltSvc)
+		})
+	}
+}
+
+func TestHTTPProtocolHTTP3(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+	cert := issueCert(t, certTemplate(), nil, ca)
+	caPEM, _ := ca.PEM(t)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &http3.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		TLSConfig: http3.ConfigureTLSConfig(&tls.Config{
+			Certificates: []tls.Certificate{cert.TLSCertificate(ca)},
+		}),
+	}
+	go server.Serve(conn)
+	t.Cleanup(func() { server.Close() })
+
+	req := buildCheck("https://" + conn.LocalAddr().String())
+	req.Options.HTTPProtocol = types.HTTPProtocolHTTP3
+	req.Options.TrustedCAs = types.TrustedCAs{PEM: caPEM}
+
+	c := checker.Init(req)
+	c.Perform()
+	a.Equal(t, "", c.Res.Error)
+	a.Equal(t, "HTTP/3.0", c.Res.Proto)
+	a.Equal(t, "h3", c.Res.ALPN)
+	a.NotNil(t, c.Res.Certificate)
+	a.Equal(t, types.IPFamilyIPv4, c.Res.Family)
+
+	// The certificate is verified
+	req = buildCheck("https://" + conn.LocalAddr().String())
+	req.Options.HTTPProtocol = types.HTTPProtocolHTTP3
+
+	c = checker.Init(req)
+	c.Perform()
+	a.Equal(t, types.CertUntrustedAuthority.ToString(), c.Res.Error)
+
+	// The server doesn't support HTTP/3
+	h1 := startTLSServer(t, cert.TLSCertificate(ca), nil)
+	req = buildCheck(h1.URL)
+	req.Options.HTTPProtocol = types.HTTPProtocolHTTP3
+	req.Options.TrustedCAs = types.TrustedCAs{PEM: caPEM}
+
+	c = checker.Init(req)
+	c.Perform()
+	a.Equal(t, types.ConnectionRefused.ToString(), c.Res.Error)
+
+	// QUIC isn't available for plain HTTP or through a proxy
+	req = buildCheck("http://" + conn.LocalAddr().String())
+	req.Options.HTTPProtocol = types.HTTPProtocolHTTP3
+	req.Options.TrustedCAs = types.TrustedCAs{PEM: caPEM}
+
+	c = checker.Init(req)
+	c.Perform()
+	a.Equal(t, types.HTTPProtocolInvalid.ToString(), c.Res.Error)
+
+	req = buildCheck("https://" + conn.LocalAddr().String())
+	req.Options.HTTPProtocol = types.HTTPProtocolHTTP3
+	req.Options.Proxy = types.Proxy{URL: "socks5://" + closedAddress(t)}
+	c = checker.Init(req)
+	c.Perform()
+	a.Equal(t, types.HTTPProtocolInvalid.ToString(), c.Res.Error)
+}
@@ -0,0 +1,99 @@
+package checker
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// Error returned by the dialer when the host has no address in the family of
+// the network
+const noSuitableAddress = "no suitable address found"
+
+// network restricts the network to the address family of the check
+func (c *Checker) network(network string) string {
+	if network != "tcp" && network != "udp" {
+		return network
+	}
+
+	switch c.Req.Options.IPFamily {
+	case types.IPFamilyIPv4:
+		return network + "4"
+
+	case types.IPFamilyIPv6:
+		return network + "6"
+
+	default:
+		return network
+	}
+}
+
+// familyOfAddr returns the address family of a connection address
+func familyOfAddr(addr net.Addr) types.IPFamily {
+	var ip net.IP
+	switch addr := addr.(type) {
+	case *net.TCPAddr:
+		ip = addr.IP
+	case *net.UDPAddr:
+		ip = addr.IP
+	default:
+		return types.IPFamilyAny
+	}
+
+	if ip.To4() != nil {
+		return types.IPFamilyIPv4
+	}
+
+	return types.IPFamilyIPv6
+}
+
+// performBothFamilies performs the check over IPv4 and IPv6 at the same time.
+// The overall result is the IPv4 result, unless only the IPv6 check failed,
+// and both results are included side by side.
+func (c *Checker) performBothFamilies() bool {
+	var ipv4, ipv6 *Checker
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		ipv4 = c.performFamily(types.IPFamilyIPv4)
+	}()
+	go func() {
+		defer wg.Done()
+		ipv6 = c.performFamily(types.IPFamilyIPv6)
+	}()
+	wg.Wait()
+
+	overall := ipv4
+	if ipv4.Success && !ipv6.Success {
+		overall = ipv6
+	}
+
+	res := *overall.Res
+	res.IPv4 = ipv4.Res
+	res.IPv6 = ipv6.Res
+
+	c.Res = &res
+	c.Success = overall.Success
+
+	c.recordMetrics(res.Status, time.Since(c.start))
+
+	return c.Success
+}
+
+func (c *Checker) performFamily(family types.IPFamily) *Checker {
+	req := *c.Req
+	req.Options.IPFamily = family
+
+	checker := Init(&req)
+	checker.skipMetrics = true
+	checker.Perform()
+
+	// Set the family even if the check failed before connecting
+	checker.Res.Family = family
+
+	return checker
+}
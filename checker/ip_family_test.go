@@ -0,0 +1,111 @@
+package checker_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	a "github.com/stretchr/testify/assert"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// startServer starts a HTTP server listening on the address
+func startServer(t *testing.T, addr string) *httptest.Server {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		t.Skip("Unable to listen on", addr, err)
+	}
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Listener.Close()
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestIPFamily(t *testing.T) {
+	nameserver := startDNSServer(t, "udp", nil)
+
+	// Listen on IPv4, and IPv6 on the same port
+	ipv4 := startServer(t, "127.0.0.1:0")
+	port := strconv.Itoa(ipv4.Listener.Addr().(*net.TCPAddr).Port)
+
+	tests := []struct {
+		name   string
+		host   string
+		ipv6   bool
+		family types.IPFamily
+		error  string
+	}{
+		{"ipv4", "dual", true, types.IPFamilyIPv4, ""},
+		{"ipv6", "dual", true, types.IPFamilyIPv6, ""},
+		{"ipv6 down", "dual", false, types.IPFamilyIPv6, types.ConnectionRefused.ToString()},
+		{"no ipv6 address", "www", true, types.IPFamilyIPv6, types.NoSuitableAddress.ToString()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.ipv6 {
+				startServer(t, "[::1]:"+port)
+			}
+
+			req := buildCheck("http://" + tt.host + ".example.test:" + port + "/")
+			req.Options.Resolver = types.Resolver{Nameservers: []string{nameserver}}
+			req.Options.IPFamily = tt.family
+
+			c := checker.Init(req)
+			c.Perform()
+
+			a.Equal(t, tt.error, c.Res.Error)
+			if tt.error == "" {
+				a.Equal(t, tt.family, c.Res.Family)
+			}
+		})
+	}
+}
+
+func TestIPFamilyBoth(t *testing.T) {
+	nameserver := startDNSServer(t, "udp", nil)
+
+	ipv4 := startServer(t, "127.0.0.1:0")
+	port := strconv.Itoa(ipv4.Listener.Addr().(*net.TCPAddr).Port)
+
+	check := func() *checker.Checker {
+		req := buildCheck("http://dual.example.test:" + port + "/")
+		req.Options.Resolver = types.Resolver{Nameservers: []string{nameserver}}
+		req.Options.IPFamily = types.IPFamilyBoth
+
+		c := checker.Init(req)
+		c.Perform()
+
+		return c
+	}
+
+	// IPv6 is down, so the overall result is the IPv6 failure
+	c := check()
+	a.False(t, c.Success)
+	a.Equal(t, types.ConnectionRefused.ToString(), c.Res.Error)
+	a.Equal(t, types.IPFamilyIPv6, c.Res.Family)
+	a.True(t, c.Res.IPv4.Success())
+	a.Equal(t, types.IPFamilyIPv4, c.Res.IPv4.Family)
+	a.False(t, c.Res.IPv6.Success())
+	a.Equal(t, types.ConnectionRefused.ToString(), c.Res.IPv6.Error)
+
+	// Both up
+	startServer(t, "[::1]:"+port)
+
+	c = check()
+	a.True(t, c.Success)
+	a.Equal(t, types.IPFamilyIPv4, c.Res.Family)
+	a.True(t, c.Res.IPv4.Success())
+	a.True(t, c.Res.IPv6.Success())
+	a.Equal(t, types.IPFamilyIPv6, c.Res.IPv6.Family)
+}
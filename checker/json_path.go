@@ -0,0 +1,71 @@
+package checker
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	jsonPathSegmentPattern = regexp.MustCompile(`^([^\[\]]*)((?:\[\d+\])*)$`)
+	jsonPathIndexPattern   = regexp.MustCompile(`\[(\d+)\]`)
+)
+
+// jsonPath returns the value at a simple path such as data.items[0].id, with
+// an optional $. prefix. Strings are returned as is, and other values are
+// returned as JSON.
+func jsonPath(body []byte, path string) (string, bool) {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return "", false
+	}
+
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if path != "" {
+		for _, segment := range strings.Split(path, ".") {
+			match := jsonPathSegmentPattern.FindStringSubmatch(segment)
+			if match == nil {
+				return "", false
+			}
+
+			if match[1] != "" {
+				object, ok := value.(map[string]interface{})
+				if !ok {
+					return "", false
+				}
+
+				if value, ok = object[match[1]]; !ok {
+					return "", false
+				}
+			}
+
+			for _, index := range jsonPathIndexPattern.FindAllStringSubmatch(match[2], -1) {
+				array, ok := value.([]interface{})
+				if !ok {
+					return "", false
+				}
+
+				i, _ := strconv.Atoi(index[1])
+				if i >= len(array) {
+					return "", false
+				}
+
+				value = array[i]
+			}
+		}
+	}
+
+	switch value := value.(type) {
+	case string:
+		return value, true
+
+	default:
+		res, err := json.Marshal(value)
+		if err != nil {
+			return "", false
+		}
+
+		return string(res), true
+	}
+}
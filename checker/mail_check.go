@@ -0,0 +1,405 @@
+package checker
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+const (
+	defaultEHLOHostname = "localhost"
+)
+
+// mailProtocol is a mail protocol, the implementations send the commands
+// and parse the responses
+type mailProtocol interface {
+	// Read the greeting from the server
+	greeting(conn *textproto.Conn) (string, error)
+
+	// Request the capabilities of the server
+	capabilities(conn *textproto.Conn) ([]string, error)
+
+	// Request to upgrade the connection to TLS
+	startTLS(conn *textproto.Conn) error
+
+	// Close the session
+	quit(conn *textproto.Conn)
+}
+
+// mailError is an unexpected response from the server
+type mailError struct {
+	code    types.CheckError
+	message string
+}
+
+func (e *mailError) Error() string {
+	return e.message
+}
+
+func newSMTPProtocol(c *Checker) mailProtocol {
+	return &smtpProtocol{hostname: c.Req.Options.MailCheck.Hostname}
+}
+
+func newIMAPProtocol(_ *Checker) mailProtocol {
+	return &imapProtocol{}
+}
+
+func newPOP3Protocol(_ *Checker) mailProtocol {
+	return &pop3Protocol{}
+}
+
+// mailCheckType returns the check type for the mail protocol, connecting to
+// the default port if the URL doesn't have one
+func mailCheckType(protocol func(c *Checker) mailProtocol, defaultPort string, implicitTLS bool) CheckType {
+	return CheckTypeFunc(func(c *Checker) bool {
+		return c.performMailCheck(protocol(c), defaultPort, implicitTLS)
+	})
+}
+
+// performMailCheck connects to the mail server, reads the greeting and
+// capabilities, and optionally upgrades the connection to TLS
+func (c *Checker) performMailCheck(protocol mailProtocol, defaultPort string, implicitTLS bool) bool {
+	opts := c.Req.Options.MailCheck
+
+	if err := ValidateResolver(c.resolver()); err != nil {
+		return c.handleFailure("Invalid resolver configuration", types.ResolverInvalid.ToString())
+	}
+
+	config, configErr := c.tlsConfig()
+	if configErr != "" {
+		return c.handleFailure("Invalid TLS configuration", configErr.ToString())
+	}
+	config.ServerName = c.Req.URL.Hostname()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout())
+	defer cancel()
+
+	// Instrument the connection, and extract timings at various points
+	ctx, trace := withConnTrace(ctx)
+
+	conn, err := c.dialContext(ctx, "tcp", hostPort(c.Req.URL, defaultPort))
+	if err != nil {
+		return c.handleError("Error connecting", err)
+	}
+	defer conn.Close()
+
+	deadline, _ := ctx.Deadline()
+	conn.SetDeadline(deadline)
+
+	var state *tls.ConnectionState
+	handshake := func() error {
+		tlsConn, err := trace.handshake(conn, config)
+		if err != nil {
+			return err
+		}
+
+		s := tlsConn.ConnectionState()
+		state = &s
+		conn = tlsConn
+
+		c.Res.Certificate = certInfoFromTLSConnectionState(state)
+		c.Res.Proto = tls.VersionName(state.Version)
+
+		return nil
+	}
+
+	if implicitTLS {
+		if err := handshake(); err != nil {
+			return c.handleError("Error performing TLS handshake", err)
+		}
+	}
+
+	report := &types.MailReport{}
+	c.Res.Mail = report
+
+	// Greeting
+	greetingStart := time.Now()
+	text := textproto.NewConn(conn)
+	if report.Greeting, err = protocol.greeting(text); err != nil {
+		return c.handleMailError("Error reading greeting", err)
+	}
+	greetingDone := time.Now()
+
+	// Capabilities
+	if report.Capabilities, err = protocol.capabilities(text); err != nil {
+		return c.handleMailError("Error reading capabilities", err)
+	}
+
+	// Upgrade to TLS
+	if opts.StartTLS && !implicitTLS {
+		if !hasCapability(report.Capabilities, "STARTTLS") && !hasCapability(report.Capabilities, "STLS") {
+			return c.handleFailure("STARTTLS not supported", types.MailStartTLSUnsupported.ToString())
+		}
+
+		if err := protocol.startTLS(text); err != nil {
+			return c.handleMailError("Error starting TLS", err)
+		}
+
+		if err := handshake(); err != nil {
+			return c.handleError("Error performing TLS handshake", err)
+		}
+		report.StartTLS = true
+
+		// Capabilities may change after upgrading
+		text = textproto.NewConn(conn)
+		if report.Capabilities, err = protocol.capabilities(text); err != nil {
+			return c.handleMailError("Error reading capabilities", err)
+		}
+	}
+	commandsDone := time.Now()
+
+	protocol.quit(text)
+
+	// Waiting is the time until the greeting, and receiving the time for the
+	// capability and STARTTLS commands, excluding the TLS handshake
+	waiting := greetingDone.Sub(greetingStart).Truncate(time.Millisecond)
+	receiving := commandsDone.Sub(greetingDone)
+	if report.StartTLS {
+		receiving -= trace.tlsDuration()
+	}
+	receiving = receiving.Truncate(time.Millisecond)
+
+	c.Res.Timing = trace.timing()
+	c.Res.Timing.Waiting = &waiting
+	c.Res.Timing.Receiving = &receiving
+
+	for _, capability := range opts.RequiredCapabilities {
+		if !hasCapability(report.Capabilities, capability) {
+			return c.handleFailure("Missing capability", types.MailMissingCapability.ToString())
+		}
+	}
+
+	// Perform optional checks on the certificate
+	if state != nil {
+		if err := c.checkCertificate(state); err != "" {
+			return c.handleFailure("Certificate check failed", err.ToString())
+		}
+	}
+
+	return c.handleSuccessSince(trace.start())
+}
+
+// handleMailError records unexpected responses with their own error code,
+// and classifies other errors as normal
+func (c *Checker) handleMailError(message string, err error) bool {
+	var mailErr *mailError
+	if errors.As(err, &mailErr) {
+		return c.handleFailure(message, mailErr.code.ToString())
+	}
+
+	var protoErr textproto.ProtocolError
+	if errors.As(err, &protoErr) {
+		return c.handleFailure(message, types.MailProtocolError.ToString())
+	}
+
+	return c.handleError(message, err)
+}
+
+// hasCapability checks if the capability is advertised, ignoring case and
+// any parameters, e.g. SIZE matches "SIZE 35882577"
+func hasCapability(capabilities []string, capability string) bool {
+	for _, c := range capabilities {
+		name := strings.Fields(c)
+		if len(name) > 0 && strings.EqualFold(name[0], capability) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// smtpProtocol implements SMTP, RFC 5321
+type smtpProtocol struct {
+	hostname string
+}
+
+func (p *smtpProtocol) greeting(conn *textproto.Conn) (string, error) {
+	_, message, err := conn.ReadResponse(220)
+	if err != nil {
+		return message, smtpError(err, types.MailBadGreeting)
+	}
+
+	return message, nil
+}
+
+func (p *smtpProtocol) capabilities(conn *textproto.Conn) ([]string, error) {
+	if err := conn.PrintfLine("EHLO %s", p.ehloHostname()); err != nil {
+		return nil, err
+	}
+
+	_, message, err := conn.ReadResponse(250)
+	if err != nil {
+		return nil, smtpError(err, types.MailCommandFailed)
+	}
+
+	// The first line is the server's hostname, followed by the extensions
+	lines := strings.Split(message, "\n")
+	return lines[1:], nil
+}
+
+func (p *smtpProtocol) startTLS(conn *textproto.Conn) error {
+	if err := conn.PrintfLine("STARTTLS"); err != nil {
+		return err
+	}
+
+	_, _, err := conn.ReadResponse(220)
+	return smtpError(err, types.MailCommandFailed)
+}
+
+func (p *smtpProtocol) quit(conn *textproto.Conn) {
+	if conn.PrintfLine("QUIT") == nil {
+		conn.ReadResponse(221)
+	}
+}
+
+func (p *smtpProtocol) ehloHostname() string {
+	if p.hostname != "" {
+		return p.hostname
+	}
+
+	return defaultEHLOHostname
+}
+
+// smtpError converts an error response to a mailError
+func smtpError(err error, code types.CheckError) error {
+	var tpErr *textproto.Error
+	if errors.As(err, &tpErr) {
+		return &mailError{code: code, message: tpErr.Error()}
+	}
+
+	return err
+}
+
+// imapProtocol implements IMAP, RFC 9051
+type imapProtocol struct {
+	tag int
+}
+
+func (p *imapProtocol) greeting(conn *textproto.Conn) (string, error) {
+	line, err := conn.ReadLine()
+	if err != nil {
+		return "", err
+	}
+
+	if !strings.HasPrefix(line, "* OK") && !strings.HasPrefix(line, "* PREAUTH") {
+		return line, &mailError{code: types.MailBadGreeting, message: line}
+	}
+
+	return line, nil
+}
+
+func (p *imapProtocol) capabilities(conn *textproto.Conn) ([]string, error) {
+	lines, err := p.command(conn, "CAPABILITY")
+	if err != nil {
+		return nil, err
+	}
+
+	var capabilities []string
+	for _, line := range lines {
+		if strings.HasPrefix(strings.ToUpper(line), "* CAPABILITY ") {
+			capabilities = append(capabilities, strings.Fields(line)[2:]...)
+		}
+	}
+
+	return capabilities, nil
+}
+
+func (p *imapProtocol) startTLS(conn *textproto.Conn) error {
+	_, err := p.command(conn, "STARTTLS")
+	return err
+}
+
+func (p *imapProtocol) quit(conn *textproto.Conn) {
+	p.command(conn, "LOGOUT")
+}
+
+// command sends the command and reads the untagged responses until the
+// tagged completion
+func (p *imapProtocol) command(conn *textproto.Conn, command string) ([]string, error) {
+	p.tag++
+	tag := fmt.Sprintf("a%d", p.tag)
+
+	if err := conn.PrintfLine("%s %s", tag, command); err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return nil, err
+		}
+
+		if !strings.HasPrefix(line, tag+" ") {
+			lines = append(lines, line)
+			continue
+		}
+
+		if !strings.HasPrefix(line, tag+" OK") {
+			return nil, &mailError{code: types.MailCommandFailed, message: line}
+		}
+
+		return lines, nil
+	}
+}
+
+// pop3Protocol implements POP3, RFC 1939 and the CAPA extension, RFC 2449
+type pop3Protocol struct{}
+
+func (p *pop3Protocol) greeting(conn *textproto.Conn) (string, error) {
+	line, err := conn.ReadLine()
+	if err != nil {
+		return "", err
+	}
+
+	if !strings.HasPrefix(line, "+OK") {
+		return line, &mailError{code: types.MailBadGreeting, message: line}
+	}
+
+	return line, nil
+}
+
+func (p *pop3Protocol) capabilities(conn *textproto.Conn) ([]string, error) {
+	line, err := p.command(conn, "CAPA")
+	if err != nil {
+		return nil, err
+	}
+
+	// CAPA isn't supported by all servers
+	if !strings.HasPrefix(line, "+OK") {
+		return nil, nil
+	}
+
+	return conn.ReadDotLines()
+}
+
+func (p *pop3Protocol) startTLS(conn *textproto.Conn) error {
+	line, err := p.command(conn, "STLS")
+	if err != nil {
+		return err
+	}
+
+	if !strings.HasPrefix(line, "+OK") {
+		return &mailError{code: types.MailCommandFailed, message: line}
+	}
+
+	return nil
+}
+
+func (p *pop3Protocol) quit(conn *textproto.Conn) {
+	p.command(conn, "QUIT")
+}
+
+func (p *pop3Protocol) command(conn *textproto.Conn, command string) (string, error) {
+	if err := conn.PrintfLine("%s", command); err != nil {
+		return "", err
+	}
+
+	return conn.ReadLine()
+}
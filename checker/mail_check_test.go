@@ -0,0 +1,227 @@
+package checker_test
+
+import (
+	"crypto/tls"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	a "github.com/stretchr/testify/assert"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// fakeMailServer is a minimal SMTP, IMAP or POP3 server, which replies to
+// each command from a table, and upgrades the connection on STARTTLS
+type fakeMailServer struct {
+	greeting string
+
+	// Replies to each command, before and after upgrading to TLS
+	replies    map[string]string
+	tlsReplies map[string]string
+
+	// Command which upgrades the connection, and its reply
+	startTLS      string
+	startTLSReply string
+
+	config *tls.Config
+}
+
+func (s *fakeMailServer) handle(conn net.Conn) {
+	text := textproto.NewConn(conn)
+	text.PrintfLine("%s", s.greeting)
+
+	replies := s.replies
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		// IMAP commands are prefixed with a tag
+		command, tag := line, ""
+		if strings.HasPrefix(line, "a") {
+			parts := strings.SplitN(line, " ", 2)
+			tag, command = parts[0], parts[1]
+		}
+
+		if s.startTLS != "" && command == s.startTLS && s.config != nil {
+			text.PrintfLine("%s", strings.Replace(s.startTLSReply, "TAG", tag, -1))
+
+			tlsConn := tls.Server(conn, s.config)
+			if tlsConn.Handshake() != nil {
+				return
+			}
+			conn = tlsConn
+			text = textproto.NewConn(conn)
+			replies = s.tlsReplies
+			continue
+		}
+
+		if strings.HasPrefix(command, "EHLO ") {
+			command = "EHLO"
+		}
+
+		reply, ok := replies[command]
+		if !ok {
+			reply = "500 unknown command"
+		}
+		text.PrintfLine("%s", strings.Replace(reply, "TAG", tag, -1))
+
+		if command == "QUIT" || command == "LOGOUT" {
+			return
+		}
+	}
+}
+
+func smtpServer(config *tls.Config) *fakeMailServer {
+	return &fakeMailServer{
+		greeting: "220 mail.example.com ESMTP",
+		replies: map[string]string{
+			"EHLO": "250-mail.example.com\r\n250-SIZE 35882577\r\n250 STARTTLS",
+			"QUIT": "221 Bye",
+		},
+		tlsReplies: map[string]string{
+			"EHLO": "250-mail.example.com\r\n250-SIZE 35882577\r\n250 AUTH PLAIN",
+			"QUIT": "221 Bye",
+		},
+		startTLS:      "STARTTLS",
+		startTLSReply: "220 Ready to start TLS",
+		config:        config,
+	}
+}
+
+func imapServer(config *tls.Config) *fakeMailServer {
+	return &fakeMailServer{
+		greeting: "* OK IMAP4rev1 ready",
+		replies: map[string]string{
+			"CAPABILITY": "* CAPABILITY IMAP4rev1 STARTTLS LOGINDISABLED\r\nTAG OK CAPABILITY completed",
+			"LOGOUT":     "* BYE\r\nTAG OK LOGOUT completed",
+		},
+		tlsReplies: map[string]string{
+			"CAPABILITY": "* CAPABILITY IMAP4rev1 AUTH=PLAIN IDLE\r\nTAG OK CAPABILITY completed",
+			"LOGOUT":     "* BYE\r\nTAG OK LOGOUT completed",
+		},
+		startTLS:      "STARTTLS",
+		startTLSReply: "TAG OK Begin TLS negotiation now",
+		config:        config,
+	}
+}
+
+func pop3Server(config *tls.Config) *fakeMailServer {
+	return &fakeMailServer{
+		greeting: "+OK POP3 server ready",
+		replies: map[string]string{
+			"CAPA": "+OK Capability list follows\r\nUSER\r\nSTLS\r\n.",
+			"QUIT": "+OK Bye",
+		},
+		tlsReplies: map[string]string{
+			"CAPA": "+OK Capability list follows\r\nUSER\r\nSASL PLAIN\r\n.",
+			"QUIT": "+OK Bye",
+		},
+		startTLS:      "STLS",
+		startTLSReply: "+OK Begin TLS negotiation",
+		config:        config,
+	}
+}
+
+func TestMailCheck(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+	cert := issueCert(t, certTemplate(), nil, ca)
+	caPEM, _ := ca.PEM(t)
+	config := &tls.Config{Certificates: []tls.Certificate{cert.TLSCertificate(ca)}}
+
+	tests := []struct {
+		name         string
+		scheme       string
+		server       *fakeMailServer
+		check        types.MailCheck
+		error        string
+		capabilities []string
+	}{
+		{"smtp", "smtp", smtpServer(config), types.MailCheck{}, "", []string{"SIZE 35882577", "STARTTLS"}},
+		{"smtp starttls", "smtp", smtpServer(config), types.MailCheck{StartTLS: true}, "", []string{"SIZE 35882577", "AUTH PLAIN"}},
+		{"smtp capability", "smtp", smtpServer(config), types.MailCheck{RequiredCapabilities: []string{"size"}}, "", []string{"SIZE 35882577", "STARTTLS"}},
+		{"smtp missing capability", "smtp", smtpServer(config), types.MailCheck{RequiredCapabilities: []string{"PIPELINING"}}, types.MailMissingCapability.ToString(), []string{"SIZE 35882577", "STARTTLS"}},
+		{"smtp starttls unsupported", "smtp", &fakeMailServer{greeting: "220 ESMTP", replies: map[string]string{"EHLO": "250 mail.example.com"}}, types.MailCheck{StartTLS: true}, types.MailStartTLSUnsupported.ToString(), []string{}},
+		{"smtp bad greeting", "smtp", &fakeMailServer{greeting: "554 No SMTP service here"}, types.MailCheck{}, types.MailBadGreeting.ToString(), nil},
+		{"imap", "imap", imapServer(config), types.MailCheck{}, "", []string{"IMAP4rev1", "STARTTLS", "LOGINDISABLED"}},
+		{"imap starttls", "imap", imapServer(config), types.MailCheck{StartTLS: true, RequiredCapabilities: []string{"IDLE"}}, "", []string{"IMAP4rev1", "AUTH=PLAIN", "IDLE"}},
+		{"imap bad greeting", "imap", &fakeMailServer{greeting: "* BYE Too many connections"}, types.MailCheck{}, types.MailBadGreeting.ToString(), nil},
+		{"pop3", "pop3", pop3Server(config), types.MailCheck{}, "", []string{"USER", "STLS"}},
+		{"pop3 starttls", "pop3", pop3Server(config), types.MailCheck{StartTLS: true}, "", []string{"USER", "SASL PLAIN"}},
+		{"pop3 bad greeting", "pop3", &fakeMailServer{greeting: "-ERR Service unavailable"}, types.MailCheck{}, types.MailBadGreeting.ToString(), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := startTCPServer(t, nil, tt.server.handle)
+
+			req := buildCheck(tt.scheme + "://" + addr)
+			req.Options.MailCheck = tt.check
+			req.Options.TrustedCAs = types.TrustedCAs{PEM: caPEM}
+
+			c := checker.Init(req)
+			c.Perform()
+
+			a.Equal(t, tt.error, c.Res.Error)
+			a.Equal(t, tt.error == "", c.Success)
+
+			if tt.capabilities != nil {
+				a.Equal(t, tt.capabilities, c.Res.Mail.Capabilities)
+			}
+
+			if tt.error == "" {
+				a.Equal(t, tt.check.StartTLS, c.Res.Mail.StartTLS)
+				a.NotEmpty(t, c.Res.Mail.Greeting)
+				a.NotNil(t, c.Res.Timing.Waiting)
+
+				if tt.check.StartTLS {
+					a.Equal(t, "TLS 1.3", c.Res.Proto)
+					a.Equal(t, cert.Cert.SerialNumber.Bytes(), c.Res.Certificate.Serial)
+				}
+			}
+		})
+	}
+}
+
+func TestMailCheckImplicitTLS(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+	cert := issueCert(t, certTemplate(), nil, ca)
+	caPEM, _ := ca.PEM(t)
+	config := &tls.Config{Certificates: []tls.Certificate{cert.TLSCertificate(ca)}}
+
+	for _, scheme := range []string{"smtps", "imaps", "pop3s"} {
+		t.Run(scheme, func(t *testing.T) {
+			var server *fakeMailServer
+			switch scheme {
+			case "smtps":
+				server = smtpServer(nil)
+			case "imaps":
+				server = imapServer(nil)
+			case "pop3s":
+				server = pop3Server(nil)
+			}
+
+			addr := startTCPServer(t, config, server.handle)
+
+			req := buildCheck(scheme + "://" + addr)
+			req.Options.TrustedCAs = types.TrustedCAs{PEM: caPEM}
+
+			c := checker.Init(req)
+			c.Perform()
+			a.Equal(t, "", c.Res.Error)
+			a.Equal(t, "TLS 1.3", c.Res.Proto)
+			a.NotNil(t, c.Res.Certificate)
+			a.NotEmpty(t, c.Res.Mail.Capabilities)
+
+			req = buildCheck(scheme + "://" + addr)
+
+			c = checker.Init(req)
+			c.Perform()
+			a.Equal(t, types.CertUntrustedAuthority.ToString(), c.Res.Error)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package checker
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"sync"
+	"time"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+const (
+	// Fingerprints not seen for this long are forgotten
+	fingerprintTTL = 7 * 24 * time.Hour
+
+	// Maximum number of fingerprints remembered
+	maxFingerprints = 10000
+)
+
+// seenFingerprint is the fingerprint of the last certificate seen for a
+// target
+type seenFingerprint struct {
+	fingerprint []byte
+	seen        time.Time
+}
+
+var (
+	// Fingerprint of the last certificate seen for each Ref and target
+	lastFingerprints   = make(map[string]seenFingerprint)
+	lastFingerprintsMu sync.Mutex
+)
+
+// checkCertPins verifies the certificate chain matches at least one of the
+// pins in the check options. Pins are only checked if any are set.
+func (c *Checker) checkCertPins(state *tls.ConnectionState) types.CheckError {
+	pins := c.Req.Options.CertPins
+	if len(pins.LeafSHA256) == 0 && len(pins.SPKISHA256) == 0 {
+		return ""
+	}
+
+	if containsHash(pins.LeafSHA256, extractFingerprintSHA256(state.PeerCertificates[0])) {
+		return ""
+	}
+
+	for _, cert := range pinnableChain(state) {
+		if containsHash(pins.SPKISHA256, extractSPKISHA256(cert)) {
+			return ""
+		}
+	}
+
+	return types.CertPinMismatch
+}
+
+// detectCertChange compares the leaf certificate with the one from the
+// previous check, and adds a warning if it has changed. The previous
+// certificate is only remembered for monitors, ad hoc checks must supply it.
+func (c *Checker) detectCertChange(cert *x509.Certificate) {
+	if !c.Req.Options.CertPins.NotifyChange {
+		return
+	}
+
+	fingerprint := extractFingerprintSHA256(cert)
+
+	previous := c.Req.Options.CertPins.PreviousSHA256
+	if c.Req.Ref != "" && c.Req.Ref != types.AdHocRef {
+		key := c.Req.Ref + " " + c.Req.URL.Scheme + "://" + c.Req.URL.Host
+		if last := swapFingerprint(key, fingerprint); len(previous) == 0 {
+			previous = last
+		}
+	}
+
+	if len(previous) > 0 && !bytes.Equal(previous, fingerprint) {
+		c.Res.Certificate.PreviousFingerprintSHA256 = previous
+		c.addWarning(types.CertChanged)
+	}
+}
+
+// swapFingerprint remembers the fingerprint for the key, and returns the
+// previous one
+func swapFingerprint(key string, fingerprint []byte) []byte {
+	lastFingerprintsMu.Lock()
+	defer lastFingerprintsMu.Unlock()
+
+	now := time.Now()
+
+	var previous []byte
+	if last, ok := lastFingerprints[key]; ok && now.Sub(last.seen) < fingerprintTTL {
+		previous = last.fingerprint
+	}
+
+	lastFingerprints[key] = seenFingerprint{fingerprint: fingerprint, seen: now}
+	if len(lastFingerprints) > maxFingerprints {
+		evictFingerprints(now)
+	}
+
+	return previous
+}
+
+// evictFingerprints forgets expired fingerprints, and the oldest one if there
+// are still too many
+func evictFingerprints(now time.Time) {
+	var oldestKey string
+	var oldest time.Time
+
+	for key, last := range lastFingerprints {
+		if now.Sub(last.seen) >= fingerprintTTL {
+			delete(lastFingerprints, key)
+			continue
+		}
+
+		if oldestKey == "" || last.seen.Before(oldest) {
+			oldestKey = key
+			oldest = last.seen
+		}
+	}
+
+	if len(lastFingerprints) > maxFingerprints {
+		delete(lastFingerprints, oldestKey)
+	}
+}
+
+// pinnableChain returns the certificates which SPKI pins can match. The
+// server can send any certificates it likes, so pins only match the issuers
+// if the chain was verified. Otherwise only the leaf can match, as the server
+// proved it has its key.
+func pinnableChain(state *tls.ConnectionState) []*x509.Certificate {
+	if len(state.VerifiedChains) > 0 {
+		return state.VerifiedChains[0]
+	}
+
+	return state.PeerCertificates[:1]
+}
+
+func containsHash(hashes [][]byte, hash []byte) bool {
+	for _, h := range hashes {
+		if bytes.Equal(h, hash) {
+			return true
+		}
+	}
+
+	return false
+}
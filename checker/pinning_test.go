@@ -0,0 +1,135 @@
+package checker_test
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	a "github.com/stretchr/testify/assert"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+func sha256Sum(b []byte) []byte {
+	sum := sha256.Sum256(b)
+	return sum[:]
+}
+
+func TestCertPins(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+	cert := issueCert(t, certTemplate(), nil, ca)
+	other := issueCert(t, certTemplate(), nil, ca)
+
+	caPEM, _ := ca.PEM(t)
+
+	server := startTLSServer(t, cert.TLSCertificate(ca), nil)
+
+	tests := []struct {
+		name     string
+		pins     types.CertPins
+		verified bool
+		success  bool
+		error    string
+	}{
+		{"no pins", types.CertPins{}, false, true, ""},
+		{"leaf", types.CertPins{LeafSHA256: [][]byte{sha256Sum(cert.Cert.Raw)}}, false, true, ""},
+		{"leaf mismatch", types.CertPins{LeafSHA256: [][]byte{sha256Sum(other.Cert.Raw)}}, false, false, types.CertPinMismatch.ToString()},
+		{"spki leaf", types.CertPins{SPKISHA256: [][]byte{sha256Sum(cert.Cert.RawSubjectPublicKeyInfo)}}, false, true, ""},
+		{"spki issuer", types.CertPins{SPKISHA256: [][]byte{sha256Sum(ca.Cert.RawSubjectPublicKeyInfo)}}, true, true, ""},
+		// The issuer was only sent by the server, so it can't be trusted
+		{"spki issuer unverified", types.CertPins{SPKISHA256: [][]byte{sha256Sum(ca.Cert.RawSubjectPublicKeyInfo)}}, false, false, types.CertPinMismatch.ToString()},
+		{"spki mismatch", types.CertPins{SPKISHA256: [][]byte{sha256Sum(other.Cert.RawSubjectPublicKeyInfo)}}, true, false, types.CertPinMismatch.ToString()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := buildCheck(server.URL)
+			req.Options.CertPins = tt.pins
+			if tt.verified {
+				req.Options.TrustedCAs = types.TrustedCAs{PEM: caPEM}
+			} else {
+				req.Options.IgnoreTLSErrors = true
+			}
+
+			c := checker.Init(req)
+			c.Perform()
+
+			a.Equal(t, tt.success, c.Success)
+			a.Equal(t, tt.error, c.Res.Error)
+		})
+	}
+}
+
+func TestCertChange(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+	first := issueCert(t, certTemplate(), nil, ca)
+	second := issueCert(t, certTemplate(), nil, ca)
+
+	// Server presenting whichever certificate is current
+	current := first
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{
+		GetConfigForClient: func(_ *tls.ClientHelloInfo) (*tls.Config, error) {
+			return &tls.Config{Certificates: []tls.Certificate{current.TLSCertificate(ca)}}, nil
+		},
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	// Another server with the second certificate
+	other := startTLSServer(t, second.TLSCertificate(ca), nil)
+
+	perform := func(rawurl, ref string, previous []byte) *checker.Checker {
+		req := buildCheck(rawurl)
+		req.Ref = ref
+		req.Options.IgnoreTLSErrors = true
+		req.Options.CertPins = types.CertPins{NotifyChange: true, PreviousSHA256: previous}
+
+		c := checker.Init(req)
+		c.Perform()
+
+		return c
+	}
+
+	// First check has nothing to compare against
+	c := perform(server.URL, t.Name(), nil)
+	a.Equal(t, true, c.Success)
+	a.Empty(t, c.Res.Warnings)
+
+	// Same certificate
+	c = perform(server.URL, t.Name(), nil)
+	a.Empty(t, c.Res.Warnings)
+
+	// Another target with the same Ref is tracked separately
+	c = perform(other.URL, t.Name(), nil)
+	a.Empty(t, c.Res.Warnings)
+
+	// Certificate swapped
+	current = second
+	c = perform(server.URL, t.Name(), nil)
+	a.Equal(t, true, c.Success)
+	a.Equal(t, []string{types.CertChanged.ToString()}, c.Res.Warnings)
+	a.Equal(t, sha256Sum(first.Cert.Raw), c.Res.Certificate.PreviousFingerprintSHA256)
+	a.Equal(t, sha256Sum(second.Cert.Raw), c.Res.Certificate.FingerprintSHA256)
+
+	// Previous fingerprint supplied with the request
+	c = perform(server.URL, t.Name(), sha256Sum(second.Cert.Raw))
+	a.Empty(t, c.Res.Warnings)
+
+	c = perform(server.URL, t.Name(), sha256Sum(first.Cert.Raw))
+	a.Equal(t, []string{types.CertChanged.ToString()}, c.Res.Warnings)
+
+	// Ad hoc checks aren't tracked
+	for _, ref := range []string{types.AdHocRef, ""} {
+		current = first
+		c = perform(server.URL, ref, nil)
+		a.Empty(t, c.Res.Warnings)
+
+		current = second
+		c = perform(server.URL, ref, nil)
+		a.Empty(t, c.Res.Warnings)
+	}
+}
@@ -0,0 +1,547 @@
+package checker
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// SOCKS5 protocol values, see RFC 1928 and RFC 1929
+const (
+	socksVersion = 0x05
+
+	socksNoAuth       = 0x00
+	socksPasswordAuth = 0x02
+	socksNoAcceptable = 0xff
+
+	socksConnect = 0x01
+
+	socksIPv4   = 0x01
+	socksDomain = 0x03
+	socksIPv6   = 0x04
+
+	socksSucceeded          = 0x00
+	socksNetworkUnreachable = 0x03
+	socksHostUnreachable    = 0x04
+	socksConnectionRefused  = 0x05
+	socksTTLExpired         = 0x06
+)
+
+// proxyError is an error connecting through the proxy, this is classified
+// separately so it isn't blamed on the target
+type proxyError struct {
+	code types.CheckError
+	err  error
+}
+
+func (e *proxyError) Error() string {
+	return fmt.Sprintf("Proxy: %v", e.err)
+}
+
+func (e *proxyError) Unwrap() error {
+	return e.err
+}
+
+func newProxyError(code types.CheckError, message string) *proxyError {
+	return &proxyError{code: code, err: errors.New(message)}
+}
+
+// proxyTrace records the time spent connecting through the proxy, after the
+// connection to the proxy is established
+type proxyTrace struct {
+	duration time.Duration
+	used     bool
+}
+
+type proxyTraceKey struct{}
+
+// withProxyTrace returns a context which records the proxy handshake when
+// connecting with it
+func withProxyTrace(ctx context.Context) (context.Context, *proxyTrace) {
+	trace := &proxyTrace{}
+	return context.WithValue(ctx, proxyTraceKey{}, trace), trace
+}
+
+// timing returns the duration of the proxy handshake, or nil if the proxy
+// wasn't used
+func (t *proxyTrace) timing() *time.Duration {
+	if !t.used {
+		return nil
+	}
+
+	duration := t.duration.Truncate(time.Millisecond)
+	return &duration
+}
+
+// proxy returns the proxy for the check, or nil if connections are direct.
+// The check's proxy is used instead of the agent's.
+func (c *Checker) proxy() *types.Proxy {
+	if c.Req.Options.Proxy.URL != "" {
+		return &c.Req.Options.Proxy
+	}
+
+	if agentConfig.Proxy.URL != "" {
+		return &agentConfig.Proxy
+	}
+
+	return nil
+}
+
+// proxyURL parses the proxy URL, and adds the credentials from the secret
+// store if they are referenced
+func (c *Checker) proxyURL(proxy *types.Proxy) (*url.URL, types.CheckError) {
+	u, err := url.Parse(proxy.URL)
+	if err != nil || u.Hostname() == "" {
+		return nil, types.ProxyInvalid
+	}
+
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, types.ProxyInvalid
+	}
+
+	if proxy.Credentials != "" {
+		if _, ok := lookupSecret(proxy.Credentials); !ok {
+			return nil, types.SecretNotFound
+		}
+
+		creds, err := c.lookupCredentials(proxy.Credentials)
+		if err != "" {
+			return nil, types.ProxyInvalid
+		}
+
+		u.User = url.UserPassword(creds.username, creds.password)
+	}
+
+	return u, ""
+}
+
+// proxyAddress returns the address of the proxy, with the default port for
+// the scheme if there isn't one
+func proxyAddress(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		port = map[string]string{"http": "80", "https": "443"}[u.Scheme]
+		if port == "" {
+			port = "1080"
+		}
+	}
+
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// forwardProxy returns the proxy for the HTTP transport. Plain HTTP requests
+// are sent to HTTP proxies in absolute form, as proxies often only allow
+// CONNECT to port 443. Other requests are tunnelled by the dialer, as are
+// h2c requests as proxies don't forward HTTP/2 with prior knowledge.
+func (c *Checker) forwardProxy(req *http.Request) (*url.URL, error) {
+	if c.Req.Options.HTTPProtocol == types.HTTPProtocolH2C {
+		return nil, nil
+	}
+
+	return c.plainHTTPProxy(req)
+}
+
+// plainHTTPProxy returns the HTTP proxy plain HTTP requests are sent to in
+// absolute form, if there is one
+func (c *Checker) plainHTTPProxy(req *http.Request) (*url.URL, error) {
+	proxy := c.proxy()
+	if proxy == nil || req.URL.Scheme != "http" {
+		return nil, nil
+	}
+
+	u, configErr := c.proxyURL(proxy)
+	if configErr != "" {
+		return nil, &proxyError{code: configErr, err: errors.New("Invalid proxy")}
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, nil
+	}
+
+	// The dialer connects to the proxy, with TLS for HTTPS proxies, so the
+	// transport sees a plain HTTP proxy
+	return &url.URL{Scheme: "http", Host: proxyAddress(u), User: u.User}, nil
+}
+
+// dialProxy connects to the address through the proxy. Connections to the
+// HTTP proxy's own address are to the proxy itself, which is how the
+// transport sends requests in absolute form.
+func (c *Checker) dialProxy(ctx context.Context, dialer *net.Dialer, proxy *types.Proxy, network, addr string) (net.Conn, error) {
+	u, configErr := c.proxyURL(proxy)
+	if configErr != "" {
+		return nil, &proxyError{code: configErr, err: errors.New("Invalid proxy")}
+	}
+
+	proxyAddr := proxyAddress(u)
+	conn, err := c.dialResolved(ctx, dialer, network, proxyAddr)
+	if err != nil {
+		return nil, &proxyError{code: proxyDialErrorCode(err), err: err}
+	}
+	forward := addr == proxyAddr
+
+	start := time.Now()
+
+	// The handshake shouldn't take longer than the check
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	} else {
+		conn.SetDeadline(start.Add(c.timeout()))
+	}
+
+	switch u.Scheme {
+	case "https":
+		tlsConn, err := c.proxyTLS(ctx, conn, u.Hostname())
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+
+		conn = tlsConn
+		if !forward {
+			conn, err = c.proxyConnect(conn, u, addr)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+	case "http":
+		if !forward {
+			conn, err = c.proxyConnect(conn, u, addr)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+	default:
+		conn, err = c.proxySOCKS(ctx, conn, u, network, addr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	conn.SetDeadline(time.Time{})
+
+	if trace, ok := ctx.Value(proxyTraceKey{}).(*proxyTrace); ok {
+		trace.duration += time.Since(start)
+		trace.used = true
+	}
+
+	return conn, nil
+}
+
+// proxyTLS performs a TLS handshake with a HTTPS proxy, this trusts the same
+// certificate authorities as the check
+func (c *Checker) proxyTLS(ctx context.Context, conn net.Conn, host string) (net.Conn, error) {
+	config := &tls.Config{ServerName: host}
+	if err := c.configureCAs(config, nil); err != "" {
+		return nil, &proxyError{code: types.ProxyInvalid, err: errors.New("Invalid trusted CAs")}
+	}
+
+	tlsConn := tls.Client(conn, config)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return nil, &proxyError{code: types.ProxyConnectFailed, err: err}
+	}
+
+	return tlsConn, nil
+}
+
+// proxyConnect opens a tunnel to the address with the CONNECT method
+func (c *Checker) proxyConnect(conn net.Conn, u *url.URL, addr string) (net.Conn, error) {
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: http.Header{"User-Agent": {userAgent}},
+	}
+
+	if u.User != nil {
+		password, _ := u.User.Password()
+		auth := base64.StdEncoding.EncodeToString([]byte(u.User.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+auth)
+	}
+
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, proxyHandshakeError(err)
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		conn.Close()
+		return nil, proxyHandshakeError(err)
+	}
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusProxyAuthRequired:
+		conn.Close()
+		return nil, newProxyError(types.ProxyAuthFailed, resp.Status)
+
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		conn.Close()
+		return nil, newProxyError(types.ProxyRejected, resp.Status)
+	}
+
+	// The target may have sent data before we read the response
+	if reader.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, reader: reader}, nil
+	}
+
+	return conn, nil
+}
+
+// bufferedConn is a connection with data already read into the buffer
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+// proxySOCKS connects to the address through a SOCKS5 proxy. With socks5h
+// the proxy resolves the host, otherwise it's resolved locally.
+func (c *Checker) proxySOCKS(ctx context.Context, conn net.Conn, u *url.URL, network, addr string) (net.Conn, error) {
+	host, portString, err := net.SplitHostPort(addr)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	port, _ := strconv.Atoi(portString)
+
+	if u.Scheme == "socks5" && net.ParseIP(host) == nil {
+		if host, err = c.resolveForProxy(ctx, network, host); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	if err := socksHandshake(conn, u.User, host, port); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// resolveForProxy resolves the host to the first address usable on the
+// network
+func (c *Checker) resolveForProxy(ctx context.Context, network, host string) (string, error) {
+	if c.customResolver() {
+		ips, err := c.lookupHost(ctx, network, host)
+		if err != nil {
+			return "", &net.OpError{Op: "dial", Net: network, Err: err}
+		}
+
+		return ips[0], nil
+	}
+
+	ipNetwork := "ip"
+	switch network {
+	case "tcp4":
+		ipNetwork = "ip4"
+	case "tcp6":
+		ipNetwork = "ip6"
+	}
+
+	ips, err := net.DefaultResolver.LookupIP(ctx, ipNetwork, host)
+	if err != nil {
+		return "", &net.OpError{Op: "dial", Net: network, Err: err}
+	}
+
+	return ips[0].String(), nil
+}
+
+func socksHandshake(conn net.Conn, user *url.Userinfo, host string, port int) error {
+	methods := []byte{socksNoAuth}
+	if user != nil {
+		methods = []byte{socksPasswordAuth}
+	}
+
+	if _, err := conn.Write(append([]byte{socksVersion, byte(len(methods))}, methods...)); err != nil {
+		return proxyHandshakeError(err)
+	}
+
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return proxyHandshakeError(err)
+	}
+
+	if reply[0] != socksVersion {
+		return newProxyError(types.ProxyRejected, "Not a SOCKS5 proxy")
+	}
+
+	switch reply[1] {
+	case socksNoAuth:
+
+	case socksPasswordAuth:
+		if user == nil {
+			return newProxyError(types.ProxyAuthFailed, "Proxy requires authentication")
+		}
+
+		if err := socksAuthenticate(conn, user); err != nil {
+			return err
+		}
+
+	case socksNoAcceptable:
+		return newProxyError(types.ProxyAuthFailed, "No acceptable authentication methods")
+
+	default:
+		return newProxyError(types.ProxyRejected, "Unsupported authentication method")
+	}
+
+	// Connect request
+	req := []byte{socksVersion, socksConnect, 0x00}
+	if ip := net.ParseIP(host); ip == nil {
+		if len(host) > 255 {
+			return newProxyError(types.ProxyRejected, "Host name too long")
+		}
+		req = append(req, socksDomain, byte(len(host)))
+		req = append(req, host...)
+	} else if ip4 := ip.To4(); ip4 != nil {
+		req = append(req, socksIPv4)
+		req = append(req, ip4...)
+	} else {
+		req = append(req, socksIPv6)
+		req = append(req, ip...)
+	}
+	req = append(req, byte(port>>8), byte(port))
+
+	if _, err := conn.Write(req); err != nil {
+		return proxyHandshakeError(err)
+	}
+
+	// Reply, the bound address isn't needed
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return proxyHandshakeError(err)
+	}
+
+	if header[1] != socksSucceeded {
+		return socksReplyError(header[1])
+	}
+
+	var skip int
+	switch header[3] {
+	case socksIPv4:
+		skip = net.IPv4len
+	case socksIPv6:
+		skip = net.IPv6len
+	case socksDomain:
+		length := make([]byte, 1)
+		if _, err := io.ReadFull(conn, length); err != nil {
+			return proxyHandshakeError(err)
+		}
+		skip = int(length[0])
+	default:
+		return newProxyError(types.ProxyRejected, "Invalid address type")
+	}
+
+	if _, err := io.ReadFull(conn, make([]byte, skip+2)); err != nil {
+		return proxyHandshakeError(err)
+	}
+
+	return nil
+}
+
+// socksAuthenticate authenticates with a username and password, see RFC 1929
+func socksAuthenticate(conn net.Conn, user *url.Userinfo) error {
+	username := user.Username()
+	password, _ := user.Password()
+	if len(username) > 255 || len(password) > 255 {
+		return newProxyError(types.ProxyInvalid, "Credentials too long")
+	}
+
+	req := []byte{0x01, byte(len(username))}
+	req = append(req, username...)
+	req = append(req, byte(len(password)))
+	req = append(req, password...)
+
+	if _, err := conn.Write(req); err != nil {
+		return proxyHandshakeError(err)
+	}
+
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return proxyHandshakeError(err)
+	}
+
+	if reply[1] != 0x00 {
+		return newProxyError(types.ProxyAuthFailed, "Authentication failed")
+	}
+
+	return nil
+}
+
+// socksReplyError returns the error for the reply. Failures connecting to
+// the target are classified the same as connecting directly.
+func socksReplyError(reply byte) error {
+	switch reply {
+	case socksNetworkUnreachable, socksHostUnreachable:
+		return &targetError{code: types.HostUnreachable}
+	case socksConnectionRefused:
+		return &targetError{code: types.ConnectionRefused}
+	case socksTTLExpired:
+		return &targetError{code: types.Timeout}
+	default:
+		return newProxyError(types.ProxyRejected, fmt.Sprintf("SOCKS reply %d", reply))
+	}
+}
+
+// targetError is an error connecting to the target reported by the proxy
+type targetError struct {
+	code types.CheckError
+}
+
+func (e *targetError) Error() string {
+	return fmt.Sprintf("Proxy: unable to connect to target (%s)", e.code)
+}
+
+// proxyHandshakeError classifies errors reading from or writing to the proxy
+func proxyHandshakeError(err error) error {
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return &proxyError{code: types.ProxyTimeout, err: err}
+	}
+
+	return &proxyError{code: types.ProxyConnectFailed, err: err}
+}
+
+// unwrapProxyError classifies errors from connecting through the proxy
+func unwrapProxyError(err error) (UnwrappedError, bool) {
+	var proxyErr *proxyError
+	if errors.As(err, &proxyErr) {
+		return UnwrappedError{Err: proxyErr.code}, true
+	}
+
+	var targetErr *targetError
+	if errors.As(err, &targetErr) {
+		return UnwrappedError{Err: targetErr.code}, true
+	}
+
+	return UnwrappedError{}, false
+}
+
+// proxyDialErrorCode classifies errors connecting to the proxy
+func proxyDialErrorCode(err error) types.CheckError {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return types.ProxyTimeout
+	}
+
+	return types.ProxyConnectFailed
+}
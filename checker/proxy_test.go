@@ -0,0 +1,278 @@
+package checker_test
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	a "github.com/stretchr/testify/assert"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// connectProxy handles CONNECT requests, requiring the credentials if they
+// are set
+func connectProxy(credentials string) func(net.Conn) {
+	return func(conn net.Conn) {
+		reader := bufio.NewReader(conn)
+		req, err := http.ReadRequest(reader)
+		if err != nil {
+			return
+		}
+
+		if req.Method != http.MethodConnect {
+			conn.Write([]byte("HTTP/1.1 405 Method Not Allowed\r\n\r\n"))
+			return
+		}
+
+		if credentials != "" && req.Header.Get("Proxy-Authorization") != "Basic "+base64.StdEncoding.EncodeToString([]byte(credentials)) {
+			conn.Write([]byte("HTTP/1.1 407 Proxy Authentication Required\r\n\r\n"))
+			return
+		}
+
+		target, err := net.Dial("tcp", req.Host)
+		if err != nil {
+			conn.Write([]byte("HTTP/1.1 502 Bad Gateway\r\n\r\n"))
+			return
+		}
+		defer target.Close()
+
+		conn.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n"))
+		pipe(conn, reader, target)
+	}
+}
+
+// forwardingProxy handles requests in absolute form, requiring the
+// credentials if they are set. CONNECT isn't allowed.
+func forwardingProxy(credentials string) func(net.Conn) {
+	return func(conn net.Conn) {
+		req, err := http.ReadRequest(bufio.NewReader(conn))
+		if err != nil {
+			return
+		}
+
+		if req.Method == http.MethodConnect || !req.URL.IsAbs() {
+			conn.Write([]byte("HTTP/1.1 405 Method Not Allowed\r\n\r\n"))
+			return
+		}
+
+		if credentials != "" && req.Header.Get("Proxy-Authorization") != "Basic "+base64.StdEncoding.EncodeToString([]byte(credentials)) {
+			conn.Write([]byte("HTTP/1.1 407 Proxy Authentication Required\r\n\r\n"))
+			return
+		}
+
+		target, err := net.Dial("tcp", req.URL.Host)
+		if err != nil {
+			conn.Write([]byte("HTTP/1.1 502 Bad Gateway\r\n\r\n"))
+			return
+		}
+		defer target.Close()
+
+		req.Header.Del("Proxy-Authorization")
+		req.Close = true
+		req.Write(target)
+		io.Copy(conn, target)
+	}
+}
+
+// socksProxy handles SOCKS5 connect requests, requiring the credentials if
+// they are set
+func socksProxy(username, password string) func(net.Conn) {
+	return func(conn net.Conn) {
+		header := make([]byte, 2)
+		if _, err := io.ReadFull(conn, header); err != nil {
+			return
+		}
+		methods := make([]byte, header[1])
+		io.ReadFull(conn, methods)
+
+		if username == "" {
+			conn.Write([]byte{0x05, 0x00})
+		} else {
+			conn.Write([]byte{0x05, 0x02})
+
+			auth := make([]byte, 2)
+			io.ReadFull(conn, auth)
+			user := make([]byte, auth[1])
+			io.ReadFull(conn, user)
+			io.ReadFull(conn, auth[:1])
+			pass := make([]byte, auth[0])
+			io.ReadFull(conn, pass)
+
+			if string(user) != username || string(pass) != password {
+				conn.Write([]byte{0x01, 0x01})
+				return
+			}
+			conn.Write([]byte{0x01, 0x00})
+		}
+
+		req := make([]byte, 4)
+		if _, err := io.ReadFull(conn, req); err != nil {
+			return
+		}
+
+		var host string
+		switch req[3] {
+		case 0x01:
+			ip := make([]byte, net.IPv4len)
+			io.ReadFull(conn, ip)
+			host = net.IP(ip).String()
+		case 0x03:
+			length := make([]byte, 1)
+			io.ReadFull(conn, length)
+			name := make([]byte, length[0])
+			io.ReadFull(conn, name)
+			host = string(name)
+		default:
+			return
+		}
+
+		port := make([]byte, 2)
+		io.ReadFull(conn, port)
+
+		target, err := net.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port)))))
+		if err != nil {
+			conn.Write([]byte{0x05, 0x05, 0x00, 0x01, 0, 0, 0, 0, 0, 0})
+			return
+		}
+		defer target.Close()
+
+		conn.Write([]byte{0x05, 0x00, 0x00, 0x01, 127, 0, 0, 1, 0, 0})
+		pipe(conn, conn, target)
+	}
+}
+
+func pipe(conn net.Conn, reader io.Reader, target net.Conn) {
+	go io.Copy(target, reader)
+	io.Copy(conn, target)
+}
+
+// closedAddress returns an address which refuses connections
+func closedAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener.Close()
+
+	return listener.Addr().String()
+}
+
+func TestProxy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(server.Close)
+	tlsServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(tlsServer.Close)
+
+	checker.Configure(checker.Config{Secrets: map[string]string{"proxy": "alice:s3cr3t"}})
+	t.Cleanup(func() { checker.Configure(checker.Config{}) })
+
+	forward := startTCPServer(t, nil, forwardingProxy(""))
+	forwardAuth := startTCPServer(t, nil, forwardingProxy("alice:s3cr3t"))
+	connect := startTCPServer(t, nil, connectProxy(""))
+	connectAuth := startTCPServer(t, nil, connectProxy("alice:s3cr3t"))
+	socks := startTCPServer(t, nil, socksProxy("", ""))
+	socksAuth := startTCPServer(t, nil, socksProxy("alice", "s3cr3t"))
+	rejecting := startTCPServer(t, nil, func(conn net.Conn) {
+		http.ReadRequest(bufio.NewReader(conn))
+		conn.Write([]byte("HTTP/1.1 403 Forbidden\r\n\r\n"))
+	})
+	closed := closedAddress(t)
+
+	tests := []struct {
+		name   string
+		target string
+		proxy  types.Proxy
+		error  string
+	}{
+		{"http", server.URL, types.Proxy{URL: "http://" + forward}, ""},
+		{"http auth", server.URL, types.Proxy{URL: "http://" + forwardAuth, Credentials: "proxy"}, ""},
+		{"http auth missing", server.URL, types.Proxy{URL: "http://" + forwardAuth}, types.ProxyAuthFailed.ToString()},
+		{"connect", tlsServer.URL, types.Proxy{URL: "http://" + connect}, ""},
+		{"connect auth", tlsServer.URL, types.Proxy{URL: "http://" + connectAuth, Credentials: "proxy"}, ""},
+		{"connect auth missing", tlsServer.URL, types.Proxy{URL: "http://" + connectAuth}, types.ProxyAuthFailed.ToString()},
+		{"connect rejected", tlsServer.URL, types.Proxy{URL: "http://" + rejecting}, types.ProxyRejected.ToString()},
+		{"socks5", server.URL, types.Proxy{URL: "socks5://" + socks}, ""},
+		{"socks5h", "http://localhost:" + server.URL[len("http://127.0.0.1:"):], types.Proxy{URL: "socks5h://" + socks}, ""},
+		{"socks5 auth", server.URL, types.Proxy{URL: "socks5://" + socksAuth, Credentials: "proxy"}, ""},
+		{"socks5 auth missing", server.URL, types.Proxy{URL: "socks5://" + socksAuth}, types.ProxyAuthFailed.ToString()},
+		{"socks5 target refused", "http://" + closed, types.Proxy{URL: "socks5://" + socks}, types.ConnectionRefused.ToString()},
+		{"proxy refused", server.URL, types.Proxy{URL: "http://" + closed}, types.ProxyConnectFailed.ToString()},
+		{"invalid", server.URL, types.Proxy{URL: "ftp://" + connect}, types.ProxyInvalid.ToString()},
+		{"missing credentials", server.URL, types.Proxy{URL: "http://" + forwardAuth, Credentials: "missing"}, types.SecretNotFound.ToString()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := buildCheck(tt.target)
+			req.Options.Proxy = tt.proxy
+			req.Options.IgnoreTLSErrors = true
+
+			c := checker.Init(req)
+			c.Perform()
+
+			a.Equal(t, tt.error, c.Res.Error)
+			if tt.error == "" {
+				a.Equal(t, 200, c.Res.StatusCode)
+				a.NotNil(t, c.Res.Timing.Proxy)
+			}
+		})
+	}
+}
+
+func TestProxyH2C(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	// h2c requests are tunnelled with CONNECT, which isn't allowed
+	req := buildCheck(server.URL)
+	req.Options.HTTPProtocol = types.HTTPProtocolH2C
+	req.Options.Proxy = types.Proxy{URL: "http://" + startTCPServer(t, nil, forwardingProxy(""))}
+
+	c := checker.Init(req)
+	c.Perform()
+
+	a.Equal(t, types.ProxyRejected.ToString(), c.Res.Error)
+}
+
+func TestAgentProxy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	// The agent's proxy refuses connections, so only checks with their own
+	// proxy succeed
+	checker.Configure(checker.Config{Proxy: types.Proxy{URL: "http://" + closedAddress(t)}})
+	t.Cleanup(func() { checker.Configure(checker.Config{}) })
+
+	c := checker.Init(buildCheck(server.URL))
+	c.Perform()
+	a.Equal(t, types.ProxyConnectFailed.ToString(), c.Res.Error)
+
+	req := buildCheck(server.URL)
+	req.Options.Proxy = types.Proxy{URL: "socks5://" + startTCPServer(t, nil, socksProxy("", ""))}
+	c = checker.Init(req)
+	c.Perform()
+	a.Equal(t, "", c.Res.Error)
+}
+
+func TestProxyTCPCheck(t *testing.T) {
+	checker.Configure(checker.Config{Proxy: types.Proxy{URL: "http://" + startTCPServer(t, nil, connectProxy(""))}})
+	t.Cleanup(func() { checker.Configure(checker.Config{}) })
+
+	req := buildCheck("tcp://" + startTCPServer(t, nil, pingHandler))
+	req.Type = "tcp"
+	req.Options.TCPCheck = types.TCPCheck{Payload: []byte("PING\r\n"), ExpectBanner: `^\+PONG`}
+
+	c := checker.Init(req)
+	c.Perform()
+
+	a.Equal(t, "", c.Res.Error)
+	a.NotNil(t, c.Res.Timing.Proxy)
+}
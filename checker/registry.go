@@ -0,0 +1,112 @@
+package checker
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// CheckType performs checks of a particular type, e.g. HTTP or DNS.
+// Implementations should report the result with HandleSuccess, HandleFailure
+// or HandleError, so the timing, metrics and errors are consistent between
+// types.
+type CheckType interface {
+	Perform(c *Checker) bool
+}
+
+// CheckTypeFunc is a function which implements CheckType
+type CheckTypeFunc func(c *Checker) bool
+
+// Perform performs the check
+func (f CheckTypeFunc) Perform(c *Checker) bool {
+	return f(c)
+}
+
+var (
+	checkTypes = map[string]CheckType{}
+)
+
+func init() {
+	RegisterCheckType("http", CheckTypeFunc((*Checker).performHTTPCheck))
+	RegisterCheckType("https", CheckTypeFunc((*Checker).performHTTPCheck))
+	RegisterCheckType("tls", CheckTypeFunc((*Checker).performTLSScan))
+	RegisterCheckType("dns", CheckTypeFunc((*Checker).performDNSCheck))
+	RegisterCheckType("tcp", CheckTypeFunc((*Checker).performTCPCheck))
+	RegisterCheckType("smtp", mailCheckType(newSMTPProtocol, "25", false))
+	RegisterCheckType("smtps", mailCheckType(newSMTPProtocol, "465", true))
+	RegisterCheckType("imap", mailCheckType(newIMAPProtocol, "143", false))
+	RegisterCheckType("imaps", mailCheckType(newIMAPProtocol, "993", true))
+	RegisterCheckType("pop3", mailCheckType(newPOP3Protocol, "110", false))
+	RegisterCheckType("pop3s", mailCheckType(newPOP3Protocol, "995", true))
+	RegisterCheckType("ws", CheckTypeFunc((*Checker).performWebSocketCheck))
+	RegisterCheckType("wss", CheckTypeFunc((*Checker).performWebSocketCheck))
+	RegisterCheckType("grpc", CheckTypeFunc((*Checker).performGRPCCheck))
+	RegisterCheckType("grpcs", CheckTypeFunc((*Checker).performGRPCCheck))
+	RegisterCheckType("transaction", CheckTypeFunc((*Checker).performTransaction))
+}
+
+// RegisterCheckType registers the check type, which is used for requests
+// with the type or URL scheme name. This should be called before any checks
+// are performed.
+func RegisterCheckType(name string, checkType CheckType) {
+	checkTypes[strings.ToLower(name)] = checkType
+}
+
+func lookupCheckType(name string) (CheckType, bool) {
+	checkType, ok := checkTypes[strings.ToLower(name)]
+	return checkType, ok
+}
+
+// checkType returns the name of the check type, the type of the request or
+// the URL scheme if it doesn't have one
+func (c *Checker) checkType() string {
+	if c.Req.Type != "" {
+		return c.Req.Type
+	}
+
+	return c.Req.URL.Scheme
+}
+
+// HandleSuccess records the check as successful
+func (c *Checker) HandleSuccess() bool {
+	return c.handleSuccess()
+}
+
+// HandleFailure records the check as failed with the error code
+func (c *Checker) HandleFailure(message string, err types.CheckError) bool {
+	return c.handleFailure(message, err.ToString())
+}
+
+// HandleError records the check as failed, classifying the error
+func (c *Checker) HandleError(message string, err error) bool {
+	return c.handleError(message, err)
+}
+
+// Dial connects to the address, using the resolver, resolve overrides and
+// address family of the check
+func (c *Checker) Dial(ctx context.Context, network, addr string) (net.Conn, error) {
+	return c.dialContext(ctx, network, addr)
+}
+
+// TLSConfig returns the TLS configuration for the check, including the
+// trusted CAs and client certificate
+func (c *Checker) TLSConfig() (*tls.Config, types.CheckError) {
+	return c.tlsConfig()
+}
+
+// CheckCertificate performs the certificate checks requested by the check
+// options, recording the certificate in the result
+func (c *Checker) CheckCertificate(state *tls.ConnectionState) types.CheckError {
+	c.Res.Certificate = certInfoFromTLSConnectionState(state)
+
+	return c.checkCertificate(state)
+}
+
+// Timeout returns the timeout for the check
+func (c *Checker) Timeout() time.Duration {
+	return c.timeout()
+}
@@ -0,0 +1,84 @@
+package checker_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	a "github.com/stretchr/testify/assert"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// echoCheck connects to the server and checks it echoes a message
+var echoCheck = checker.CheckTypeFunc(func(c *checker.Checker) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout())
+	defer cancel()
+
+	conn, err := c.Dial(ctx, "tcp", c.Req.URL.Host)
+	if err != nil {
+		return c.HandleError("Error connecting", err)
+	}
+	defer conn.Close()
+
+	conn.Write([]byte("hello"))
+
+	buf := make([]byte, 5)
+	if _, err := conn.Read(buf); err != nil {
+		return c.HandleError("Error reading", err)
+	}
+
+	if string(buf) != "hello" {
+		return c.HandleFailure("Unexpected reply", types.TCPBannerMismatch)
+	}
+
+	return c.HandleSuccess()
+})
+
+func TestRegisterCheckType(t *testing.T) {
+	checker.RegisterCheckType("echo", echoCheck)
+
+	addr := startTCPServer(t, nil, func(conn net.Conn) {
+		buf := make([]byte, 5)
+		conn.Read(buf)
+		conn.Write(buf)
+	})
+
+	// Nothing listening
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed := listener.Addr().String()
+	listener.Close()
+
+	tests := []struct {
+		name      string
+		checkType string
+		url       string
+		error     string
+	}{
+		{"scheme", "", "echo://" + addr, ""},
+		{"type", "echo", "custom://" + addr, ""},
+		{"builtin type", "tcp", "redis://" + addr, ""},
+		{"shared error reporting", "", "echo://" + closed, types.ConnectionRefused.ToString()},
+		{"unsupported scheme", "", "custom://" + addr, types.UnsupportedSchema.ToString()},
+		{"unsupported type", "custom", "echo://" + addr, types.UnsupportedCheckType.ToString()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := buildCheck(tt.url)
+			req.Type = tt.checkType
+
+			c := checker.Init(req)
+			c.Perform()
+
+			a.Equal(t, tt.error, c.Res.Error)
+			a.NotNil(t, c.Res.Time)
+			a.True(t, *c.Res.Time < time.Second)
+		})
+	}
+}
@@ -0,0 +1,370 @@
+package checker
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+const (
+	defaultResolverTimeout = 5 * time.Second
+
+	// Maximum size of a DNS over HTTPS response
+	maxDoHResponseSize = 65535
+)
+
+var (
+	errInvalidNameserver = errors.New("Invalid nameserver")
+)
+
+// nameserver is a parsed nameserver address
+type nameserver struct {
+	// udp, tcp, tls or https
+	protocol string
+
+	// host:port, or the URL for https
+	addr string
+}
+
+func (ns *nameserver) String() string {
+	if ns.protocol == "https" {
+		return ns.addr
+	}
+
+	return ns.protocol + "://" + ns.addr
+}
+
+func parseNameserver(s string) (*nameserver, error) {
+	if !strings.Contains(s, "://") {
+		s = "udp://" + s
+	}
+
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return nil, errInvalidNameserver
+	}
+
+	switch u.Scheme {
+	case "udp", "tcp":
+		return &nameserver{protocol: u.Scheme, addr: hostPort(u, "53")}, nil
+
+	case "tls":
+		return &nameserver{protocol: u.Scheme, addr: hostPort(u, "853")}, nil
+
+	case "https":
+		return &nameserver{protocol: u.Scheme, addr: u.String()}, nil
+
+	default:
+		return nil, errInvalidNameserver
+	}
+}
+
+// ValidateResolver checks the nameserver addresses can be parsed
+func ValidateResolver(resolver types.Resolver) error {
+	for _, s := range resolver.Nameservers {
+		if _, err := parseNameserver(s); err != nil {
+			return fmt.Errorf("%v %s", err, s)
+		}
+	}
+
+	return nil
+}
+
+// resolver returns the resolver for the check, or the agent resolver if the
+// check doesn't have one. If neither has nameservers the system resolver is
+// used.
+func (c *Checker) resolver() types.Resolver {
+	if len(c.Req.Options.Resolver.Nameservers) > 0 {
+		return c.Req.Options.Resolver
+	}
+
+	return agentConfig.Resolver
+}
+
+// resolverTimeout returns the timeout for each query
+func (c *Checker) resolverTimeout() time.Duration {
+	if timeout := c.resolver().Timeout; timeout > 0 {
+		return timeout
+	}
+
+	return defaultResolverTimeout
+}
+
+// customResolver returns whether the system resolver is bypassed
+func (c *Checker) customResolver() bool {
+	return len(c.resolver().Nameservers) > 0
+}
+
+// lookupHost resolves the host using the custom resolver, only looking up
+// the addresses usable on the network (tcp, tcp4 or tcp6)
+func (c *Checker) lookupHost(ctx context.Context, network, host string) ([]string, error) {
+	resolver := c.resolver()
+	timeout := c.resolverTimeout()
+
+	var qtypes []uint16
+	if network != "tcp6" {
+		qtypes = append(qtypes, dns.TypeA)
+	}
+	if network != "tcp4" {
+		qtypes = append(qtypes, dns.TypeAAAA)
+	}
+
+	trace := httptrace.ContextClientTrace(ctx)
+	if trace != nil && trace.DNSStart != nil {
+		trace.DNSStart(httptrace.DNSStartInfo{Host: host})
+	}
+
+	addrs, err := c.queryNameservers(ctx, resolver.Nameservers, timeout, network, host, qtypes)
+
+	if trace != nil && trace.DNSDone != nil {
+		info := httptrace.DNSDoneInfo{Err: err}
+		for _, addr := range addrs {
+			info.Addrs = append(info.Addrs, net.IPAddr{IP: net.ParseIP(addr)})
+		}
+
+		trace.DNSDone(info)
+	}
+
+	return addrs, err
+}
+
+// queryNameservers queries each nameserver in turn until one answers
+func (c *Checker) queryNameservers(ctx context.Context, nameservers []string, timeout time.Duration, network, host string, qtypes []uint16) ([]string, error) {
+	dnsErr := &net.DNSError{Name: host, Err: "no nameservers"}
+
+	for _, s := range nameservers {
+		ns, err := parseNameserver(s)
+		if err != nil {
+			dnsErr = &net.DNSError{Name: host, Server: s, Err: err.Error()}
+			continue
+		}
+
+		res := &types.DNSResolution{
+			Host:     host,
+			Resolver: ns.String(),
+			Rcode:    dns.RcodeToString[dns.RcodeSuccess],
+		}
+
+		// As with the system resolver, a failed query is ignored if the
+		// other address family returned addresses
+		var queryErr error
+		for _, qtype := range qtypes {
+			msg := new(dns.Msg)
+			msg.SetQuestion(dns.Fqdn(host), qtype)
+
+			resp, err := c.exchange(ctx, ns, msg, timeout)
+			if err != nil {
+				log.WithFields(log.Fields{
+					"Ref":        c.Req.Ref,
+					"Nameserver": ns.String(),
+					"Err":        err,
+				}).Debug("DNS query failed")
+
+				queryErr = err
+				continue
+			}
+
+			if resp.Rcode != dns.RcodeSuccess {
+				res.Rcode = dns.RcodeToString[resp.Rcode]
+				break
+			}
+
+			for _, rr := range resp.Answer {
+				switch rr := rr.(type) {
+				case *dns.A:
+					res.Addresses = append(res.Addresses, rr.A.String())
+				case *dns.AAAA:
+					res.Addresses = append(res.Addresses, rr.AAAA.String())
+				}
+			}
+		}
+
+		if queryErr != nil && len(res.Addresses) == 0 && res.Rcode == dns.RcodeToString[dns.RcodeSuccess] {
+			dnsErr = &net.DNSError{Name: host, Server: ns.String(), Err: queryErr.Error(), IsTimeout: isTimeout(queryErr)}
+			continue
+		}
+
+		c.Res.DNS = res
+
+		switch {
+		case res.Rcode == dns.RcodeToString[dns.RcodeNameError]:
+			return nil, &net.DNSError{Name: host, Server: ns.String(), Err: "no such host", IsNotFound: true}
+
+		case res.Rcode != dns.RcodeToString[dns.RcodeSuccess]:
+			return nil, &net.DNSError{Name: host, Server: ns.String(), Err: "server misbehaving"}
+
+		case len(res.Addresses) == 0 && network != "tcp":
+			return nil, &net.AddrError{Err: noSuitableAddress, Addr: host}
+
+		case len(res.Addresses) == 0:
+			return nil, &net.DNSError{Name: host, Server: ns.String(), Err: "no such host", IsNotFound: true}
+		}
+
+		return res.Addresses, nil
+	}
+
+	return nil, dnsErr
+}
+
+// untracedContext keeps the deadline and cancellation of the parent context,
+// but none of its values, so the check's client trace isn't inherited
+type untracedContext struct {
+	context.Context
+}
+
+func (untracedContext) Value(key interface{}) interface{} {
+	return nil
+}
+
+// exchange sends the query to the nameserver. The connection to the
+// nameserver isn't traced, so it isn't recorded in the check's timings.
+func (c *Checker) exchange(ctx context.Context, ns *nameserver, msg *dns.Msg, timeout time.Duration) (*dns.Msg, error) {
+	ctx, cancel := context.WithTimeout(untracedContext{ctx}, timeout)
+	defer cancel()
+
+	if ns.protocol == "https" {
+		return c.exchangeDoH(ctx, ns, msg)
+	}
+
+	client := &dns.Client{Timeout: timeout}
+
+	if ns.protocol == "tls" {
+		config, err := c.resolverTLSConfig(ns.addr)
+		if err != nil {
+			return nil, err
+		}
+
+		return c.exchangeConn(ctx, client, "tcp", config, ns.addr, msg)
+	}
+
+	resp, err := c.exchangeConn(ctx, client, ns.protocol, nil, ns.addr, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Retry over TCP if the response didn't fit in a UDP packet
+	if resp.Truncated && ns.protocol == "udp" {
+		resp, err = c.exchangeConn(ctx, client, "tcp", nil, ns.addr, msg)
+	}
+
+	return resp, err
+}
+
+// exchangeConn connects to the nameserver from the bind address, and sends
+// the query over the connection
+func (c *Checker) exchangeConn(ctx context.Context, client *dns.Client, network string, config *tls.Config, addr string, msg *dns.Msg) (*dns.Msg, error) {
+	conn, err := c.dialFrom(ctx, &net.Dialer{}, network, addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if config != nil {
+		tlsConn := tls.Client(conn, config)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return nil, err
+		}
+		conn = tlsConn
+	}
+
+	resp, _, err := client.ExchangeWithConnContext(ctx, msg, &dns.Conn{Conn: conn})
+	return resp, err
+}
+
+// exchangeDoH sends the query using DNS over HTTPS (RFC 8484)
+func (c *Checker) exchangeDoH(ctx context.Context, ns *nameserver, msg *dns.Msg) (*dns.Msg, error) {
+	// The ID should be zero to make responses cache friendly
+	msg.Id = 0
+
+	packed, err := msg.Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	u, _ := url.Parse(ns.addr)
+	config, err := c.resolverTLSConfig(hostPort(u, "443"))
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				return c.dialFrom(ctx, &net.Dialer{}, network, addr)
+			},
+			TLSClientConfig:   config,
+			ForceAttemptHTTP2: true,
+		},
+	}
+	defer client.CloseIdleConnections()
+
+	req, err := http.NewRequest("POST", ns.addr, bytes.NewReader(packed))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/dns-message")
+	req.Header.Set("Accept", "application/dns-message")
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("DNS over HTTPS status %d", resp.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxDoHResponseSize))
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(dns.Msg)
+	if err := res.Unpack(body); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// resolverTLSConfig returns the TLS configuration used to connect to DNS over
+// TLS and DNS over HTTPS nameservers, which trusts the same certificate
+// authorities as the check
+func (c *Checker) resolverTLSConfig(addr string) (*tls.Config, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{ServerName: host}
+	if err := c.configureCAs(config, nil); err != "" {
+		return nil, errors.New(err.ToString())
+	}
+
+	return config, nil
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return netErr.Timeout()
+	}
+
+	return errors.Is(err, context.DeadlineExceeded)
+}
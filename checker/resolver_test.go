@@ -0,0 +1,264 @@
+package checker_test
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	a "github.com/stretchr/testify/assert"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// testDNSHandler answers for a few names under example.test
+var testDNSHandler = dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+	res := new(dns.Msg)
+	res.SetReply(req)
+
+	q := req.Question[0]
+	switch q.Name {
+	case "www.example.test.":
+		if q.Qtype == dns.TypeA {
+			rr, _ := dns.NewRR("www.example.test. 60 IN A 127.0.0.1")
+			res.Answer = append(res.Answer, rr)
+		}
+
+	case "dual.example.test.":
+		switch q.Qtype {
+		case dns.TypeA:
+			rr, _ := dns.NewRR("dual.example.test. 60 IN A 127.0.0.1")
+			res.Answer = append(res.Answer, rr)
+		case dns.TypeAAAA:
+			rr, _ := dns.NewRR("dual.example.test. 60 IN AAAA ::1")
+			res.Answer = append(res.Answer, rr)
+		}
+
+	case "broken.example.test.":
+		res.Rcode = dns.RcodeServerFailure
+
+	default:
+		res.Rcode = dns.RcodeNameError
+	}
+
+	w.WriteMsg(res)
+})
+
+// startDNSServer starts a DNS server on the network, returning its address
+func startDNSServer(t *testing.T, network string, config *tls.Config) string {
+	t.Helper()
+
+	server := &dns.Server{Net: network, Handler: testDNSHandler}
+	started := make(chan struct{})
+	server.NotifyStartedFunc = func() { close(started) }
+
+	var addr string
+	switch network {
+	case "udp":
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		server.PacketConn = conn
+		addr = conn.LocalAddr().String()
+
+	default:
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if config != nil {
+			listener = tls.NewListener(listener, config)
+		}
+		server.Listener = listener
+		addr = listener.Addr().String()
+	}
+
+	go server.ActivateAndServe()
+	t.Cleanup(func() { server.Shutdown() })
+	<-started
+
+	return addr
+}
+
+// dohHandler answers DNS over HTTPS queries with testDNSHandler
+var dohHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+
+	req := new(dns.Msg)
+	if r.Header.Get("Content-Type") != "application/dns-message" || req.Unpack(body) != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	rw := &dohResponseWriter{}
+	testDNSHandler.ServeDNS(rw, req)
+
+	packed, _ := rw.msg.Pack()
+	w.Header().Set("Content-Type", "application/dns-message")
+	w.Write(packed)
+})
+
+// startDoHServer starts a DNS over HTTPS server, returning its URL
+func startDoHServer(t *testing.T, cert tls.Certificate) string {
+	return startTLSServer(t, cert, dohHandler).URL + "/dns-query"
+}
+
+type dohResponseWriter struct {
+	dns.ResponseWriter
+	msg *dns.Msg
+}
+
+func (w *dohResponseWriter) WriteMsg(msg *dns.Msg) error {
+	w.msg = msg
+	return nil
+}
+
+func TestResolver(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+	cert := issueCert(t, certTemplate(), nil, ca).TLSCertificate()
+	caPEM, _ := ca.PEM(t)
+
+	udp := startDNSServer(t, "udp", nil)
+	tcp := startDNSServer(t, "tcp", nil)
+	dot := startDNSServer(t, "tcp-tls", &tls.Config{Certificates: []tls.Certificate{cert}})
+	doh := startDoHServer(t, cert)
+
+	// Nameserver which never answers
+	unresponsive, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unresponsive.Close()
+
+	server := httptest.NewServer(nil)
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+
+	tests := []struct {
+		name        string
+		host        string
+		nameservers []string
+		resolver    string
+		error       string
+	}{
+		{"udp", "www", []string{"udp://" + udp}, "udp://" + udp, "404"},
+		{"no scheme", "www", []string{udp}, "udp://" + udp, "404"},
+		{"tcp", "www", []string{"tcp://" + tcp}, "tcp://" + tcp, "404"},
+		{"tls", "www", []string{"tls://" + dot}, "tls://" + dot, "404"},
+		{"https", "www", []string{doh}, doh, "404"},
+		{"fallback", "www", []string{"udp://" + unresponsive.LocalAddr().String(), "tcp://" + tcp}, "tcp://" + tcp, "404"},
+		{"nxdomain", "missing", []string{"udp://" + udp}, "udp://" + udp, types.NxDomain.ToString()},
+		{"servfail", "broken", []string{"udp://" + udp}, "udp://" + udp, types.DNSError.ToString()},
+		{"timeout", "www", []string{"udp://" + unresponsive.LocalAddr().String()}, "", types.Timeout.ToString()},
+		{"invalid", "www", []string{"ftp://" + udp}, "", types.ResolverInvalid.ToString()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := buildCheck("http://" + tt.host + ".example.test:" + serverURL.Port() + "/status/404")
+			req.Options.TrustedCAs = types.TrustedCAs{PEM: caPEM}
+			req.Options.Resolver = types.Resolver{
+				Nameservers: tt.nameservers,
+				Timeout:     200 * time.Millisecond,
+			}
+
+			c := checker.Init(req)
+			c.Perform()
+
+			// The server returns 404 for every request, so a successful
+			// lookup fails with the status code
+			a.Equal(t, tt.error, c.Res.Error)
+
+			if tt.resolver == "" {
+				a.Nil(t, c.Res.DNS)
+				return
+			}
+
+			a.Equal(t, tt.host+".example.test", c.Res.DNS.Host)
+			a.Equal(t, tt.resolver, c.Res.DNS.Resolver)
+
+			if tt.error == "404" {
+				a.Equal(t, "NOERROR", c.Res.DNS.Rcode)
+				a.Equal(t, []string{"127.0.0.1"}, c.Res.DNS.Addresses)
+				a.NotNil(t, c.Res.Timing.DNS)
+			}
+		})
+	}
+}
+
+func TestResolverTiming(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+	cert := issueCert(t, certTemplate(), nil, ca).TLSCertificate()
+	caPEM, _ := ca.PEM(t)
+
+	// DNS over HTTPS server with a slow handshake, so it would show in the
+	// timings if it was traced
+	dohServer := httptest.NewUnstartedServer(dohHandler)
+	dohServer.TLS = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		GetConfigForClient: func(_ *tls.ClientHelloInfo) (*tls.Config, error) {
+			time.Sleep(20 * time.Millisecond)
+			return nil, nil
+		},
+	}
+	dohServer.StartTLS()
+	t.Cleanup(dohServer.Close)
+	doh := dohServer.URL + "/dns-query"
+
+	server := httptest.NewServer(nil)
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+
+	req := buildCheck("http://www.example.test:" + serverURL.Port() + "/status/404")
+	req.Options.TrustedCAs = types.TrustedCAs{PEM: caPEM}
+	req.Options.Resolver = types.Resolver{Nameservers: []string{doh}}
+
+	c := checker.Init(req)
+	c.Perform()
+
+	a.Equal(t, "404", c.Res.Error)
+	a.Equal(t, doh, c.Res.DNS.Resolver)
+
+	// The connection to the DNS over HTTPS server isn't part of the timings
+	// for the plain HTTP target
+	a.Equal(t, time.Duration(0), *c.Res.Timing.TLS)
+	a.GreaterOrEqual(t, *c.Res.Timing.DNS, 20*time.Millisecond)
+	a.Less(t, *c.Res.Timing.Connecting, 20*time.Millisecond)
+}
+
+func TestAgentResolver(t *testing.T) {
+	udp := startDNSServer(t, "udp", nil)
+	tcp := startDNSServer(t, "tcp", nil)
+
+	checker.Configure(checker.Config{Resolver: types.Resolver{Nameservers: []string{udp}}})
+	t.Cleanup(func() { checker.Configure(checker.Config{}) })
+
+	server := httptest.NewServer(nil)
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	checkURL := "http://www.example.test:" + serverURL.Port() + "/status/404"
+
+	// Agent resolver
+	c := checker.Init(buildCheck(checkURL))
+	c.Perform()
+
+	a.Equal(t, "404", c.Res.Error)
+	a.Equal(t, "udp://"+udp, c.Res.DNS.Resolver)
+
+	// Check resolver takes precedence
+	req := buildCheck(checkURL)
+	req.Options.Resolver = types.Resolver{Nameservers: []string{"tcp://" + tcp}}
+
+	c = checker.Init(req)
+	c.Perform()
+
+	a.Equal(t, "404", c.Res.Error)
+	a.Equal(t, "tcp://"+tcp, c.Res.DNS.Resolver)
+}
@@ -0,0 +1,220 @@
+package checker
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+const (
+	revocationTimeout = 10 * time.Second
+
+	// Limit the size of responses, some CRLs are huge
+	maxRevocationResponseSize = 10 * 1024 * 1024
+)
+
+var (
+	errResponseExpired = errors.New("Revocation response has expired")
+)
+
+// checkRevocation determines if the certificate presented by the server has
+// been revoked. A stapled OCSP response is used if there is one, otherwise the
+// OCSP responders and then the CRL distribution points named in the
+// certificate are queried. If a source doesn't know the certificate the next
+// is tried, and if none do the first to respond is reported as the source.
+func (c *Checker) checkRevocation(state *tls.ConnectionState) (types.RevocationStatus, types.RevocationSource) {
+	cert := state.PeerCertificates[0]
+
+	issuer := issuerFromConnectionState(state)
+	if issuer == nil {
+		return types.RevocationUnknown, ""
+	}
+
+	var unknownSource types.RevocationSource
+
+	if len(state.OCSPResponse) > 0 {
+		status, err := parseOCSPResponse(state.OCSPResponse, cert, issuer)
+		if err == nil && status != types.RevocationUnknown {
+			return status, types.RevocationSourceStapled
+		}
+
+		if err == nil {
+			unknownSource = types.RevocationSourceStapled
+		} else {
+			c.logRevocationError(err, "stapled")
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout())
+	defer cancel()
+
+	client := c.revocationClient()
+
+	for _, server := range cert.OCSPServer {
+		status, err := queryOCSP(ctx, client, server, cert, issuer)
+		if err == nil && status != types.RevocationUnknown {
+			return status, types.RevocationSourceOCSP
+		}
+
+		if err != nil {
+			c.logRevocationError(err, server)
+		} else if unknownSource == "" {
+			unknownSource = types.RevocationSourceOCSP
+		}
+	}
+
+	for _, distributionPoint := range cert.CRLDistributionPoints {
+		status, err := queryCRL(ctx, client, distributionPoint, cert, issuer)
+		if err == nil {
+			return status, types.RevocationSourceCRL
+		}
+
+		c.logRevocationError(err, distributionPoint)
+	}
+
+	return types.RevocationUnknown, unknownSource
+}
+
+// revocationClient returns a client which connects to OCSP responders and
+// CRL distribution points the same way as the check, without recording the
+// connections in the result
+func (c *Checker) revocationClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = c.dial
+	if c.proxy() != nil {
+		transport.Proxy = nil
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   revocationTimeout,
+	}
+}
+
+func (c *Checker) logRevocationError(err error, source string) {
+	log.WithFields(log.Fields{
+		"Ref":    c.Req.Ref,
+		"Err":    err,
+		"Source": source,
+	}).Debug("Unable to check revocation")
+}
+
+func issuerFromConnectionState(state *tls.ConnectionState) *x509.Certificate {
+	if len(state.VerifiedChains) > 0 && len(state.VerifiedChains[0]) > 1 {
+		return state.VerifiedChains[0][1]
+	}
+
+	// Chain wasn't verified (IgnoreTLSErrors), so trust the server's order
+	if len(state.PeerCertificates) > 1 {
+		return state.PeerCertificates[1]
+	}
+
+	return nil
+}
+
+func parseOCSPResponse(raw []byte, cert, issuer *x509.Certificate) (types.RevocationStatus, error) {
+	resp, err := ocsp.ParseResponseForCert(raw, cert, issuer)
+	if err != nil {
+		return types.RevocationUnknown, err
+	}
+
+	if !resp.NextUpdate.IsZero() && resp.NextUpdate.Before(time.Now()) {
+		return types.RevocationUnknown, errResponseExpired
+	}
+
+	switch resp.Status {
+	case ocsp.Good:
+		return types.RevocationGood, nil
+
+	case ocsp.Revoked:
+		return types.RevocationRevoked, nil
+
+	default:
+		return types.RevocationUnknown, nil
+	}
+}
+
+func queryOCSP(ctx context.Context, client *http.Client, server string, cert, issuer *x509.Certificate) (types.RevocationStatus, error) {
+	body, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return types.RevocationUnknown, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server, bytes.NewReader(body))
+	if err != nil {
+		return types.RevocationUnknown, err
+	}
+	req.Header.Set("Content-Type", "application/ocsp-request")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return types.RevocationUnknown, err
+	}
+
+	body, err = readRevocationResponse(resp)
+	if err != nil {
+		return types.RevocationUnknown, err
+	}
+
+	return parseOCSPResponse(body, cert, issuer)
+}
+
+func queryCRL(ctx context.Context, client *http.Client, distributionPoint string, cert, issuer *x509.Certificate) (types.RevocationStatus, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, distributionPoint, nil)
+	if err != nil {
+		return types.RevocationUnknown, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return types.RevocationUnknown, err
+	}
+
+	body, err := readRevocationResponse(resp)
+	if err != nil {
+		return types.RevocationUnknown, err
+	}
+
+	crl, err := x509.ParseRevocationList(body)
+	if err != nil {
+		return types.RevocationUnknown, err
+	}
+
+	if err := crl.CheckSignatureFrom(issuer); err != nil {
+		return types.RevocationUnknown, err
+	}
+
+	if !crl.NextUpdate.IsZero() && crl.NextUpdate.Before(time.Now()) {
+		return types.RevocationUnknown, errResponseExpired
+	}
+
+	for _, entry := range crl.RevokedCertificateEntries {
+		if entry.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+			return types.RevocationRevoked, nil
+		}
+	}
+
+	return types.RevocationGood, nil
+}
+
+func readRevocationResponse(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Unsuccessful status code %d", resp.StatusCode)
+	}
+
+	return ioutil.ReadAll(io.LimitReader(resp.Body, maxRevocationResponseSize))
+}
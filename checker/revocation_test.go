@@ -0,0 +1,287 @@
+package checker_test
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	a "github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// ocspResponse creates a signed OCSP response for the certificate
+func ocspResponse(t *testing.T, ca, cert *testCert, status int) []byte {
+	t.Helper()
+
+	resp, err := ocsp.CreateResponse(ca.Cert, ca.Cert, ocsp.Response{
+		Status:       status,
+		SerialNumber: cert.Cert.SerialNumber,
+		ThisUpdate:   time.Now().Add(-time.Hour),
+		NextUpdate:   time.Now().Add(time.Hour),
+		RevokedAt:    time.Now().Add(-time.Hour),
+	}, ca.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return resp
+}
+
+// startOCSPResponder starts a local OCSP responder which answers with the
+// given status for every certificate issued by the CA
+func startOCSPResponder(t *testing.T, ca *testCert, status int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		req, err := ocsp.ParseRequest(body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		resp, err := ocsp.CreateResponse(ca.Cert, ca.Cert, ocsp.Response{
+			Status:       status,
+			SerialNumber: req.SerialNumber,
+			ThisUpdate:   time.Now().Add(-time.Hour),
+			NextUpdate:   time.Now().Add(time.Hour),
+			RevokedAt:    time.Now().Add(-time.Hour),
+		}, ca.Key)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/ocsp-response")
+		w.Write(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// startCRLServer starts a local server for a CRL listing the revoked serials
+func startCRLServer(t *testing.T, ca *testCert, revoked ...*big.Int) *httptest.Server {
+	var entries []x509.RevocationListEntry
+	for _, serial := range revoked {
+		entries = append(entries, x509.RevocationListEntry{
+			SerialNumber:   serial,
+			RevocationTime: time.Now().Add(-time.Hour),
+		})
+	}
+
+	crl, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:                    big.NewInt(1),
+		ThisUpdate:                time.Now().Add(-time.Hour),
+		NextUpdate:                time.Now().Add(time.Hour),
+		RevokedCertificateEntries: entries,
+	}, ca.Cert, ca.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pkix-crl")
+		w.Write(crl)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestRevocationStapled(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+	cert := issueCert(t, certTemplate(), nil, ca)
+
+	tlsCert := cert.TLSCertificate(ca)
+	tlsCert.OCSPStaple = ocspResponse(t, ca, cert, ocsp.Good)
+	server := startTLSServer(t, tlsCert, nil)
+
+	req := buildCheck(server.URL)
+	req.Options.IgnoreTLSErrors = true
+	req.Options.CheckRevocation = true
+
+	c := checker.Init(req)
+	c.Perform()
+	a.Equal(t, true, c.Success)
+	a.Equal(t, types.RevocationGood, c.Res.Certificate.RevocationStatus)
+	a.Equal(t, types.RevocationSourceStapled, c.Res.Certificate.RevocationSource)
+
+	tlsCert.OCSPStaple = ocspResponse(t, ca, cert, ocsp.Revoked)
+	server = startTLSServer(t, tlsCert, nil)
+
+	req = buildCheck(server.URL)
+	req.Options.IgnoreTLSErrors = true
+	req.Options.CheckRevocation = true
+
+	c = checker.Init(req)
+	c.Perform()
+	a.Equal(t, false, c.Success)
+	a.Equal(t, types.CertRevoked.ToString(), c.Res.Error)
+	a.Equal(t, types.RevocationRevoked, c.Res.Certificate.RevocationStatus)
+}
+
+func TestRevocationOCSPResponder(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+
+	tests := []struct {
+		status  int
+		success bool
+		result  types.RevocationStatus
+		error   string
+	}{
+		{ocsp.Good, true, types.RevocationGood, ""},
+		{ocsp.Revoked, false, types.RevocationRevoked, types.CertRevoked.ToString()},
+		{ocsp.Unknown, true, types.RevocationUnknown, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.result), func(t *testing.T) {
+			responder := startOCSPResponder(t, ca, tt.status)
+
+			template := certTemplate()
+			template.OCSPServer = []string{responder.URL}
+			cert := issueCert(t, template, nil, ca)
+
+			server := startTLSServer(t, cert.TLSCertificate(ca), nil)
+
+			req := buildCheck(server.URL)
+			req.Options.IgnoreTLSErrors = true
+			req.Options.CheckRevocation = true
+
+			c := checker.Init(req)
+			c.Perform()
+			a.Equal(t, tt.success, c.Success)
+			a.Equal(t, tt.error, c.Res.Error)
+			a.Equal(t, tt.result, c.Res.Certificate.RevocationStatus)
+			a.Equal(t, types.RevocationSourceOCSP, c.Res.Certificate.RevocationSource)
+		})
+	}
+}
+
+func TestRevocationCRL(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+
+	revokedTemplate := certTemplate()
+	crl := startCRLServer(t, ca, revokedTemplate.SerialNumber)
+
+	revokedTemplate.CRLDistributionPoints = []string{crl.URL}
+	revoked := issueCert(t, revokedTemplate, nil, ca)
+
+	goodTemplate := certTemplate()
+	goodTemplate.CRLDistributionPoints = []string{crl.URL}
+	good := issueCert(t, goodTemplate, nil, ca)
+
+	server := startTLSServer(t, good.TLSCertificate(ca), nil)
+
+	req := buildCheck(server.URL)
+	req.Options.IgnoreTLSErrors = true
+	req.Options.CheckRevocation = true
+
+	c := checker.Init(req)
+	c.Perform()
+	a.Equal(t, true, c.Success)
+	a.Equal(t, types.RevocationGood, c.Res.Certificate.RevocationStatus)
+	a.Equal(t, types.RevocationSourceCRL, c.Res.Certificate.RevocationSource)
+
+	server = startTLSServer(t, revoked.TLSCertificate(ca), nil)
+
+	req = buildCheck(server.URL)
+	req.Options.IgnoreTLSErrors = true
+	req.Options.CheckRevocation = true
+
+	c = checker.Init(req)
+	c.Perform()
+	a.Equal(t, false, c.Success)
+	a.Equal(t, types.CertRevoked.ToString(), c.Res.Error)
+	a.Equal(t, types.RevocationRevoked, c.Res.Certificate.RevocationStatus)
+}
+
+func TestRevocationUnknownFallsBackToCRL(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+	responder := startOCSPResponder(t, ca, ocsp.Unknown)
+
+	revokedTemplate := certTemplate()
+	crl := startCRLServer(t, ca, revokedTemplate.SerialNumber)
+
+	// Stapled response which doesn't know the certificate
+	revokedTemplate.CRLDistributionPoints = []string{crl.URL}
+	revoked := issueCert(t, revokedTemplate, nil, ca)
+
+	tlsCert := revoked.TLSCertificate(ca)
+	tlsCert.OCSPStaple = ocspResponse(t, ca, revoked, ocsp.Unknown)
+	server := startTLSServer(t, tlsCert, nil)
+
+	req := buildCheck(server.URL)
+	req.Options.IgnoreTLSErrors = true
+	req.Options.CheckRevocation = true
+
+	c := checker.Init(req)
+	c.Perform()
+	a.Equal(t, types.CertRevoked.ToString(), c.Res.Error)
+	a.Equal(t, types.RevocationRevoked, c.Res.Certificate.RevocationStatus)
+	a.Equal(t, types.RevocationSourceCRL, c.Res.Certificate.RevocationSource)
+
+	// Responder which doesn't know the certificate
+	goodTemplate := certTemplate()
+	goodTemplate.OCSPServer = []string{responder.URL}
+	goodTemplate.CRLDistributionPoints = []string{crl.URL}
+	good := issueCert(t, goodTemplate, nil, ca)
+
+	server = startTLSServer(t, good.TLSCertificate(ca), nil)
+
+	req = buildCheck(server.URL)
+	req.Options.IgnoreTLSErrors = true
+	req.Options.CheckRevocation = true
+
+	c = checker.Init(req)
+	c.Perform()
+	a.Equal(t, true, c.Success)
+	a.Equal(t, types.RevocationGood, c.Res.Certificate.RevocationStatus)
+	a.Equal(t, types.RevocationSourceCRL, c.Res.Certificate.RevocationSource)
+}
+
+func TestRevocationResolveOverride(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+	responder := startOCSPResponder(t, ca, ocsp.Revoked)
+
+	// The responder can only be reached with the check's resolve override
+	template := certTemplate()
+	template.OCSPServer = []string{"http://ocsp.invalid:" + responder.URL[len("http://127.0.0.1:"):]}
+	cert := issueCert(t, template, nil, ca)
+	server := startTLSServer(t, cert.TLSCertificate(ca), nil)
+
+	req := buildCheck(server.URL)
+	req.Options.IgnoreTLSErrors = true
+	req.Options.CheckRevocation = true
+	req.Options.ResolveOverrides = []types.ResolveOverride{{Host: "ocsp.invalid", Address: "127.0.0.1"}}
+
+	c := checker.Init(req)
+	c.Perform()
+
+	a.Equal(t, types.CertRevoked.ToString(), c.Res.Error)
+	a.Equal(t, types.RevocationSourceOCSP, c.Res.Certificate.RevocationSource)
+}
+
+func TestRevocationUnavailable(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+	cert := issueCert(t, certTemplate(), nil, ca)
+
+	server := startTLSServer(t, cert.TLSCertificate(ca), nil)
+
+	req := buildCheck(server.URL)
+	req.Options.IgnoreTLSErrors = true
+	req.Options.CheckRevocation = true
+
+	c := checker.Init(req)
+	c.Perform()
+	a.Equal(t, true, c.Success)
+	a.Equal(t, types.RevocationUnknown, c.Res.Certificate.RevocationStatus)
+	a.Equal(t, []string{types.CertRevocationUnknown.ToString()}, c.Res.Warnings)
+}
@@ -0,0 +1,376 @@
+package checker
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"time"
+
+	"golang.org/x/crypto/cryptobyte"
+	cbasn1 "golang.org/x/crypto/cryptobyte/asn1"
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+const (
+	sctSourceEmbedded = "embedded"
+	sctSourceTLS      = "tls"
+	sctSourceOCSP     = "ocsp"
+
+	x509Entry    = 0
+	precertEntry = 1
+)
+
+var (
+	// OID of the SCT list certificate extension
+	oidCertSCTList = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}
+
+	// OID of the SCT list OCSP single response extension
+	oidOCSPSCTList = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 5}
+
+	errInvalidSCT        = errors.New("Invalid SCT")
+	errUnsupportedLogKey = errors.New("Unsupported CT log key")
+)
+
+// CTLog is a Certificate Transparency log trusted when verifying SCTs
+type CTLog struct {
+	ID          [32]byte
+	Description string
+	Key         crypto.PublicKey
+}
+
+// NewCTLog creates a log from its DER encoded public key
+func NewCTLog(description string, der []byte) (*CTLog, error) {
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey:
+	default:
+		return nil, errUnsupportedLogKey
+	}
+
+	return &CTLog{
+		ID:          sha256.Sum256(der),
+		Description: description,
+		Key:         key,
+	}, nil
+}
+
+// LoadCTLogs loads the logs from a file in the same JSON format as the
+// published log list, https://www.gstatic.com/ct/log_list/v3/log_list.json
+func LoadCTLogs(path string) ([]*CTLog, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	type logEntry struct {
+		Description string `json:"description"`
+		Key         string `json:"key"`
+	}
+
+	var list struct {
+		Operators []struct {
+			Logs      []logEntry `json:"logs"`
+			TiledLogs []logEntry `json:"tiled_logs"`
+		} `json:"operators"`
+	}
+
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+
+	var logs []*CTLog
+	for _, operator := range list.Operators {
+		for _, entry := range append(operator.Logs, operator.TiledLogs...) {
+			der, err := base64.StdEncoding.DecodeString(entry.Key)
+			if err != nil {
+				return nil, err
+			}
+
+			log, err := NewCTLog(entry.Description, der)
+			if err == errUnsupportedLogKey {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			logs = append(logs, log)
+		}
+	}
+
+	return logs, nil
+}
+
+type signedCertificateTimestamp struct {
+	version            uint8
+	logID              []byte
+	timestamp          uint64
+	extensions         []byte
+	hashAlgorithm      uint8
+	signatureAlgorithm uint8
+	signature          []byte
+}
+
+// extractSCTs extracts the SCTs from the certificate extension, the TLS
+// extension and the stapled OCSP response, and verifies them against the
+// configured logs
+func extractSCTs(state *tls.ConnectionState) []types.SCTInfo {
+	cert := state.PeerCertificates[0]
+	issuer := issuerFromConnectionState(state)
+
+	var res []types.SCTInfo
+
+	// Embedded SCTs are signed over the precertificate, which requires the
+	// issuer to reconstruct
+	if issuer != nil {
+		for _, ext := range cert.Extensions {
+			if !ext.Id.Equal(oidCertSCTList) {
+				continue
+			}
+
+			entry, err := precertSignedEntry(cert, issuer)
+			if err != nil {
+				break
+			}
+
+			var list []byte
+			if _, err := asn1.Unmarshal(ext.Value, &list); err == nil {
+				res = append(res, verifySCTList(list, precertEntry, entry, sctSourceEmbedded)...)
+			}
+		}
+	}
+
+	var b cryptobyte.Builder
+	b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddBytes(cert.Raw)
+	})
+	entry := b.BytesOrPanic()
+
+	for _, raw := range state.SignedCertificateTimestamps {
+		res = append(res, verifySCT(raw, x509Entry, entry, sctSourceTLS))
+	}
+
+	if len(state.OCSPResponse) > 0 && issuer != nil {
+		if resp, err := ocsp.ParseResponseForCert(state.OCSPResponse, cert, issuer); err == nil {
+			for _, ext := range resp.Extensions {
+				if !ext.Id.Equal(oidOCSPSCTList) {
+					continue
+				}
+
+				var list []byte
+				if _, err := asn1.Unmarshal(ext.Value, &list); err == nil {
+					res = append(res, verifySCTList(list, x509Entry, entry, sctSourceOCSP)...)
+				}
+			}
+		}
+	}
+
+	return res
+}
+
+// countSCTLogs returns the number of distinct logs with a verified SCT
+func countSCTLogs(scts []types.SCTInfo) int {
+	logs := map[string]bool{}
+	for _, sct := range scts {
+		if sct.Verified {
+			logs[string(sct.LogID)] = true
+		}
+	}
+
+	return len(logs)
+}
+
+func verifySCTList(list []byte, entryType uint16, entry []byte, source string) []types.SCTInfo {
+	var res []types.SCTInfo
+
+	s := cryptobyte.String(list)
+	var scts cryptobyte.String
+	if !s.ReadUint16LengthPrefixed(&scts) {
+		return nil
+	}
+
+	for !scts.Empty() {
+		var raw cryptobyte.String
+		if !scts.ReadUint16LengthPrefixed(&raw) {
+			break
+		}
+
+		res = append(res, verifySCT(raw, entryType, entry, source))
+	}
+
+	return res
+}
+
+func verifySCT(raw []byte, entryType uint16, entry []byte, source string) types.SCTInfo {
+	sct, err := parseSCT(raw)
+	if err != nil {
+		return types.SCTInfo{Source: source}
+	}
+
+	info := types.SCTInfo{
+		LogID:     sct.logID,
+		Timestamp: time.Unix(0, int64(sct.timestamp)*int64(time.Millisecond)).UTC(),
+		Source:    source,
+	}
+
+	for _, log := range agentConfig.CTLogs {
+		if string(log.ID[:]) != string(sct.logID) {
+			continue
+		}
+
+		info.Log = log.Description
+		info.Verified = sct.verify(log, entryType, entry)
+		break
+	}
+
+	return info
+}
+
+func parseSCT(raw []byte) (*signedCertificateTimestamp, error) {
+	s := cryptobyte.String(raw)
+	sct := &signedCertificateTimestamp{}
+
+	var extensions, signature cryptobyte.String
+	if !s.ReadUint8(&sct.version) ||
+		!s.ReadBytes(&sct.logID, 32) ||
+		!s.ReadUint64(&sct.timestamp) ||
+		!s.ReadUint16LengthPrefixed(&extensions) ||
+		!s.ReadUint8(&sct.hashAlgorithm) ||
+		!s.ReadUint8(&sct.signatureAlgorithm) ||
+		!s.ReadUint16LengthPrefixed(&signature) ||
+		!s.Empty() {
+		return nil, errInvalidSCT
+	}
+
+	// Only v1 is defined by RFC 6962
+	if sct.version != 0 {
+		return nil, errInvalidSCT
+	}
+
+	sct.extensions = extensions
+	sct.signature = signature
+
+	return sct, nil
+}
+
+func (sct *signedCertificateTimestamp) verify(log *CTLog, entryType uint16, entry []byte) bool {
+	// SHA-256 is the only hash algorithm allowed by RFC 6962
+	if sct.hashAlgorithm != 4 {
+		return false
+	}
+
+	var b cryptobyte.Builder
+	b.AddUint8(sct.version)
+	b.AddUint8(0) // certificate_timestamp
+	b.AddUint64(sct.timestamp)
+	b.AddUint16(entryType)
+	b.AddBytes(entry)
+	b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddBytes(sct.extensions)
+	})
+
+	digest := sha256.Sum256(b.BytesOrPanic())
+
+	switch key := log.Key.(type) {
+	case *ecdsa.PublicKey:
+		return sct.signatureAlgorithm == 3 && ecdsa.VerifyASN1(key, digest[:], sct.signature)
+
+	case *rsa.PublicKey:
+		return sct.signatureAlgorithm == 1 && rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sct.signature) == nil
+
+	default:
+		return false
+	}
+}
+
+// precertSignedEntry reconstructs the precertificate entry which embedded
+// SCTs are signed over, the issuer key hash followed by the TBSCertificate
+// with the SCT list extension removed
+func precertSignedEntry(cert, issuer *x509.Certificate) ([]byte, error) {
+	tbs, err := removeSCTListExtension(cert.RawTBSCertificate)
+	if err != nil {
+		return nil, err
+	}
+
+	issuerKeyHash := sha256.Sum256(issuer.RawSubjectPublicKeyInfo)
+
+	var b cryptobyte.Builder
+	b.AddBytes(issuerKeyHash[:])
+	b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddBytes(tbs)
+	})
+
+	return b.Bytes()
+}
+
+func removeSCTListExtension(raw []byte) ([]byte, error) {
+	input := cryptobyte.String(raw)
+
+	var tbs cryptobyte.String
+	if !input.ReadASN1(&tbs, cbasn1.SEQUENCE) {
+		return nil, errInvalidSCT
+	}
+
+	var b cryptobyte.Builder
+	b.AddASN1(cbasn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		for !tbs.Empty() {
+			var element cryptobyte.String
+			var tag cbasn1.Tag
+			if !tbs.ReadAnyASN1Element(&element, &tag) {
+				b.SetError(errInvalidSCT)
+				return
+			}
+
+			extensionsTag := cbasn1.Tag(3).Constructed().ContextSpecific()
+			if tag != extensionsTag {
+				b.AddBytes(element)
+				continue
+			}
+
+			var extensions cryptobyte.String
+			if !element.ReadASN1(&element, extensionsTag) || !element.ReadASN1(&extensions, cbasn1.SEQUENCE) {
+				b.SetError(errInvalidSCT)
+				return
+			}
+
+			b.AddASN1(extensionsTag, func(b *cryptobyte.Builder) {
+				b.AddASN1(cbasn1.SEQUENCE, func(b *cryptobyte.Builder) {
+					for !extensions.Empty() {
+						var extension, ext cryptobyte.String
+						var oid asn1.ObjectIdentifier
+						if !extensions.ReadASN1Element(&extension, cbasn1.SEQUENCE) {
+							b.SetError(errInvalidSCT)
+							return
+						}
+
+						ext = extension
+						if !ext.ReadASN1(&ext, cbasn1.SEQUENCE) || !ext.ReadASN1ObjectIdentifier(&oid) {
+							b.SetError(errInvalidSCT)
+							return
+						}
+
+						if !oid.Equal(oidCertSCTList) {
+							b.AddBytes(extension)
+						}
+					}
+				})
+			})
+		}
+	})
+
+	return b.Bytes()
+}
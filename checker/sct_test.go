@@ -0,0 +1,238 @@
+package checker_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	a "github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/cryptobyte"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+type testCTLog struct {
+	key *ecdsa.PrivateKey
+	der []byte
+}
+
+func newTestCTLog(t *testing.T) *testCTLog {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testCTLog{key: key, der: der}
+}
+
+// sign creates a SCT for the entry as described in RFC 6962
+func (l *testCTLog) sign(t *testing.T, entryType uint16, entry []byte) []byte {
+	logID := sha256.Sum256(l.der)
+	timestamp := uint64(time.Now().UnixNano() / int64(time.Millisecond))
+
+	var signed cryptobyte.Builder
+	signed.AddUint8(0)
+	signed.AddUint8(0)
+	signed.AddUint64(timestamp)
+	signed.AddUint16(entryType)
+	signed.AddBytes(entry)
+	signed.AddUint16(0)
+
+	digest := sha256.Sum256(signed.BytesOrPanic())
+	signature, err := ecdsa.SignASN1(rand.Reader, l.key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b cryptobyte.Builder
+	b.AddUint8(0)
+	b.AddBytes(logID[:])
+	b.AddUint64(timestamp)
+	b.AddUint16(0)
+	b.AddUint8(4)
+	b.AddUint8(3)
+	b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddBytes(signature)
+	})
+
+	return b.BytesOrPanic()
+}
+
+func x509SCTEntry(cert *x509.Certificate) []byte {
+	var b cryptobyte.Builder
+	b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddBytes(cert.Raw)
+	})
+
+	return b.BytesOrPanic()
+}
+
+// issueCertWithSCTs issues a certificate with SCTs from the logs embedded
+func issueCertWithSCTs(t *testing.T, ca *testCert, logs ...*testCTLog) *testCert {
+	template := certTemplate()
+	precert := issueCert(t, template, nil, ca)
+
+	issuerKeyHash := sha256.Sum256(ca.Cert.RawSubjectPublicKeyInfo)
+
+	var entry cryptobyte.Builder
+	entry.AddBytes(issuerKeyHash[:])
+	entry.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddBytes(precert.Cert.RawTBSCertificate)
+	})
+
+	var list cryptobyte.Builder
+	list.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+		for _, log := range logs {
+			sct := log.sign(t, 1, entry.BytesOrPanic())
+			b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+				b.AddBytes(sct)
+			})
+		}
+	})
+
+	value, err := asn1.Marshal(list.BytesOrPanic())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template.ExtraExtensions = []pkix.Extension{{
+		Id:    asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2},
+		Value: value,
+	}}
+
+	return issueCert(t, template, precert.Key, ca)
+}
+
+func configureCTLogs(t *testing.T, logs ...*testCTLog) {
+	var ctLogs []*checker.CTLog
+	for i, log := range logs {
+		ctLog, err := checker.NewCTLog(fmt.Sprintf("Test Log %d", i+1), log.der)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ctLogs = append(ctLogs, ctLog)
+	}
+
+	checker.Configure(checker.Config{CTLogs: ctLogs})
+	t.Cleanup(func() { checker.Configure(checker.Config{}) })
+}
+
+func TestSCTs(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+	log1, log2, unknown := newTestCTLog(t), newTestCTLog(t), newTestCTLog(t)
+	configureCTLogs(t, log1, log2)
+
+	cert := issueCertWithSCTs(t, ca, log1, unknown)
+
+	tlsCert := cert.TLSCertificate(ca)
+	tlsCert.SignedCertificateTimestamps = [][]byte{
+		log2.sign(t, 0, x509SCTEntry(cert.Cert)),
+	}
+	server := startTLSServer(t, tlsCert, nil)
+
+	tests := []struct {
+		minSCTs int
+		success bool
+		error   string
+	}{
+		{0, true, ""},
+		{2, true, ""},
+		{3, false, types.CertInsufficientSCTs.ToString()},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("min %d", tt.minSCTs), func(t *testing.T) {
+			req := buildCheck(server.URL)
+			req.Options.IgnoreTLSErrors = true
+			req.Options.CheckSCTs = true
+			req.Options.CertPolicy.MinSCTs = tt.minSCTs
+
+			c := checker.Init(req)
+			c.Perform()
+
+			a.Equal(t, tt.success, c.Success)
+			a.Equal(t, tt.error, c.Res.Error)
+
+			scts := c.Res.Certificate.SCTs
+			if a.Len(t, scts, 3) {
+				a.Equal(t, "embedded", scts[0].Source)
+				a.Equal(t, "Test Log 1", scts[0].Log)
+				a.True(t, scts[0].Verified)
+
+				a.Equal(t, "embedded", scts[1].Source)
+				a.Equal(t, "", scts[1].Log)
+				a.False(t, scts[1].Verified)
+
+				a.Equal(t, "tls", scts[2].Source)
+				a.Equal(t, "Test Log 2", scts[2].Log)
+				a.True(t, scts[2].Verified)
+			}
+		})
+	}
+}
+
+func TestSCTsInvalidSignature(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+	log := newTestCTLog(t)
+	configureCTLogs(t, log)
+
+	cert := issueCert(t, certTemplate(), nil, ca)
+	other := issueCert(t, certTemplate(), nil, ca)
+
+	// SCT for a different certificate
+	tlsCert := cert.TLSCertificate(ca)
+	tlsCert.SignedCertificateTimestamps = [][]byte{
+		log.sign(t, 0, x509SCTEntry(other.Cert)),
+	}
+	server := startTLSServer(t, tlsCert, nil)
+
+	req := buildCheck(server.URL)
+	req.Options.IgnoreTLSErrors = true
+	req.Options.CertPolicy.MinSCTs = 1
+
+	c := checker.Init(req)
+	c.Perform()
+
+	a.Equal(t, false, c.Success)
+	a.Equal(t, types.CertInsufficientSCTs.ToString(), c.Res.Error)
+	a.Equal(t, []types.SCTInfo{{
+		LogID:     sha256Sum(log.der),
+		Log:       "Test Log 1",
+		Timestamp: c.Res.Certificate.SCTs[0].Timestamp,
+		Source:    "tls",
+		Verified:  false,
+	}}, c.Res.Certificate.SCTs)
+}
+
+func TestLoadCTLogs(t *testing.T) {
+	log := newTestCTLog(t)
+
+	path := filepath.Join(t.TempDir(), "log_list.json")
+	list := fmt.Sprintf(`{"operators": [{"name": "Test", "logs": [{"description": "Test Log", "key": "%s"}]}]}`, base64.StdEncoding.EncodeToString(log.der))
+	if err := ioutil.WriteFile(path, []byte(list), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	logs, err := checker.LoadCTLogs(path)
+	if a.NoError(t, err) && a.Len(t, logs, 1) {
+		a.Equal(t, "Test Log", logs[0].Description)
+		a.Equal(t, sha256Sum(log.der), logs[0].ID[:])
+	}
+}
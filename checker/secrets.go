@@ -0,0 +1,340 @@
+package checker
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// Prefix of placeholders referencing secrets, e.g. ${secret:api-key}
+	secretPrefix = "secret:"
+
+	// Replacement for secret values in logs and results
+	redacted = "[REDACTED]"
+
+	// Secrets shorter than this are rejected, as redacting them would mangle
+	// unrelated output
+	minSecretLength = 8
+)
+
+var (
+	errInvalidSecretsKey = errors.New("Secrets key must be 32 bytes, encoded as base64")
+	errInvalidSecrets    = errors.New("Unable to decrypt secrets")
+
+	// Replaces secret values, this is updated when the agent is configured
+	redactor = strings.NewReplacer()
+)
+
+// LoadSecrets loads named secrets from a directory, each file contains one
+// secret with the file name as its name
+func LoadSecrets(dir string) (map[string]string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := map[string]string{}
+	for _, file := range files {
+		if file.IsDir() || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		// Editors usually add a trailing newline
+		secrets[file.Name()] = strings.TrimRight(string(data), "\r\n")
+	}
+
+	return secrets, nil
+}
+
+// SecretsFromEnv returns the environment variables with the prefix as named
+// secrets, with the prefix removed from the name
+func SecretsFromEnv(prefix string, environ []string) map[string]string {
+	secrets := map[string]string{}
+
+	for _, variable := range environ {
+		parts := strings.SplitN(variable, "=", 2)
+		if len(parts) != 2 || !strings.HasPrefix(parts[0], prefix) || parts[0] == prefix {
+			continue
+		}
+
+		secrets[strings.TrimPrefix(parts[0], prefix)] = parts[1]
+	}
+
+	return secrets
+}
+
+// ParseSecretsKey decodes the key used to encrypt secrets
+func ParseSecretsKey(s string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil || len(key) != 32 {
+		return nil, errInvalidSecretsKey
+	}
+
+	return key, nil
+}
+
+// EncryptSecrets encrypts the named secrets with AES-256-GCM, the result is
+// base64 encoded so it can be stored as text
+func EncryptSecrets(secrets map[string]string, key []byte) ([]byte, error) {
+	aead, err := secretsCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	sealed := aead.Seal(nonce, nonce, plaintext, nil)
+
+	return []byte(base64.StdEncoding.EncodeToString(sealed)), nil
+}
+
+// LoadEncryptedSecrets loads named secrets from a file created with
+// EncryptSecrets
+func LoadEncryptedSecrets(path string, key []byte) (map[string]string, error) {
+	aead, err := secretsCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return nil, errInvalidSecrets
+	}
+
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, errInvalidSecrets
+	}
+
+	secrets := map[string]string{}
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, errInvalidSecrets
+	}
+
+	return secrets, nil
+}
+
+func secretsCipher(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, errInvalidSecretsKey
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// lookupSecret returns the secret with the name from the agent's store
+func lookupSecret(name string) (string, bool) {
+	secret, ok := agentConfig.Secrets[name]
+	return secret, ok
+}
+
+// withSecrets extends the lookup to resolve ${secret:name} placeholders
+func withSecrets(lookup func(name string) (string, bool)) func(name string) (string, bool) {
+	return func(name string) (string, bool) {
+		if strings.HasPrefix(name, secretPrefix) {
+			return lookupSecret(strings.TrimPrefix(name, secretPrefix))
+		}
+
+		return lookup(name)
+	}
+}
+
+// expandSecrets replaces ${secret:name} placeholders with the secret, other
+// placeholders are left as they are
+func expandSecrets(s string) (string, error) {
+	return expandVariables(s, withSecrets(func(name string) (string, bool) {
+		return "${" + name + "}", true
+	}))
+}
+
+// undefinedSecret returns whether the error is from a placeholder for a
+// secret which isn't in the store
+func undefinedSecret(err error) bool {
+	var undefined *undefinedVariableError
+	return errors.As(err, &undefined) && strings.HasPrefix(undefined.name, secretPrefix)
+}
+
+// ValidateSecrets checks every secret can be redacted without mangling
+// unrelated output, so none are shorter than minSecretLength
+func ValidateSecrets(secrets map[string]string) error {
+	var names []string
+	for name := range secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, value := range redactedValues(secrets[name]) {
+			if len(value) < minSecretLength {
+				return fmt.Errorf("Secret %s is too short to be redacted, it must be at least %d bytes", name, minSecretLength)
+			}
+		}
+	}
+
+	return nil
+}
+
+// redactedValues returns the values redacted for the secret. Credentials are
+// also redacted by their password, so it isn't logged if it's used on its
+// own.
+func redactedValues(secret string) []string {
+	values := []string{secret}
+	if parts := strings.SplitN(secret, ":", 2); len(parts) == 2 {
+		values = append(values, parts[1])
+	}
+
+	return values
+}
+
+// newRedactor creates a replacer for the secret values
+func newRedactor(secrets map[string]string) *strings.Replacer {
+	values := map[string]bool{}
+	for _, secret := range secrets {
+		for _, value := range redactedValues(secret) {
+			if value != "" {
+				values[value] = true
+			}
+		}
+	}
+
+	// Longer values are replaced first, so a value containing another isn't
+	// partially redacted
+	var sorted []string
+	for value := range values {
+		sorted = append(sorted, value)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return len(sorted[i]) > len(sorted[j])
+	})
+
+	var oldnew []string
+	for _, value := range sorted {
+		oldnew = append(oldnew, value, redacted)
+	}
+
+	return strings.NewReplacer(oldnew...)
+}
+
+// Redact replaces any secret values in the string
+func Redact(s string) string {
+	return redactor.Replace(s)
+}
+
+// redactURL replaces any secret values in the URL
+func redactURL(u *url.URL) *url.URL {
+	if u == nil {
+		return nil
+	}
+
+	s := u.String()
+	if r := Redact(s); r != s {
+		if res, err := url.Parse(r); err == nil {
+			return res
+		}
+	}
+
+	return u
+}
+
+// redactResult replaces secret values which may have been echoed by the
+// server, or used in URLs
+func (c *Checker) redactResult() {
+	res := c.Res
+
+	res.URL = redactURL(res.URL)
+	res.Body = Redact(res.Body)
+
+	redactHeaders(res.Headers)
+
+	if res.Request != nil {
+		res.Request.URL = Redact(res.Request.URL)
+		res.Request.Body = Redact(res.Request.Body)
+		redactHeaders(res.Request.Headers)
+	}
+
+	for i := range res.Transaction {
+		step := &res.Transaction[i]
+		step.URL = Redact(step.URL)
+
+		for j := range step.Assertions {
+			step.Assertions[j].Value = Redact(step.Assertions[j].Value)
+		}
+	}
+
+	for i := range res.Cookies {
+		res.Cookies[i].URL = Redact(res.Cookies[i].URL)
+	}
+
+	if res.WebSocket != nil {
+		res.WebSocket.Reply = Redact(res.WebSocket.Reply)
+	}
+}
+
+func redactHeaders(headers map[string][]string) {
+	for _, values := range headers {
+		for i, value := range values {
+			values[i] = Redact(value)
+		}
+	}
+}
+
+// RedactHook is a logrus hook which redacts secret values from log entries
+type RedactHook struct{}
+
+// Levels returns the levels the hook applies to
+func (h RedactHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+// Fire redacts the message and fields of the entry
+func (h RedactHook) Fire(entry *log.Entry) error {
+	entry.Message = Redact(entry.Message)
+
+	for key, value := range entry.Data {
+		switch value := value.(type) {
+		case string:
+			entry.Data[key] = Redact(value)
+		case error:
+			entry.Data[key] = Redact(value.Error())
+		case fmt.Stringer:
+			entry.Data[key] = Redact(value.String())
+		}
+	}
+
+	return nil
+}
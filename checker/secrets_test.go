@@ -0,0 +1,188 @@
+package checker_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	a "github.com/stretchr/testify/assert"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+func TestLoadSecrets(t *testing.T) {
+	dir := t.TempDir()
+	ioutil.WriteFile(filepath.Join(dir, "api-key"), []byte("k3y\n"), 0600)
+	ioutil.WriteFile(filepath.Join(dir, ".hidden"), []byte("ignored"), 0600)
+
+	secrets, err := checker.LoadSecrets(dir)
+	a.NoError(t, err)
+	a.Equal(t, map[string]string{"api-key": "k3y"}, secrets)
+}
+
+func TestSecretsFromEnv(t *testing.T) {
+	secrets := checker.SecretsFromEnv("SECRET_", []string{"SECRET_api_key=k3y=", "SECRET_=empty", "PATH=/bin"})
+	a.Equal(t, map[string]string{"api_key": "k3y="}, secrets)
+}
+
+func TestEncryptedSecrets(t *testing.T) {
+	key, err := checker.ParseSecretsKey("MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=")
+	a.NoError(t, err)
+
+	secrets := map[string]string{"api-key": "k3y", "alice": "alice:s3cr3t"}
+	encrypted, err := checker.EncryptSecrets(secrets, key)
+	a.NoError(t, err)
+	a.NotContains(t, string(encrypted), "s3cr3t")
+
+	path := filepath.Join(t.TempDir(), "secrets")
+	ioutil.WriteFile(path, encrypted, 0600)
+
+	loaded, err := checker.LoadEncryptedSecrets(path, key)
+	a.NoError(t, err)
+	a.Equal(t, secrets, loaded)
+
+	// Wrong key
+	key[0]++
+	_, err = checker.LoadEncryptedSecrets(path, key)
+	a.Error(t, err)
+
+	_, err = checker.ParseSecretsKey("c2hvcnQ=")
+	a.Error(t, err)
+}
+
+// startEchoServer starts a server which requires the API key, and echoes it
+// in the body and headers
+func startEchoServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Query().Get("key") + r.Header.Get("X-Api-Key")
+		if cookie, err := r.Cookie("key"); err == nil {
+			key += cookie.Value
+		}
+
+		if key != "k3y-v4lu3" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		w.Header().Set("X-Echo", "key="+key)
+		w.Write([]byte("Your key is " + key))
+	}))
+	t.Cleanup(server.Close)
+
+	checker.Configure(checker.Config{Secrets: map[string]string{"api-key": "k3y-v4lu3"}})
+	t.Cleanup(func() { checker.Configure(checker.Config{}) })
+
+	return server
+}
+
+func TestSecretPlaceholders(t *testing.T) {
+	server := startEchoServer(t)
+
+	tests := []struct {
+		name   string
+		modify func(req *types.CheckRequest)
+		error  string
+	}{
+		{"url", func(req *types.CheckRequest) {
+			req.URL, _ = req.URL.Parse("/echo?key=${secret:api-key}")
+		}, ""},
+		{"cookie", func(req *types.CheckRequest) {
+			req.Options.Cookies = []types.Cookie{{Name: "key", Value: "${secret:api-key}"}}
+		}, ""},
+		{"cookie jar", func(req *types.CheckRequest) {
+			req.Options.CookieJar = true
+			req.Options.Cookies = []types.Cookie{{Name: "key", Value: "${secret:api-key}"}}
+		}, ""},
+		{"transaction", func(req *types.CheckRequest) {
+			req.Type = "transaction"
+			req.Options.Transaction.Steps = []types.TransactionStep{
+				{URL: "/echo", Headers: http.Header{"X-Api-Key": {"${secret:api-key}"}}},
+			}
+		}, ""},
+		{"undefined", func(req *types.CheckRequest) {
+			req.URL, _ = req.URL.Parse("/echo?key=${secret:missing}")
+		}, types.SecretNotFound.ToString()},
+		{"undefined transaction", func(req *types.CheckRequest) {
+			req.Type = "transaction"
+			req.Options.Transaction.Steps = []types.TransactionStep{{URL: "/echo?key=${secret:missing}"}}
+		}, types.SecretNotFound.ToString()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := buildCheck(server.URL)
+			req.Method = "GET"
+			tt.modify(req)
+
+			c := checker.Init(req)
+			c.Perform()
+
+			a.Equal(t, tt.error, c.Res.Error)
+
+			// Placeholders aren't replaced in the request
+			a.NotContains(t, req.URL.String(), "k3y")
+			for _, cookie := range req.Options.Cookies {
+				a.Equal(t, "${secret:api-key}", cookie.Value)
+			}
+
+			if tt.error == "" {
+				a.Equal(t, "Your key is [REDACTED]", c.Res.Body)
+				a.Equal(t, []string{"key=[REDACTED]"}, c.Res.Headers["X-Echo"])
+				a.NotContains(t, c.Res.URL.String(), "k3y")
+			}
+		})
+	}
+}
+
+func TestRedact(t *testing.T) {
+	checker.Configure(checker.Config{Secrets: map[string]string{
+		"api-key": "k3y-v4lu3",
+		"alice":   "alice:s3cr3t-pw",
+		"long":    "k3y-v4lu3-l0ng",
+	}})
+	t.Cleanup(func() { checker.Configure(checker.Config{}) })
+
+	a.Equal(t, "[REDACTED] and [REDACTED], or [REDACTED] on its own", checker.Redact("k3y-v4lu3 and k3y-v4lu3-l0ng, or s3cr3t-pw on its own"))
+	a.Equal(t, "user [REDACTED]", checker.Redact("user alice:s3cr3t-pw"))
+
+	entry := log.NewEntry(log.StandardLogger()).WithFields(log.Fields{
+		"Url": "https://example.com/?key=k3y-v4lu3",
+		"Err": errors.New("invalid password s3cr3t-pw"),
+		"Ref": 1,
+	})
+	entry.Message = "< key:k3y-v4lu3"
+
+	a.NoError(t, checker.RedactHook{}.Fire(entry))
+	a.Equal(t, "< key:[REDACTED]", entry.Message)
+	a.Equal(t, "https://example.com/?key=[REDACTED]", entry.Data["Url"])
+	a.Equal(t, "invalid password [REDACTED]", entry.Data["Err"])
+	a.Equal(t, 1, entry.Data["Ref"])
+}
+
+func TestValidateSecrets(t *testing.T) {
+	a.NoError(t, checker.ValidateSecrets(map[string]string{
+		"api-key": "k3y-v4lu3",
+		"alice":   "alice:s3cr3t-pw",
+	}))
+
+	// Short values would mangle unrelated output when they're redacted
+	a.EqualError(t, checker.ValidateSecrets(map[string]string{"pin": "1"}), "Secret pin is too short to be redacted, it must be at least 8 bytes")
+
+	// The password of credentials is also redacted on its own
+	a.EqualError(t, checker.ValidateSecrets(map[string]string{"bob": "bob:p4ssw0rd", "alice": "alice:pw"}), "Secret alice is too short to be redacted, it must be at least 8 bytes")
+}
+
+func TestRedactShortSecrets(t *testing.T) {
+	checker.Configure(checker.Config{Secrets: map[string]string{
+		"alice": "alice:pw",
+	}})
+	t.Cleanup(func() { checker.Configure(checker.Config{}) })
+
+	// Secrets which weren't validated are still redacted
+	a.Equal(t, "[REDACTED] [REDACTED]", checker.Redact("alice:pw pw"))
+}
@@ -0,0 +1,156 @@
+package checker
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"regexp"
+	"time"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+const (
+	// Maximum amount of data read when matching the banner
+	maxBannerSize = 64 * 1024
+)
+
+// performTCPCheck connects to the port, optionally sending a payload and
+// checking the response
+func (c *Checker) performTCPCheck() bool {
+	opts := c.Req.Options.TCPCheck
+
+	if c.Req.URL.Port() == "" {
+		return c.handleFailure("Missing port", types.TCPMissingPort.ToString())
+	}
+
+	var banner *regexp.Regexp
+	if opts.ExpectBanner != "" {
+		var err error
+		if banner, err = regexp.Compile(opts.ExpectBanner); err != nil {
+			return c.handleFailure("Invalid banner pattern", types.TCPInvalidPattern.ToString())
+		}
+	}
+
+	if err := ValidateResolver(c.resolver()); err != nil {
+		return c.handleFailure("Invalid resolver configuration", types.ResolverInvalid.ToString())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout())
+	defer cancel()
+
+	// Instrument the connection, and extract timings at various points
+	ctx, trace := withConnTrace(ctx)
+	var sendStart, sendDone, firstByte time.Time
+
+	conn, err := c.dialContext(ctx, "tcp", c.Req.URL.Host)
+	if err != nil {
+		return c.handleError("Error connecting", err)
+	}
+	defer conn.Close()
+
+	deadline, _ := ctx.Deadline()
+	conn.SetDeadline(deadline)
+
+	// Upgrade to TLS
+	var state *tls.ConnectionState
+	if opts.TLS {
+		config, configErr := c.tlsConfig()
+		if configErr != "" {
+			return c.handleFailure("Invalid TLS configuration", configErr.ToString())
+		}
+		config.ServerName = c.Req.URL.Hostname()
+
+		tlsConn, err := trace.handshake(conn, config)
+		if err != nil {
+			return c.handleError("Error performing TLS handshake", err)
+		}
+
+		s := tlsConn.ConnectionState()
+		state = &s
+		conn = tlsConn
+
+		c.Res.Certificate = certInfoFromTLSConnectionState(state)
+		c.Res.Proto = tls.VersionName(state.Version)
+	}
+
+	sendStart = time.Now()
+	if len(opts.Payload) > 0 {
+		if _, err := conn.Write(opts.Payload); err != nil {
+			return c.handleError("Error sending payload", err)
+		}
+	}
+	sendDone = time.Now()
+
+	// Read until the banner matches
+	if banner != nil {
+		received, first, err := readBanner(conn, banner)
+		firstByte = first
+		c.Res.Body = string(received)
+
+		if !banner.Match(received) {
+			if len(received) == 0 && err != nil {
+				return c.handleError("Error reading banner", err)
+			}
+
+			return c.handleFailure("Banner doesn't match", types.TCPBannerMismatch.ToString())
+		}
+	}
+
+	done := time.Now()
+	if firstByte.IsZero() {
+		firstByte = sendDone
+	}
+
+	sending := sendDone.Sub(sendStart).Truncate(time.Millisecond)
+	waiting := firstByte.Sub(sendDone).Truncate(time.Millisecond)
+	receiving := done.Sub(firstByte).Truncate(time.Millisecond)
+
+	c.Res.Timing = trace.timing()
+	c.Res.Timing.Sending = &sending
+	c.Res.Timing.Waiting = &waiting
+	c.Res.Timing.Receiving = &receiving
+
+	// Perform optional checks on the certificate
+	if state != nil {
+		if err := c.checkCertificate(state); err != "" {
+			return c.handleFailure("Certificate check failed", err.ToString())
+		}
+	}
+
+	return c.handleSuccessSince(trace.start())
+}
+
+// readBanner reads from the connection until the data matches, the
+// connection is closed, or the deadline is reached. It returns the data
+// received and the time the first byte was received.
+func readBanner(conn net.Conn, banner *regexp.Regexp) ([]byte, time.Time, error) {
+	var received []byte
+	var firstByte time.Time
+
+	buf := make([]byte, 4096)
+	for len(received) < maxBannerSize {
+		n, err := conn.Read(buf)
+		if n > 0 {
+			if firstByte.IsZero() {
+				firstByte = time.Now()
+			}
+
+			received = append(received, buf[:n]...)
+			if banner.Match(received) {
+				return received, firstByte, nil
+			}
+		}
+
+		if err == io.EOF {
+			return received, firstByte, nil
+		}
+
+		if err != nil {
+			return received, firstByte, err
+		}
+	}
+
+	return received, firstByte, nil
+}
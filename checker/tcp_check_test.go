@@ -0,0 +1,191 @@
+package checker_test
+
+import (
+	"bufio"
+	"crypto/tls"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	a "github.com/stretchr/testify/assert"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// startTCPServer starts a server which handles each connection, returning
+// its address
+func startTCPServer(t *testing.T, config *tls.Config, handler func(net.Conn)) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config != nil {
+		listener = tls.NewListener(listener, config)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+				handler(conn)
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+// pingHandler replies to PING with PONG, like Redis
+func pingHandler(conn net.Conn) {
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err == nil && line == "PING\r\n" {
+		conn.Write([]byte("+PONG\r\n"))
+	}
+}
+
+func TestTCPCheck(t *testing.T) {
+	greeting := startTCPServer(t, nil, func(conn net.Conn) {
+		conn.Write([]byte("SSH-2.0-OpenSSH_9.6\r\n"))
+	})
+	ping := startTCPServer(t, nil, pingHandler)
+	silent := startTCPServer(t, nil, func(conn net.Conn) {
+		time.Sleep(time.Second)
+	})
+
+	// Nothing listening
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed := listener.Addr().String()
+	listener.Close()
+
+	tests := []struct {
+		name  string
+		url   string
+		check types.TCPCheck
+		error string
+		body  string
+	}{
+		{"connect", "tcp://" + greeting, types.TCPCheck{}, "", ""},
+		{"banner", "tcp://" + greeting, types.TCPCheck{ExpectBanner: `^SSH-2\.0-`}, "", "SSH-2.0-OpenSSH_9.6\r\n"},
+		{"banner mismatch", "tcp://" + greeting, types.TCPCheck{ExpectBanner: `^220 `}, types.TCPBannerMismatch.ToString(), "SSH-2.0-OpenSSH_9.6\r\n"},
+		{"payload", "tcp://" + ping, types.TCPCheck{Payload: []byte("PING\r\n"), ExpectBanner: `\+PONG`}, "", "+PONG\r\n"},
+		{"payload mismatch", "tcp://" + ping, types.TCPCheck{Payload: []byte("QUIT\r\n"), ExpectBanner: `\+PONG`}, types.TCPBannerMismatch.ToString(), ""},
+		{"no banner", "tcp://" + silent, types.TCPCheck{ExpectBanner: `.`}, types.Timeout.ToString(), ""},
+		{"connection refused", "tcp://" + closed, types.TCPCheck{}, types.ConnectionRefused.ToString(), ""},
+		{"missing port", "tcp://127.0.0.1", types.TCPCheck{}, types.TCPMissingPort.ToString(), ""},
+		{"invalid pattern", "tcp://" + greeting, types.TCPCheck{ExpectBanner: `(`}, types.TCPInvalidPattern.ToString(), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := buildCheck(tt.url)
+			req.Timeout = 500 * time.Millisecond
+			req.Options.TCPCheck = tt.check
+
+			c := checker.Init(req)
+			c.Perform()
+
+			a.Equal(t, tt.error, c.Res.Error)
+			a.Equal(t, tt.body, c.Res.Body)
+
+			if tt.error == "" {
+				a.NotNil(t, c.Res.Timing.Connecting)
+				a.Equal(t, types.IPFamilyIPv4, c.Res.Family)
+			}
+		})
+	}
+}
+
+func TestTCPCheckWithoutTimeout(t *testing.T) {
+	req := buildCheck("tcp://" + startTCPServer(t, nil, pingHandler))
+	req.Timeout = 0
+	req.Options.TCPCheck = types.TCPCheck{Payload: []byte("PING\r\n"), ExpectBanner: `\+PONG`}
+
+	c := checker.Init(req)
+	c.Perform()
+
+	a.Equal(t, "", c.Res.Error)
+}
+
+func TestTCPCheckTLS(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+	cert := issueCert(t, certTemplate(), nil, ca)
+	caPEM, _ := ca.PEM(t)
+
+	addr := startTCPServer(t, &tls.Config{Certificates: []tls.Certificate{cert.TLSCertificate(ca)}}, pingHandler)
+
+	check := func(trusted bool) *checker.Checker {
+		req := buildCheck("tcp://" + addr)
+		req.Options.TCPCheck = types.TCPCheck{
+			Payload:      []byte("PING\r\n"),
+			ExpectBanner: `\+PONG`,
+			TLS:          true,
+		}
+		if trusted {
+			req.Options.TrustedCAs = types.TrustedCAs{PEM: caPEM}
+		}
+
+		c := checker.Init(req)
+		c.Perform()
+
+		return c
+	}
+
+	c := check(true)
+	a.Equal(t, "", c.Res.Error)
+	a.Equal(t, "+PONG\r\n", c.Res.Body)
+	a.Equal(t, "TLS 1.3", c.Res.Proto)
+	a.Equal(t, cert.Cert.SerialNumber.Bytes(), c.Res.Certificate.Serial)
+	a.NotNil(t, c.Res.Timing.TLS)
+
+	c = check(false)
+	a.Equal(t, types.CertUntrustedAuthority.ToString(), c.Res.Error)
+	a.NotNil(t, c.Res.Certificate)
+}
+
+func TestTCPCheckTimeExcludesDNS(t *testing.T) {
+	addr := startTCPServer(t, nil, func(conn net.Conn) {})
+	_, port, _ := net.SplitHostPort(addr)
+
+	// Slow DNS server, so the lookup would dominate the total if it was
+	// included
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	started := make(chan struct{})
+	server := &dns.Server{
+		PacketConn: conn,
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+			time.Sleep(100 * time.Millisecond)
+			testDNSHandler.ServeDNS(w, req)
+		}),
+		NotifyStartedFunc: func() { close(started) },
+	}
+	go server.ActivateAndServe()
+	defer server.Shutdown()
+	<-started
+
+	req := buildCheck("tcp://www.example.test:" + port)
+	req.Options.Resolver = types.Resolver{Nameservers: []string{conn.LocalAddr().String()}}
+
+	c := checker.Init(req)
+	c.Perform()
+
+	a.Equal(t, true, c.Success)
+	a.GreaterOrEqual(t, *c.Res.Timing.DNS, 100*time.Millisecond)
+	a.Less(t, *c.Res.Time, 100*time.Millisecond)
+}
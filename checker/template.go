@@ -0,0 +1,255 @@
+package checker
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+var (
+	// Named formats for ${date:format}, other formats are Go layouts
+	dateFormats = map[string]string{
+		"rfc3339":  time.RFC3339,
+		"rfc1123":  http.TimeFormat,
+		"http":     http.TimeFormat,
+		"iso8601":  "20060102T150405Z",
+		"yyyymmdd": "20060102",
+	}
+
+	hmacHashes = map[string]func() hash.Hash{
+		"sha1":   sha1.New,
+		"sha256": sha256.New,
+		"sha512": sha512.New,
+	}
+)
+
+// invalidTemplateError is returned for unknown template functions or invalid
+// arguments
+type invalidTemplateError struct {
+	name string
+}
+
+func (e *invalidTemplateError) Error() string {
+	return fmt.Sprintf("Invalid template value %s", e.name)
+}
+
+// requestTemplate generates dynamic values for a request. The time is the
+// same for every value, so signatures and the values they sign match.
+type requestTemplate struct {
+	now time.Time
+
+	// Rendered body, for HMACs
+	body string
+
+	// First invalid value, as lookups can only return whether the value was
+	// found
+	err error
+}
+
+func newRequestTemplate() *requestTemplate {
+	return &requestTemplate{now: time.Now()}
+}
+
+// render replaces template values and secrets in the string
+func (t *requestTemplate) render(s string) (string, error) {
+	return t.renderWith(s, noVariables)
+}
+
+// noVariables is the lookup for requests without variables
+func noVariables(name string) (string, bool) {
+	return "", false
+}
+
+// renderWith replaces template values and secrets, and any other variables
+// found by the lookup
+func (t *requestTemplate) renderWith(s string, lookup func(name string) (string, bool)) (string, error) {
+	return t.expand(s, lookup, expandVariables)
+}
+
+// renderURLWith renders a URL like renderWith, with every value escaped for
+// where it is in the URL
+func (t *requestTemplate) renderURLWith(s string, lookup func(name string) (string, bool)) (string, error) {
+	return t.expand(s, lookup, expandURLVariables)
+}
+
+// expand replaces template values, secrets and variables with the expansion
+// function
+func (t *requestTemplate) expand(s string, lookup func(name string) (string, bool), expand func(string, func(string) (string, bool)) (string, error)) (string, error) {
+	t.err = nil
+
+	res, err := expand(s, withSecrets(func(name string) (string, bool) {
+		if value, ok := lookup(name); ok {
+			return value, true
+		}
+
+		return t.lookup(name)
+	}))
+
+	if t.err != nil {
+		return "", t.err
+	}
+
+	return res, err
+}
+
+// renderURL renders the URL, braces in the path are escaped when the URL is
+// parsed so these are unescaped first
+func (t *requestTemplate) renderURL(u *url.URL) (*url.URL, error) {
+	s := u.String()
+	if !strings.Contains(s, "$") {
+		return u, nil
+	}
+
+	s, err := t.render(strings.NewReplacer("%7B", "{", "%7D", "}").Replace(s))
+	if err != nil {
+		return nil, err
+	}
+
+	return url.Parse(s)
+}
+
+// renderBody renders the body, which must be rendered first so HMACs in the
+// URL and headers can sign it
+func (t *requestTemplate) renderBody(s string, lookup func(name string) (string, bool)) (string, error) {
+	body, err := t.renderWith(s, lookup)
+	if err != nil {
+		return "", err
+	}
+
+	t.body = body
+
+	return body, nil
+}
+
+// lookup returns the value for the template function
+func (t *requestTemplate) lookup(name string) (string, bool) {
+	parts := strings.Split(name, ":")
+
+	switch parts[0] {
+	case "timestamp":
+		return strconv.FormatInt(t.now.Unix(), 10), true
+
+	case "timestamp_ms":
+		return strconv.FormatInt(t.now.UnixNano()/int64(time.Millisecond), 10), true
+
+	case "nonce":
+		return hex.EncodeToString(randomBytes(16)), true
+
+	case "uuid":
+		return newUUID(), true
+
+	case "date":
+		if len(parts) < 2 {
+			break
+		}
+
+		// Go layouts may contain colons, e.g. 15:04:05
+		layout := strings.Join(parts[1:], ":")
+		if named, ok := dateFormats[strings.ToLower(layout)]; ok {
+			layout = named
+		}
+
+		return t.now.UTC().Format(layout), true
+
+	case "hmac":
+		if len(parts) < 3 || len(parts) > 4 {
+			break
+		}
+
+		newHash, ok := hmacHashes[strings.ToLower(parts[1])]
+		if !ok {
+			break
+		}
+
+		key, ok := lookupSecret(parts[2])
+		if !ok {
+			// Reported the same as a placeholder for the secret
+			return "", false
+		}
+
+		mac := hmac.New(newHash, []byte(key))
+		mac.Write([]byte(t.body))
+		sum := mac.Sum(nil)
+
+		if len(parts) == 4 {
+			if parts[3] != "base64" {
+				break
+			}
+
+			return base64.StdEncoding.EncodeToString(sum), true
+		}
+
+		return hex.EncodeToString(sum), true
+
+	default:
+		return "", false
+	}
+
+	if t.err == nil {
+		t.err = &invalidTemplateError{name: name}
+	}
+
+	return "", true
+}
+
+// templateErrorCode returns the error for a value which couldn't be
+// rendered, undefined is used for names which aren't secrets or functions
+func templateErrorCode(err error, undefined types.CheckError) types.CheckError {
+	if undefinedSecret(err) || strings.HasPrefix(undefinedName(err), "hmac:") {
+		return types.SecretNotFound
+	}
+
+	if _, ok := err.(*invalidTemplateError); ok {
+		return types.TemplateInvalid
+	}
+
+	return undefined
+}
+
+// undefinedName returns the name of the undefined variable, if the error is
+// for one
+func undefinedName(err error) string {
+	if undefined, ok := err.(*undefinedVariableError); ok {
+		return undefined.name
+	}
+
+	return ""
+}
+
+// newUUID returns a random (version 4) UUID
+func newUUID() string {
+	b := randomBytes(16)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	rand.Read(b)
+	return b
+}
+
+// recordRequest records the rendered request in the result
+func (c *Checker) recordRequest(req *http.Request, body string) {
+	c.Res.Request = &types.RenderedRequest{
+		Method:  req.Method,
+		URL:     req.URL.String(),
+		Headers: req.Header.Clone(),
+		Body:    body,
+	}
+}
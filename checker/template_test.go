@@ -0,0 +1,188 @@
+package checker_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	a "github.com/stretchr/testify/assert"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+var (
+	uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+)
+
+// startSignedServer starts a server which requires the body to be signed,
+// returning the requests received
+func startSignedServer(t *testing.T) (*httptest.Server, func() []*http.Request) {
+	var mu sync.Mutex
+	var requests []*http.Request
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		mu.Lock()
+		requests = append(requests, r)
+		mu.Unlock()
+
+		mac := hmac.New(sha256.New, []byte("s1gn1ng-k3y"))
+		mac.Write(body)
+		if r.Header.Get("X-Signature") != hex.EncodeToString(mac.Sum(nil)) {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	checker.Configure(checker.Config{Secrets: map[string]string{"signing-key": "s1gn1ng-k3y", "api-key": "k3y-v4lu3"}})
+	t.Cleanup(func() { checker.Configure(checker.Config{}) })
+
+	return server, func() []*http.Request {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+}
+
+func TestRequestTemplate(t *testing.T) {
+	server, requests := startSignedServer(t)
+
+	headers := http.Header{
+		"X-Signature": {"${hmac:sha256:signing-key}"},
+		"X-Date":      {"${date:rfc1123}"},
+		"X-Request":   {"${uuid}"},
+		"X-Api-Key":   {"${secret:api-key}"},
+	}
+	start := time.Now()
+
+	req := buildCheck(server.URL + "/?t=${timestamp}&n=${nonce}")
+	req.Method = "POST"
+	req.Headers = headers
+	req.Body = `{"ts": ${timestamp_ms}}`
+
+	c := checker.Init(req)
+	c.Perform()
+	a.Equal(t, "", c.Res.Error)
+
+	received := requests()[0]
+
+	timestamp, err := strconv.ParseInt(received.URL.Query().Get("t"), 10, 64)
+	a.NoError(t, err)
+	a.InDelta(t, start.Unix(), timestamp, 1)
+	a.Len(t, received.URL.Query().Get("n"), 32)
+
+	date, err := http.ParseTime(received.Header.Get("X-Date"))
+	a.NoError(t, err)
+	a.WithinDuration(t, start, date, 2*time.Second)
+	a.Regexp(t, uuidPattern, received.Header.Get("X-Request"))
+	a.Equal(t, "k3y-v4lu3", received.Header.Get("X-Api-Key"))
+
+	// The rendered request is recorded, with secrets redacted
+	if a.NotNil(t, c.Res.Request) {
+		a.Equal(t, "POST", c.Res.Request.Method)
+		a.Equal(t, server.URL+received.URL.RequestURI(), c.Res.Request.URL)
+		a.Equal(t, received.Header.Get("X-Signature"), c.Res.Request.Headers.Get("X-Signature"))
+		a.Equal(t, "[REDACTED]", c.Res.Request.Headers.Get("X-Api-Key"))
+		a.Regexp(t, `^\{"ts": \d{13}\}$`, c.Res.Request.Body)
+	}
+
+	// Values are generated for each execution
+	req = buildCheck(server.URL + "/?t=${timestamp}&n=${nonce}")
+	req.Method = "POST"
+	req.Headers = headers
+	req.Body = `{"ts": ${timestamp_ms}}`
+
+	c = checker.Init(req)
+	c.Perform()
+	a.Equal(t, "", c.Res.Error)
+	a.NotEqual(t, received.URL.Query().Get("n"), requests()[1].URL.Query().Get("n"))
+	a.NotEqual(t, received.Header.Get("X-Request"), requests()[1].Header.Get("X-Request"))
+}
+
+func TestRequestTemplateErrors(t *testing.T) {
+	server, _ := startSignedServer(t)
+
+	tests := []struct {
+		name  string
+		value string
+		error string
+	}{
+		{"unknown", "${unknown}", types.TemplateInvalid.ToString()},
+		{"date without format", "${date}", types.TemplateInvalid.ToString()},
+		{"unsupported hash", "${hmac:md5:signing-key}", types.TemplateInvalid.ToString()},
+		{"invalid encoding", "${hmac:sha256:signing-key:base32}", types.TemplateInvalid.ToString()},
+		{"hmac missing secret", "${hmac:sha256:missing}", types.SecretNotFound.ToString()},
+		{"missing secret", "${secret:missing}", types.SecretNotFound.ToString()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := buildCheck(server.URL)
+			req.Method = "POST"
+			req.Headers = http.Header{"X-Signature": {tt.value}}
+
+			c := checker.Init(req)
+			c.Perform()
+			a.Equal(t, tt.error, c.Res.Error)
+
+			req = buildCheck(server.URL + "/" + tt.value)
+			req.Method = "POST"
+
+			c = checker.Init(req)
+			c.Perform()
+			a.Equal(t, tt.error, c.Res.Error)
+		})
+	}
+}
+
+func TestTransactionTemplate(t *testing.T) {
+	server, requests := startSignedServer(t)
+
+	req := buildCheck(server.URL)
+	req.Type = "transaction"
+	req.Options.Transaction = types.Transaction{Steps: []types.TransactionStep{
+		{
+			Method:  "POST",
+			URL:     "/?id=${uuid}",
+			Headers: http.Header{"X-Signature": {"${hmac:sha256:signing-key:base64}"}},
+			Body:    "data",
+		},
+	}}
+
+	c := checker.Init(req)
+	c.Perform()
+
+	// Signed with the wrong encoding
+	a.Equal(t, "403", c.Res.Error)
+	a.Regexp(t, uuidPattern, requests()[0].URL.Query().Get("id"))
+
+	req = buildCheck(server.URL)
+	req.Type = "transaction"
+	req.Options.Transaction = types.Transaction{Steps: []types.TransactionStep{
+		{Method: "POST", URL: "/?id=${unknown}"},
+	}}
+
+	c = checker.Init(req)
+	c.Perform()
+	a.Equal(t, types.TransactionUndefinedVariable.ToString(), c.Res.Error)
+
+	req = buildCheck(server.URL)
+	req.Type = "transaction"
+	req.Options.Transaction = types.Transaction{Steps: []types.TransactionStep{
+		{Method: "POST", URL: "/?id=${date}"},
+	}}
+
+	c = checker.Init(req)
+	c.Perform()
+	a.Equal(t, types.TemplateInvalid.ToString(), c.Res.Error)
+}
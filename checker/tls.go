@@ -0,0 +1,94 @@
+package checker
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http/httptrace"
+	"net/url"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// tlsConfig returns the TLS configuration used to connect to the server
+func (c *Checker) tlsConfig() (*tls.Config, types.CheckError) {
+	config := &tls.Config{}
+
+	// Ignore TLS errors
+	if c.Req.Options.IgnoreTLSErrors {
+		config.InsecureSkipVerify = true
+	}
+
+	// Trust additional certificate authorities
+	if err := c.configureCAs(config, &c.verifiedChains); err != "" {
+		return nil, err
+	}
+
+	// Present a client certificate if the server requests one, recording the
+	// request so a rejection can be identified
+	cert, err := c.clientCertificate()
+	if err != "" {
+		return nil, err
+	}
+
+	config.GetClientCertificate = func(_ *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		c.clientCertRequested = true
+
+		if cert == nil {
+			return &tls.Certificate{}, nil
+		}
+
+		return cert, nil
+	}
+
+	return config, ""
+}
+
+// dialTLS connects to the server and performs a TLS handshake, both bounded
+// by the context
+func (c *Checker) dialTLS(ctx context.Context, addr string, config *tls.Config) (*tls.Conn, error) {
+	conn, err := c.dialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send SNI for the hostname being checked
+	if config.ServerName == "" {
+		config = config.Clone()
+		config.ServerName = c.Req.URL.Hostname()
+	}
+
+	tlsConn := tls.Client(conn, config)
+	if deadline, ok := ctx.Deadline(); ok {
+		tlsConn.SetDeadline(deadline)
+	}
+
+	trace := httptrace.ContextClientTrace(ctx)
+	if trace != nil && trace.TLSHandshakeStart != nil {
+		trace.TLSHandshakeStart()
+	}
+
+	err = tlsConn.HandshakeContext(ctx)
+
+	if trace != nil && trace.TLSHandshakeDone != nil {
+		trace.TLSHandshakeDone(tlsConn.ConnectionState(), err)
+	}
+
+	if err != nil {
+		tlsConn.Close()
+		return nil, err
+	}
+
+	return tlsConn, nil
+}
+
+// hostPort returns the host and port of the URL, using the default port if
+// the URL doesn't have one
+func hostPort(u *url.URL, defaultPort string) string {
+	port := u.Port()
+	if port == "" {
+		port = defaultPort
+	}
+
+	return net.JoinHostPort(u.Hostname(), port)
+}
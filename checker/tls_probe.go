@@ -0,0 +1,414 @@
+package checker
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"sort"
+
+	"golang.org/x/crypto/cryptobyte"
+)
+
+const (
+	recordTypeAlert     = 21
+	recordTypeHandshake = 22
+
+	handshakeTypeClientHello = 1
+	handshakeTypeServerHello = 2
+
+	extensionServerName          = 0x0000
+	extensionSupportedGroups     = 0x000a
+	extensionECPointFormats      = 0x000b
+	extensionSignatureAlgorithms = 0x000d
+	extensionSupportedVersions   = 0x002b
+	extensionKeyShare            = 0x0033
+	extensionRenegotiationInfo   = 0xff01
+
+	// Maximum size of a record, including the expansion allowed for
+	// compressed and encrypted records
+	maxRecordSize = 16384 + 2048
+)
+
+var (
+	errNoServerHello = errors.New("Server didn't send a ServerHello")
+
+	// Groups offered in probes, x25519 is also used for the TLS 1.3 key share
+	probeGroups = []uint16{
+		uint16(tls.X25519),
+		uint16(tls.CurveP256),
+		uint16(tls.CurveP384),
+		uint16(tls.CurveP521),
+	}
+
+	// Signature algorithms offered in probes, including the DSA and SHA-1
+	// algorithms old servers may need
+	probeSignatureAlgorithms = []uint16{
+		0x0403, 0x0503, 0x0603, // ECDSA
+		0x0804, 0x0805, 0x0806, // RSA-PSS
+		0x0401, 0x0501, 0x0601, // RSA PKCS #1 v1.5
+		0x0203, 0x0201, 0x0202, 0x0402, // SHA-1 and DSA
+	}
+
+	// Names of cipher suites crypto/tls doesn't implement, from the IANA
+	// registry. Other suites are named by crypto/tls, or by their ID.
+	legacyCipherSuiteNames = map[uint16]string{
+		0x0001: "TLS_RSA_WITH_NULL_MD5",
+		0x0002: "TLS_RSA_WITH_NULL_SHA",
+		0x0003: "TLS_RSA_EXPORT_WITH_RC4_40_MD5",
+		0x0004: "TLS_RSA_WITH_RC4_128_MD5",
+		0x0006: "TLS_RSA_EXPORT_WITH_RC2_CBC_40_MD5",
+		0x0007: "TLS_RSA_WITH_IDEA_CBC_SHA",
+		0x0008: "TLS_RSA_EXPORT_WITH_DES40_CBC_SHA",
+		0x0009: "TLS_RSA_WITH_DES_CBC_SHA",
+		0x0011: "TLS_DHE_DSS_EXPORT_WITH_DES40_CBC_SHA",
+		0x0012: "TLS_DHE_DSS_WITH_DES_CBC_SHA",
+		0x0013: "TLS_DHE_DSS_WITH_3DES_EDE_CBC_SHA",
+		0x0014: "TLS_DHE_RSA_EXPORT_WITH_DES40_CBC_SHA",
+		0x0015: "TLS_DHE_RSA_WITH_DES_CBC_SHA",
+		0x0016: "TLS_DHE_RSA_WITH_3DES_EDE_CBC_SHA",
+		0x0017: "TLS_DH_anon_EXPORT_WITH_RC4_40_MD5",
+		0x0018: "TLS_DH_anon_WITH_RC4_128_MD5",
+		0x0019: "TLS_DH_anon_EXPORT_WITH_DES40_CBC_SHA",
+		0x001A: "TLS_DH_anon_WITH_DES_CBC_SHA",
+		0x001B: "TLS_DH_anon_WITH_3DES_EDE_CBC_SHA",
+		0x0032: "TLS_DHE_DSS_WITH_AES_128_CBC_SHA",
+		0x0033: "TLS_DHE_RSA_WITH_AES_128_CBC_SHA",
+		0x0034: "TLS_DH_anon_WITH_AES_128_CBC_SHA",
+		0x0038: "TLS_DHE_DSS_WITH_AES_256_CBC_SHA",
+		0x0039: "TLS_DHE_RSA_WITH_AES_256_CBC_SHA",
+		0x003A: "TLS_DH_anon_WITH_AES_256_CBC_SHA",
+		0x003B: "TLS_RSA_WITH_NULL_SHA256",
+		0x003D: "TLS_RSA_WITH_AES_256_CBC_SHA256",
+		0x0040: "TLS_DHE_DSS_WITH_AES_128_CBC_SHA256",
+		0x0041: "TLS_RSA_WITH_CAMELLIA_128_CBC_SHA",
+		0x0044: "TLS_DHE_DSS_WITH_CAMELLIA_128_CBC_SHA",
+		0x0045: "TLS_DHE_RSA_WITH_CAMELLIA_128_CBC_SHA",
+		0x0067: "TLS_DHE_RSA_WITH_AES_128_CBC_SHA256",
+		0x006A: "TLS_DHE_DSS_WITH_AES_256_CBC_SHA256",
+		0x006B: "TLS_DHE_RSA_WITH_AES_256_CBC_SHA256",
+		0x0084: "TLS_RSA_WITH_CAMELLIA_256_CBC_SHA",
+		0x0087: "TLS_DHE_DSS_WITH_CAMELLIA_256_CBC_SHA",
+		0x0088: "TLS_DHE_RSA_WITH_CAMELLIA_256_CBC_SHA",
+		0x0096: "TLS_RSA_WITH_SEED_CBC_SHA",
+		0x009E: "TLS_DHE_RSA_WITH_AES_128_GCM_SHA256",
+		0x009F: "TLS_DHE_RSA_WITH_AES_256_GCM_SHA384",
+		0x00A2: "TLS_DHE_DSS_WITH_AES_128_GCM_SHA256",
+		0x00A3: "TLS_DHE_DSS_WITH_AES_256_GCM_SHA384",
+		0xC006: "TLS_ECDHE_ECDSA_WITH_NULL_SHA",
+		0xC008: "TLS_ECDHE_ECDSA_WITH_3DES_EDE_CBC_SHA",
+		0xC010: "TLS_ECDHE_RSA_WITH_NULL_SHA",
+		0xC024: "TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA384",
+		0xC028: "TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA384",
+		0xC072: "TLS_ECDHE_ECDSA_WITH_CAMELLIA_128_CBC_SHA256",
+		0xC073: "TLS_ECDHE_ECDSA_WITH_CAMELLIA_256_CBC_SHA384",
+		0xC076: "TLS_ECDHE_RSA_WITH_CAMELLIA_128_CBC_SHA256",
+		0xC077: "TLS_ECDHE_RSA_WITH_CAMELLIA_256_CBC_SHA384",
+		0xC09C: "TLS_RSA_WITH_AES_128_CCM",
+		0xC09D: "TLS_RSA_WITH_AES_256_CCM",
+		0xC09E: "TLS_DHE_RSA_WITH_AES_128_CCM",
+		0xC09F: "TLS_DHE_RSA_WITH_AES_256_CCM",
+		0xC0AC: "TLS_ECDHE_ECDSA_WITH_AES_128_CCM",
+		0xC0AD: "TLS_ECDHE_ECDSA_WITH_AES_256_CCM",
+		0xCCAA: "TLS_DHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+		0x1304: "TLS_AES_128_CCM_SHA256",
+		0x1305: "TLS_AES_128_CCM_8_SHA256",
+	}
+)
+
+// cipherSuiteName returns the IANA name of the cipher suite, or its ID if the
+// name isn't known
+func cipherSuiteName(id uint16) string {
+	if name, ok := legacyCipherSuiteNames[id]; ok {
+		return name
+	}
+
+	return tls.CipherSuiteName(id)
+}
+
+// probeCipherSuitesForVersion returns the cipher suites offered when probing
+// the version. For TLS 1.0 to 1.2 this covers the ranges of the IANA registry
+// where suites are assigned, so suites crypto/tls doesn't implement are also
+// tested.
+func probeCipherSuitesForVersion(version uint16) []uint16 {
+	var suites []uint16
+
+	if version == tls.VersionTLS13 {
+		for id := uint16(0x1301); id <= 0x1305; id++ {
+			suites = append(suites, id)
+		}
+
+		return suites
+	}
+
+	// 0x00FF is the renegotiation SCSV rather than a cipher suite
+	for id := uint16(0x0001); id < 0x00FF; id++ {
+		suites = append(suites, id)
+	}
+	for id := uint16(0xC001); id <= 0xC0FF; id++ {
+		suites = append(suites, id)
+	}
+	for id := uint16(0xCCA8); id <= 0xCCAE; id++ {
+		suites = append(suites, id)
+	}
+
+	return suites
+}
+
+// probeCipherSuites finds the cipher suites the server accepts for the
+// version. Each probe offers the remaining suites, and the one the server
+// chooses is removed for the next probe, until it rejects them all. Only the
+// ServerHello is read, so the handshake doesn't need to be supported by
+// crypto/tls.
+func (c *Checker) probeCipherSuites(ctx context.Context, addr, serverName string, version uint16) []uint16 {
+	offered := probeCipherSuitesForVersion(version)
+
+	var accepted []uint16
+	for len(offered) > 0 {
+		suite, err := c.probeServerHello(ctx, addr, serverName, version, offered)
+		if err != nil {
+			break
+		}
+
+		remaining := removeCipherSuite(offered, suite)
+		if len(remaining) == len(offered) {
+			// The server chose a suite which wasn't offered
+			break
+		}
+
+		accepted = append(accepted, suite)
+		offered = remaining
+	}
+
+	sort.Slice(accepted, func(i, j int) bool { return accepted[i] < accepted[j] })
+
+	return accepted
+}
+
+// probeServerHello sends a ClientHello offering the version and cipher
+// suites, and returns the cipher suite chosen by the server
+func (c *Checker) probeServerHello(ctx context.Context, addr, serverName string, version uint16, suites []uint16) (uint16, error) {
+	hello, err := clientHello(serverName, version, suites)
+	if err != nil {
+		return 0, err
+	}
+
+	conn, err := c.dialContext(ctx, "tcp", addr)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if _, err := conn.Write(hello); err != nil {
+		return 0, err
+	}
+
+	return readServerHello(conn, version)
+}
+
+// clientHello builds a record containing a ClientHello for the version
+func clientHello(serverName string, version uint16, suites []uint16) ([]byte, error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return nil, err
+	}
+
+	// TLS 1.3 is negotiated with the supported versions extension, and the
+	// legacy version is always TLS 1.2
+	legacyVersion := version
+	var keyShare []byte
+	if version == tls.VersionTLS13 {
+		legacyVersion = tls.VersionTLS12
+
+		key, err := ecdh.X25519().GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		keyShare = key.PublicKey().Bytes()
+	}
+
+	var b cryptobyte.Builder
+	b.AddUint8(recordTypeHandshake)
+	b.AddUint16(tls.VersionTLS10)
+	b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddUint8(handshakeTypeClientHello)
+		b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) {
+			b.AddUint16(legacyVersion)
+			b.AddBytes(random)
+
+			// Empty session ID
+			b.AddUint8(0)
+
+			b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+				for _, suite := range suites {
+					b.AddUint16(suite)
+				}
+			})
+
+			// Null compression only
+			b.AddUint8(1)
+			b.AddUint8(0)
+
+			b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+				if serverName != "" && net.ParseIP(serverName) == nil {
+					addExtension(b, extensionServerName, func(b *cryptobyte.Builder) {
+						b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+							b.AddUint8(0) // host_name
+							b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+								b.AddBytes([]byte(serverName))
+							})
+						})
+					})
+				}
+
+				addExtension(b, extensionSupportedGroups, func(b *cryptobyte.Builder) {
+					b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+						for _, group := range probeGroups {
+							b.AddUint16(group)
+						}
+					})
+				})
+
+				addExtension(b, extensionECPointFormats, func(b *cryptobyte.Builder) {
+					b.AddUint8(1)
+					b.AddUint8(0) // uncompressed
+				})
+
+				addExtension(b, extensionSignatureAlgorithms, func(b *cryptobyte.Builder) {
+					b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+						for _, algorithm := range probeSignatureAlgorithms {
+							b.AddUint16(algorithm)
+						}
+					})
+				})
+
+				addExtension(b, extensionRenegotiationInfo, func(b *cryptobyte.Builder) {
+					b.AddUint8(0)
+				})
+
+				if version == tls.VersionTLS13 {
+					addExtension(b, extensionSupportedVersions, func(b *cryptobyte.Builder) {
+						b.AddUint8(2)
+						b.AddUint16(tls.VersionTLS13)
+					})
+
+					addExtension(b, extensionKeyShare, func(b *cryptobyte.Builder) {
+						b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+							b.AddUint16(uint16(tls.X25519))
+							b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+								b.AddBytes(keyShare)
+							})
+						})
+					})
+				}
+			})
+		})
+	})
+
+	return b.Bytes()
+}
+
+func addExtension(b *cryptobyte.Builder, extension uint16, data cryptobyte.BuilderContinuation) {
+	b.AddUint16(extension)
+	b.AddUint16LengthPrefixed(data)
+}
+
+// readServerHello reads the handshake messages until the ServerHello, and
+// returns the cipher suite chosen by the server if it accepted the version
+func readServerHello(r io.Reader, version uint16) (uint16, error) {
+	var handshake []byte
+
+	for {
+		header := make([]byte, 5)
+		if _, err := io.ReadFull(r, header); err != nil {
+			return 0, err
+		}
+
+		length := int(header[3])<<8 | int(header[4])
+		if header[0] != recordTypeHandshake || length > maxRecordSize {
+			// Most likely a handshake failure or protocol version alert
+			return 0, errNoServerHello
+		}
+
+		fragment := make([]byte, length)
+		if _, err := io.ReadFull(r, fragment); err != nil {
+			return 0, err
+		}
+		handshake = append(handshake, fragment...)
+
+		if len(handshake) < 4 {
+			continue
+		}
+
+		if handshake[0] != handshakeTypeServerHello {
+			return 0, errNoServerHello
+		}
+
+		messageLength := int(handshake[1])<<16 | int(handshake[2])<<8 | int(handshake[3])
+		if messageLength > maxRecordSize {
+			return 0, errNoServerHello
+		}
+
+		if len(handshake) >= 4+messageLength {
+			return parseServerHello(handshake[4:4+messageLength], version)
+		}
+	}
+}
+
+// parseServerHello returns the cipher suite chosen in the ServerHello, or an
+// error if the server negotiated a different version
+func parseServerHello(message []byte, version uint16) (uint16, error) {
+	s := cryptobyte.String(message)
+
+	var negotiated, suite uint16
+	var sessionID cryptobyte.String
+	var compression uint8
+	if !s.ReadUint16(&negotiated) ||
+		!s.Skip(32) ||
+		!s.ReadUint8LengthPrefixed(&sessionID) ||
+		!s.ReadUint16(&suite) ||
+		!s.ReadUint8(&compression) {
+		return 0, errNoServerHello
+	}
+
+	// TLS 1.3 servers send the version in the supported versions extension.
+	// A HelloRetryRequest is also a ServerHello with the chosen suite.
+	var extensions cryptobyte.String
+	if !s.Empty() && s.ReadUint16LengthPrefixed(&extensions) {
+		for !extensions.Empty() {
+			var extension uint16
+			var data cryptobyte.String
+			if !extensions.ReadUint16(&extension) || !extensions.ReadUint16LengthPrefixed(&data) {
+				return 0, errNoServerHello
+			}
+
+			if extension == extensionSupportedVersions && !data.ReadUint16(&negotiated) {
+				return 0, errNoServerHello
+			}
+		}
+	}
+
+	if negotiated != version {
+		return 0, errNoServerHello
+	}
+
+	return suite, nil
+}
+
+func removeCipherSuite(suites []uint16, suite uint16) []uint16 {
+	var remaining []uint16
+	for _, s := range suites {
+		if s != suite {
+			remaining = append(remaining, s)
+		}
+	}
+
+	return remaining
+}
@@ -0,0 +1,222 @@
+package checker
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+const (
+	defaultTLSPort = "443"
+
+	// Time to wait for a TLS 1.3 session ticket after the handshake
+	sessionTicketTimeout = time.Second
+)
+
+var (
+	tlsVersions = []uint16{
+		tls.VersionTLS10,
+		tls.VersionTLS11,
+		tls.VersionTLS12,
+		tls.VersionTLS13,
+	}
+
+	// ALPN protocols tested during a scan
+	scanALPNProtocols = []string{"h2", "http/1.1"}
+)
+
+// performTLSScan audits the TLS configuration of the server, rather than
+// performing a single request. The timeout applies to the whole scan.
+func (c *Checker) performTLSScan() bool {
+	addr := hostPort(c.Req.URL, defaultTLSPort)
+
+	config, configErr := c.tlsConfig()
+	if configErr != "" {
+		return c.handleFailure("Invalid TLS configuration", configErr.ToString())
+	}
+
+	if err := ValidateResolver(c.resolver()); err != nil {
+		return c.handleFailure("Invalid resolver configuration", types.ResolverInvalid.ToString())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout())
+	defer cancel()
+
+	// Perform a handshake with the normal configuration, so certificate
+	// errors are reported the same way as for other checks
+	traceCtx, trace := withConnTrace(ctx)
+	conn, err := c.dialTLS(traceCtx, addr, config)
+	if err != nil {
+		return c.handleError("Error performing TLS handshake", err)
+	}
+
+	state := conn.ConnectionState()
+	conn.Close()
+
+	c.Res.Timing = trace.timing()
+	c.Res.Certificate = certInfoFromTLSConnectionState(&state)
+	c.Res.Proto = tls.VersionName(state.Version)
+
+	report := &types.TLSScanReport{
+		OCSPStapling: len(state.OCSPResponse) > 0,
+	}
+
+	// The certificate has already been verified by the first handshake
+	config.InsecureSkipVerify = true
+	config.VerifyConnection = nil
+
+	serverName := config.ServerName
+	if serverName == "" {
+		serverName = c.Req.URL.Hostname()
+	}
+
+	for _, version := range tlsVersions {
+		report.Versions = append(report.Versions, c.scanTLSVersion(ctx, addr, serverName, version))
+	}
+
+	report.ALPN = c.scanALPN(ctx, addr, config)
+	report.SessionResumption = c.scanSessionResumption(ctx, addr, config)
+
+	// A probe cut short by the timeout would look like an unsupported
+	// feature, so the report can't be trusted
+	if err := ctx.Err(); err != nil {
+		return c.handleError("Timeout performing TLS scan", err)
+	}
+
+	c.Res.TLSScan = report
+
+	if err := checkTLSPolicy(report, c.Req.Options.TLSPolicy); err != "" {
+		return c.handleFailure("TLS policy violation", err.ToString())
+	}
+
+	if err := c.checkCertificate(&state); err != "" {
+		return c.handleFailure("Certificate check failed", err.ToString())
+	}
+
+	return c.handleSuccessSince(trace.start())
+}
+
+func (c *Checker) scanHandshake(ctx context.Context, addr string, config *tls.Config) (tls.ConnectionState, bool) {
+	conn, err := c.dialTLS(ctx, addr, config)
+	if err != nil {
+		return tls.ConnectionState{}, false
+	}
+	defer conn.Close()
+
+	return conn.ConnectionState(), true
+}
+
+func (c *Checker) scanTLSVersion(ctx context.Context, addr, serverName string, version uint16) types.TLSVersionReport {
+	report := types.TLSVersionReport{
+		Version: tls.VersionName(version),
+		ID:      version,
+	}
+
+	for _, suite := range c.probeCipherSuites(ctx, addr, serverName, version) {
+		report.Supported = true
+		report.CipherSuites = append(report.CipherSuites, cipherSuiteName(suite))
+	}
+
+	return report
+}
+
+func (c *Checker) scanALPN(ctx context.Context, addr string, base *tls.Config) []string {
+	var supported []string
+
+	for _, protocol := range scanALPNProtocols {
+		config := base.Clone()
+		config.NextProtos = []string{protocol}
+
+		state, ok := c.scanHandshake(ctx, addr, config)
+		if ok && state.NegotiatedProtocol == protocol {
+			supported = append(supported, protocol)
+		}
+	}
+
+	return supported
+}
+
+func (c *Checker) scanSessionResumption(ctx context.Context, addr string, base *tls.Config) bool {
+	config := base.Clone()
+	config.ClientSessionCache = tls.NewLRUClientSessionCache(1)
+
+	conn, err := c.dialTLS(ctx, addr, config)
+	if err != nil {
+		return false
+	}
+
+	// TLS 1.3 session tickets are sent after the handshake, and are only
+	// processed when reading from the connection
+	if conn.ConnectionState().Version == tls.VersionTLS13 {
+		deadline := time.Now().Add(sessionTicketTimeout)
+		if scanDeadline, ok := ctx.Deadline(); ok && scanDeadline.Before(deadline) {
+			deadline = scanDeadline
+		}
+
+		conn.SetReadDeadline(deadline)
+		conn.Read(make([]byte, 1))
+	}
+	conn.Close()
+
+	state, ok := c.scanHandshake(ctx, addr, config)
+	return ok && state.DidResume
+}
+
+// checkTLSPolicy verifies the report against the policy, and returns the first
+// violation
+func checkTLSPolicy(report *types.TLSScanReport, policy types.TLSPolicy) types.CheckError {
+	forbidden := map[string]bool{}
+	for _, suite := range policy.ForbiddenCipherSuites {
+		forbidden[cipherSuiteName(suite)] = true
+	}
+
+	// Suites crypto/tls doesn't implement are also insecure
+	secure := map[string]bool{}
+	for _, suite := range tls.CipherSuites() {
+		secure[suite.Name] = true
+	}
+
+	for _, version := range report.Versions {
+		if !version.Supported {
+			continue
+		}
+
+		if version.ID < policy.MinVersion {
+			return types.TLSWeakVersion
+		}
+
+		for _, suite := range version.CipherSuites {
+			if forbidden[suite] || (policy.ForbidInsecureCipherSuites && !secure[suite]) {
+				return types.TLSWeakCipher
+			}
+		}
+	}
+
+	for _, protocol := range policy.RequireALPN {
+		if !containsString(report.ALPN, protocol) {
+			return types.TLSMissingALPN
+		}
+	}
+
+	if policy.RequireOCSPStapling && !report.OCSPStapling {
+		return types.TLSNoOCSPStapling
+	}
+
+	if policy.RequireSessionResumption && !report.SessionResumption {
+		return types.TLSNoSessionResumption
+	}
+
+	return ""
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,308 @@
+package checker_test
+
+import (
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	a "github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/cryptobyte"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// startTLSScanServer starts a TLS server with the given configuration
+func startTLSScanServer(t *testing.T, config *tls.Config) *url.URL {
+	server := httptest.NewUnstartedServer(nil)
+	server.TLS = config
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	u, _ := url.Parse(server.URL)
+	u.Scheme = "tls"
+
+	return u
+}
+
+func tlsScan(u *url.URL, policy types.TLSPolicy) *checker.Checker {
+	req := buildCheck(u.String())
+	req.Options.IgnoreTLSErrors = true
+	req.Options.TLSPolicy = policy
+
+	c := checker.Init(req)
+	c.Perform()
+
+	return c
+}
+
+func TestTLSScan(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+	cert := issueCert(t, certTemplate(), nil, ca)
+
+	u := startTLSScanServer(t, &tls.Config{
+		Certificates: []tls.Certificate{cert.TLSCertificate(ca)},
+		MinVersion:   tls.VersionTLS11,
+		MaxVersion:   tls.VersionTLS12,
+		CipherSuites: []uint16{
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		},
+		NextProtos: []string{"http/1.1"},
+	})
+
+	c := tlsScan(u, types.TLSPolicy{})
+	a.Equal(t, true, c.Success)
+	a.Equal(t, "TLS 1.2", c.Res.Proto)
+	a.NotNil(t, c.Res.Time)
+	a.NotNil(t, c.Res.Timing)
+
+	report := c.Res.TLSScan
+	if a.NotNil(t, report) {
+		a.Equal(t, []types.TLSVersionReport{
+			{Version: "TLS 1.0", ID: tls.VersionTLS10},
+			{Version: "TLS 1.1", ID: tls.VersionTLS11, Supported: true, CipherSuites: []string{
+				"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA",
+			}},
+			{Version: "TLS 1.2", ID: tls.VersionTLS12, Supported: true, CipherSuites: []string{
+				"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA",
+				"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+			}},
+			{Version: "TLS 1.3", ID: tls.VersionTLS13},
+		}, report.Versions)
+		a.Equal(t, []string{"http/1.1"}, report.ALPN)
+		a.Equal(t, false, report.OCSPStapling)
+		a.Equal(t, true, report.SessionResumption)
+	}
+
+	tests := []struct {
+		name   string
+		policy types.TLSPolicy
+		error  string
+	}{
+		{"min version", types.TLSPolicy{MinVersion: tls.VersionTLS12}, types.TLSWeakVersion.ToString()},
+		{"forbidden cipher", types.TLSPolicy{ForbiddenCipherSuites: []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA}}, types.TLSWeakCipher.ToString()},
+		{"alpn", types.TLSPolicy{RequireALPN: []string{"h2"}}, types.TLSMissingALPN.ToString()},
+		{"ocsp stapling", types.TLSPolicy{RequireOCSPStapling: true}, types.TLSNoOCSPStapling.ToString()},
+		{"passes", types.TLSPolicy{MinVersion: tls.VersionTLS11, RequireALPN: []string{"http/1.1"}, RequireSessionResumption: true}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tlsScan(u, tt.policy)
+			a.Equal(t, tt.error == "", c.Success)
+			a.Equal(t, tt.error, c.Res.Error)
+		})
+	}
+}
+
+func TestTLSScanTLS13(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+	cert := issueCert(t, certTemplate(), nil, ca)
+
+	u := startTLSScanServer(t, &tls.Config{
+		Certificates: []tls.Certificate{cert.TLSCertificate(ca)},
+		MinVersion:   tls.VersionTLS13,
+		NextProtos:   []string{"h2", "http/1.1"},
+	})
+
+	c := tlsScan(u, types.TLSPolicy{MinVersion: tls.VersionTLS12, RequireALPN: []string{"h2"}, RequireSessionResumption: true})
+	a.Equal(t, true, c.Success)
+	a.Equal(t, "", c.Res.Error)
+
+	report := c.Res.TLSScan
+	if a.NotNil(t, report) {
+		a.Equal(t, false, report.Versions[2].Supported)
+		a.Equal(t, true, report.Versions[3].Supported)
+		a.Equal(t, []string{
+			"TLS_AES_128_GCM_SHA256",
+			"TLS_AES_256_GCM_SHA384",
+			"TLS_CHACHA20_POLY1305_SHA256",
+		}, report.Versions[3].CipherSuites)
+		a.Equal(t, []string{"h2", "http/1.1"}, report.ALPN)
+	}
+}
+
+// startDHEServer starts a server which accepts TLS_DHE_RSA_WITH_AES_128_CBC_SHA
+// for TLS 1.2, which crypto/tls doesn't implement. Only the ServerHello is
+// sent for it, other handshakes are forwarded to the TLS server.
+func startDHEServer(t *testing.T, upstream *url.URL) *url.URL {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+
+				header := make([]byte, 5)
+				if _, err := io.ReadFull(conn, header); err != nil {
+					return
+				}
+				record := make([]byte, int(header[3])<<8|int(header[4]))
+				if _, err := io.ReadFull(conn, record); err != nil {
+					return
+				}
+
+				if offersDHE(record) {
+					var b cryptobyte.Builder
+					b.AddUint8(22)
+					b.AddUint16(tls.VersionTLS12)
+					b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+						b.AddUint8(2)
+						b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) {
+							b.AddUint16(tls.VersionTLS12)
+							b.AddBytes(make([]byte, 32))
+							b.AddUint8(0)
+							b.AddUint16(0x0033)
+							b.AddUint8(0)
+						})
+					})
+					conn.Write(b.BytesOrPanic())
+					return
+				}
+
+				server, err := net.Dial("tcp", upstream.Host)
+				if err != nil {
+					return
+				}
+				defer server.Close()
+
+				server.Write(append(header, record...))
+				go io.Copy(server, conn)
+				io.Copy(conn, server)
+			}()
+		}
+	}()
+
+	return &url.URL{Scheme: "tls", Host: listener.Addr().String()}
+}
+
+// offersDHE checks if the record is a TLS 1.2 ClientHello offering
+// TLS_DHE_RSA_WITH_AES_128_CBC_SHA
+func offersDHE(record []byte) bool {
+	s := cryptobyte.String(record)
+
+	var messageType uint8
+	var message, sessionID, suites cryptobyte.String
+	var version uint16
+	if !s.ReadUint8(&messageType) || !s.ReadUint24LengthPrefixed(&message) ||
+		!message.ReadUint16(&version) || !message.Skip(32) ||
+		!message.ReadUint8LengthPrefixed(&sessionID) ||
+		!message.ReadUint16LengthPrefixed(&suites) {
+		return false
+	}
+
+	for !suites.Empty() {
+		var suite uint16
+		suites.ReadUint16(&suite)
+		if suite == 0x0033 && version == tls.VersionTLS12 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestTLSScanUnimplementedCipherSuites(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+	cert := issueCert(t, certTemplate(), nil, ca)
+
+	upstream := startTLSScanServer(t, &tls.Config{
+		Certificates: []tls.Certificate{cert.TLSCertificate(ca)},
+		MinVersion:   tls.VersionTLS12,
+		MaxVersion:   tls.VersionTLS12,
+		CipherSuites: []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+	})
+	u := startDHEServer(t, upstream)
+
+	c := tlsScan(u, types.TLSPolicy{})
+	a.Equal(t, true, c.Success)
+
+	report := c.Res.TLSScan
+	if a.NotNil(t, report) {
+		a.Equal(t, []string{
+			"TLS_DHE_RSA_WITH_AES_128_CBC_SHA",
+			"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+		}, report.Versions[2].CipherSuites)
+	}
+
+	c = tlsScan(u, types.TLSPolicy{ForbidInsecureCipherSuites: true})
+	a.Equal(t, false, c.Success)
+	a.Equal(t, types.TLSWeakCipher.ToString(), c.Res.Error)
+
+	// Suites are also forbidden by ID
+	c = tlsScan(u, types.TLSPolicy{ForbiddenCipherSuites: []uint16{0x0033}})
+	a.Equal(t, types.TLSWeakCipher.ToString(), c.Res.Error)
+}
+
+func TestTLSScanCertificateError(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+
+	template := certTemplate()
+	template.NotAfter = time.Now().Add(-time.Minute)
+	cert := issueCert(t, template, nil, ca)
+
+	u := startTLSScanServer(t, &tls.Config{
+		Certificates: []tls.Certificate{cert.TLSCertificate(ca)},
+	})
+
+	c := checker.Init(buildCheck(u.String()))
+	c.Perform()
+
+	a.Equal(t, false, c.Success)
+	a.Equal(t, types.CertExpired.ToString(), c.Res.Error)
+	a.Nil(t, c.Res.TLSScan)
+}
+
+func TestTLSScanTimeout(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+	cert := issueCert(t, certTemplate(), nil, ca)
+	config := &tls.Config{Certificates: []tls.Certificate{cert.TLSCertificate(ca)}}
+
+	// Complete the first handshake, then stall every probe
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	a.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for i := 0; ; i++ {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() { conn.Close() })
+
+			if i == 0 {
+				go tls.Server(conn, config).Handshake()
+			}
+		}
+	}()
+
+	req := buildCheck("tls://" + ln.Addr().String())
+	req.Options.IgnoreTLSErrors = true
+	req.Timeout = 500 * time.Millisecond
+
+	start := time.Now()
+	c := checker.Init(req)
+	c.Perform()
+
+	a.Less(t, time.Since(start), 2*time.Second)
+	a.Equal(t, false, c.Success)
+	a.Equal(t, types.Timeout.ToString(), c.Res.Error)
+	a.NotNil(t, c.Res.Time)
+	a.NotNil(t, c.Res.Timing)
+	a.Nil(t, c.Res.TLSScan)
+}
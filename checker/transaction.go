@@ -0,0 +1,317 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// stepFailure is a step which completed, but didn't pass
+type stepFailure struct {
+	message string
+	code    string
+}
+
+func (e *stepFailure) Error() string {
+	return e.message
+}
+
+func newStepFailure(message string, code types.CheckError) *stepFailure {
+	return &stepFailure{message: message, code: code.ToString()}
+}
+
+// performTransaction performs each step in turn, with the same cookie jar and
+// connections. The first failing step sets the error for the check.
+func (c *Checker) performTransaction() bool {
+	steps := c.Req.Options.Transaction.Steps
+	if len(steps) == 0 {
+		return c.handleFailure("Transaction has no steps", types.TransactionNoSteps.ToString())
+	}
+
+	client, configErr := c.newHTTPClient()
+	if configErr != "" {
+		return c.handleFailure("Invalid configuration", configErr.ToString())
+	}
+	defer client.CloseIdleConnections()
+
+	// Cookies are always shared between steps, e.g. for a session after
+	// logging in
+	client.Jar, configErr = c.newCookieJar(true)
+	if configErr != "" {
+		return c.handleFailure("Invalid configuration", configErr.ToString())
+	}
+
+	// The timeout applies to the whole transaction
+	client.Timeout = 0
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout())
+	defer cancel()
+
+	variables := map[string]string{}
+	timing := emptyTiming()
+
+	for _, step := range steps {
+		result := types.TransactionStepResult{Name: step.Name}
+
+		resp, err := c.performStep(ctx, client, step, variables, &result)
+		if resp != nil {
+			c.recordResponse(resp)
+			addTiming(timing, resp.Timing)
+		}
+
+		if err != nil {
+			c.Res.Timing = timing
+			c.handleStepError(err)
+
+			result.Error = c.Res.Error
+			c.Res.Transaction = append(c.Res.Transaction, result)
+
+			return false
+		}
+
+		c.Res.Transaction = append(c.Res.Transaction, result)
+	}
+
+	c.Res.Timing = timing
+
+	return c.handleSuccess()
+}
+
+// performStep performs the request for the step, checks assertions and
+// extracts variables. The response is returned even if the step failed.
+func (c *Checker) performStep(ctx context.Context, client *http.Client, step types.TransactionStep, variables map[string]string, result *types.TransactionStepResult) (*tracedResponse, error) {
+	req, err := c.buildStepRequest(step, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Method = req.Method
+	result.URL = req.URL.String()
+
+	resp, err := c.doRequest(ctx, client, req)
+	if err != nil {
+		return nil, err
+	}
+
+	total := resp.End.Sub(resp.Start).Truncate(time.Millisecond)
+	result.Time = &total
+	result.Timing = resp.Timing
+	result.StatusCode = resp.StatusCode
+
+	checkedStatus := false
+	var failed *stepFailure
+
+	for _, assertion := range step.Assertions {
+		res, err := checkAssertion(resp, assertion)
+		if err != nil {
+			return resp, err
+		}
+
+		if assertion.Source == types.AssertStatus {
+			checkedStatus = true
+		}
+
+		if !res.Passed && failed == nil {
+			failed = newStepFailure("Assertion failed", types.AssertionFailed)
+		}
+
+		result.Assertions = append(result.Assertions, res)
+	}
+
+	if !checkedStatus && (resp.StatusCode < 200 || resp.StatusCode > 299) {
+		return resp, &stepFailure{message: "Unsuccessful status code", code: c.statusError(resp.StatusCode)}
+	}
+
+	if failed != nil {
+		return resp, failed
+	}
+
+	for _, extraction := range step.Extract {
+		value, err := extractValue(resp, extraction)
+		if err != nil {
+			return resp, err
+		}
+
+		variables[extraction.Name] = value
+	}
+
+	// Perform optional checks on the certificate
+	if resp.TLS != nil {
+		if err := c.checkCertificate(resp.TLS); err != "" {
+			return resp, newStepFailure("Certificate check failed", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// buildStepRequest creates the request for the step, replacing variables and
+// template values in the URL, headers and body
+func (c *Checker) buildStepRequest(step types.TransactionStep, variables map[string]string) (*http.Request, error) {
+	lookup := func(name string) (string, bool) {
+		value, ok := variables[name]
+		return value, ok
+	}
+
+	template := newRequestTemplate()
+
+	body, err := template.renderBody(step.Body, lookup)
+	if err != nil {
+		return nil, templateFailure(err)
+	}
+
+	rawurl, err := template.renderURLWith(step.URL, lookup)
+	if err != nil {
+		return nil, templateFailure(err)
+	}
+
+	u, err := c.Req.URL.Parse(rawurl)
+	if err != nil {
+		return nil, newStepFailure("Invalid URL", types.TransactionInvalidStep)
+	}
+
+	method := step.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	req, err := http.NewRequest(method, u.String(), strings.NewReader(body))
+	if err != nil {
+		return nil, newStepFailure("Invalid request", types.TransactionInvalidStep)
+	}
+
+	setDefaultHeaders(req)
+
+	for name, values := range step.Headers {
+		req.Header.Del(name)
+
+		for _, value := range values {
+			value, err := template.renderWith(value, lookup)
+			if err != nil {
+				return nil, templateFailure(err)
+			}
+
+			req.Header.Add(name, value)
+		}
+	}
+
+	return req, nil
+}
+
+func templateFailure(err error) *stepFailure {
+	return &stepFailure{message: err.Error(), code: templateErrorCode(err, types.TransactionUndefinedVariable).ToString()}
+}
+
+// extractValue returns the value for the extraction from the response
+func extractValue(resp *tracedResponse, extraction types.Extraction) (string, error) {
+	switch extraction.Source {
+	case types.ExtractJSON:
+		if value, ok := jsonPath(resp.Body, extraction.Expression); ok {
+			return value, nil
+		}
+
+	case types.ExtractRegex:
+		pattern, err := regexp.Compile(extraction.Expression)
+		if err != nil {
+			return "", newStepFailure("Invalid extraction pattern", types.TransactionInvalidStep)
+		}
+
+		// The first group is extracted, or the whole match if there aren't
+		// any groups
+		if match := pattern.FindSubmatch(resp.Body); len(match) > 1 {
+			return string(match[1]), nil
+		} else if match != nil {
+			return string(match[0]), nil
+		}
+
+	case types.ExtractHeader:
+		if values := resp.Header.Values(extraction.Expression); len(values) > 0 {
+			return values[0], nil
+		}
+
+	default:
+		return "", newStepFailure("Invalid extraction source", types.TransactionInvalidStep)
+	}
+
+	return "", newStepFailure(fmt.Sprintf("Unable to extract %s", extraction.Name), types.TransactionExtractionFailed)
+}
+
+// checkAssertion checks the part of the response matches the pattern
+func checkAssertion(resp *tracedResponse, assertion types.Assertion) (types.AssertionResult, error) {
+	res := types.AssertionResult{Assertion: assertion}
+
+	pattern, err := regexp.Compile(assertion.Pattern)
+	if err != nil {
+		return res, newStepFailure("Invalid assertion pattern", types.TransactionInvalidStep)
+	}
+
+	found := true
+
+	switch assertion.Source {
+	case types.AssertStatus:
+		res.Value = strconv.Itoa(resp.StatusCode)
+
+	case types.AssertHeader:
+		values := resp.Header.Values(assertion.Expression)
+		res.Value = strings.Join(values, ", ")
+		found = len(values) > 0
+
+	case types.AssertBody:
+		res.Value = string(resp.Body)
+
+	case types.AssertJSON:
+		res.Value, found = jsonPath(resp.Body, assertion.Expression)
+
+	default:
+		return res, newStepFailure("Invalid assertion source", types.TransactionInvalidStep)
+	}
+
+	res.Passed = found && pattern.MatchString(res.Value)
+
+	return res, nil
+}
+
+// handleStepError records the error for a failed step
+func (c *Checker) handleStepError(err error) bool {
+	var failure *stepFailure
+	if errors.As(err, &failure) {
+		return c.handleFailure(failure.message, failure.code)
+	}
+
+	return c.handleError("Error making request", err)
+}
+
+func emptyTiming() *types.RequestTiming {
+	var dns, connecting, tls, sending, waiting, receiving time.Duration
+
+	return &types.RequestTiming{
+		DNS:        &dns,
+		Connecting: &connecting,
+		TLS:        &tls,
+		Sending:    &sending,
+		Waiting:    &waiting,
+		Receiving:  &receiving,
+	}
+}
+
+// addTiming adds the durations of each phase to the total
+func addTiming(total, timing *types.RequestTiming) {
+	*total.DNS += *timing.DNS
+	*total.Connecting += *timing.Connecting
+	if timing.Proxy != nil {
+		if total.Proxy == nil {
+			total.Proxy = new(time.Duration)
+		}
+		*total.Proxy += *timing.Proxy
+	}
+	*total.TLS += *timing.TLS
+	*total.Sending += *timing.Sending
+	*total.Waiting += *timing.Waiting
+	*total.Receiving += *timing.Receiving
+}
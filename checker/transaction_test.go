@@ -0,0 +1,279 @@
+package checker_test
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	a "github.com/stretchr/testify/assert"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// startShopServer starts a server with a login and a page which requires the
+// session cookie and token, returning the number of connections accepted
+func startShopServer(t *testing.T) (*httptest.Server, *int32) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		var body struct{ User string }
+		if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(&body) != nil || body.User != "alice" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "s3ss10n", Path: "/"})
+		w.Header().Set("X-Request-Id", "req-1")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"token": "t0k3n", "user": {"id": 42, "roles": ["admin", "buyer"]}}`))
+	})
+
+	mux.HandleFunc("/account/", func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != "s3ss10n" || r.Header.Get("Authorization") != "Bearer t0k3n" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		w.Write([]byte(`<h1>Account ` + r.URL.Path[len("/account/"):] + `</h1><p>Basket: 3 items</p>`))
+	})
+
+	server := httptest.NewUnstartedServer(mux)
+
+	var connections int32
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&connections, 1)
+		}
+	}
+
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return server, &connections
+}
+
+func loginSteps() []types.TransactionStep {
+	return []types.TransactionStep{
+		{
+			Name:    "login",
+			Method:  "POST",
+			URL:     "/login",
+			Headers: http.Header{"Content-Type": {"application/json"}},
+			Body:    `{"user": "alice"}`,
+			Extract: []types.Extraction{
+				{Name: "token", Source: types.ExtractJSON, Expression: "$.token"},
+				{Name: "id", Source: types.ExtractJSON, Expression: "user.id"},
+				{Name: "role", Source: types.ExtractJSON, Expression: "user.roles[1]"},
+				{Name: "request", Source: types.ExtractHeader, Expression: "X-Request-Id"},
+			},
+		},
+		{
+			Name:    "account",
+			URL:     "/account/${id}?role=${role}&request=${request}",
+			Headers: http.Header{"Authorization": {"Bearer ${token}"}},
+			Extract: []types.Extraction{
+				{Name: "items", Source: types.ExtractRegex, Expression: `Basket: (\d+) items`},
+			},
+			Assertions: []types.Assertion{
+				{Source: types.AssertStatus, Pattern: "^200$"},
+				{Source: types.AssertBody, Pattern: "Account 42"},
+			},
+		},
+	}
+}
+
+func TestTransaction(t *testing.T) {
+	server, connections := startShopServer(t)
+
+	req := buildCheck(server.URL)
+	req.Type = "transaction"
+	req.Options.Transaction = types.Transaction{Steps: loginSteps()}
+
+	c := checker.Init(req)
+	c.Perform()
+
+	a.Equal(t, "", c.Res.Error)
+	a.True(t, c.Success)
+	a.Equal(t, int32(1), atomic.LoadInt32(connections))
+	a.Equal(t, 200, c.Res.StatusCode)
+
+	if a.Len(t, c.Res.Transaction, 2) {
+		login := c.Res.Transaction[0]
+		a.Equal(t, "login", login.Name)
+		a.Equal(t, "POST", login.Method)
+		a.Equal(t, server.URL+"/login", login.URL)
+		a.Equal(t, 200, login.StatusCode)
+		a.NotNil(t, login.Time)
+		a.NotNil(t, login.Timing)
+
+		account := c.Res.Transaction[1]
+		a.Equal(t, "GET", account.Method)
+		a.Equal(t, server.URL+"/account/42?role=buyer&request=req-1", account.URL)
+		a.Equal(t, "", account.Error)
+		if a.Len(t, account.Assertions, 2) {
+			a.Equal(t, "200", account.Assertions[0].Value)
+			a.True(t, account.Assertions[0].Passed)
+			a.True(t, account.Assertions[1].Passed)
+		}
+	}
+}
+
+func TestTransactionRegexGroups(t *testing.T) {
+	server, _ := startShopServer(t)
+
+	steps := loginSteps()
+	steps[1].Extract = append(steps[1].Extract,
+		types.Extraction{Name: "account", Source: types.ExtractRegex, Expression: `Account (\d+)</h1><p>Basket: (\d+)`},
+		types.Extraction{Name: "basket", Source: types.ExtractRegex, Expression: `Basket: \d+`},
+	)
+	steps = append(steps, types.TransactionStep{
+		Name:    "again",
+		URL:     "/account/${account}?basket=${basket}",
+		Headers: http.Header{"Authorization": {"Bearer ${token}"}},
+	})
+
+	req := buildCheck(server.URL)
+	req.Type = "transaction"
+	req.Options.Transaction = types.Transaction{Steps: steps}
+
+	c := checker.Init(req)
+	c.Perform()
+
+	a.Equal(t, "", c.Res.Error)
+	if a.Len(t, c.Res.Transaction, 3) {
+		a.Equal(t, server.URL+"/account/42?basket=Basket%3A%203", c.Res.Transaction[2].URL)
+	}
+}
+
+func TestTransactionFailures(t *testing.T) {
+	server, _ := startShopServer(t)
+
+	tests := []struct {
+		name   string
+		modify func(steps []types.TransactionStep) []types.TransactionStep
+		error  string
+		steps  int
+	}{
+		{"no steps", func(steps []types.TransactionStep) []types.TransactionStep {
+			return nil
+		}, types.TransactionNoSteps.ToString(), 0},
+		{"status", func(steps []types.TransactionStep) []types.TransactionStep {
+			steps[0].Body = `{"user": "mallory"}`
+			return steps
+		}, "401", 1},
+		{"assertion", func(steps []types.TransactionStep) []types.TransactionStep {
+			steps[1].Assertions[1].Pattern = "Account 43"
+			return steps
+		}, types.AssertionFailed.ToString(), 2},
+		{"status assertion", func(steps []types.TransactionStep) []types.TransactionStep {
+			steps[1].Headers = nil
+			return steps
+		}, types.AssertionFailed.ToString(), 2},
+		{"extraction", func(steps []types.TransactionStep) []types.TransactionStep {
+			steps[0].Extract[0].Expression = "user.token"
+			return steps
+		}, types.TransactionExtractionFailed.ToString(), 1},
+		{"undefined variable", func(steps []types.TransactionStep) []types.TransactionStep {
+			steps[1].URL = "/account/${missing}"
+			return steps
+		}, types.TransactionUndefinedVariable.ToString(), 2},
+		{"invalid source", func(steps []types.TransactionStep) []types.TransactionStep {
+			steps[0].Extract[0].Source = "xml"
+			return steps
+		}, types.TransactionInvalidStep.ToString(), 1},
+		{"invalid pattern", func(steps []types.TransactionStep) []types.TransactionStep {
+			steps[1].Assertions[0].Pattern = "("
+			return steps
+		}, types.TransactionInvalidStep.ToString(), 2},
+		{"connection refused", func(steps []types.TransactionStep) []types.TransactionStep {
+			steps[1].URL = "http://127.0.0.1:1/"
+			return steps
+		}, types.ConnectionRefused.ToString(), 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := buildCheck(server.URL)
+			req.Type = "transaction"
+			req.Options.Transaction = types.Transaction{Steps: tt.modify(loginSteps())}
+
+			c := checker.Init(req)
+			c.Perform()
+
+			a.Equal(t, tt.error, c.Res.Error)
+			a.False(t, c.Success)
+
+			// Later steps aren't performed, and the failing step has the error
+			if a.Len(t, c.Res.Transaction, tt.steps) && tt.steps > 0 {
+				a.Equal(t, tt.error, c.Res.Transaction[tt.steps-1].Error)
+			}
+		})
+	}
+}
+
+func TestTransactionEscapesVariables(t *testing.T) {
+	value := "a&b=c d/e?f"
+
+	checker.Configure(checker.Config{Secrets: map[string]string{"value": value}})
+	t.Cleanup(func() { checker.Configure(checker.Config{}) })
+
+	var paths, queries []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"value": value,
+			"next":  "http://" + r.Host + "/echo/next",
+			"path":  "/echo/path",
+			"host":  r.Host,
+		})
+	})
+	mux.HandleFunc("/echo/", func(w http.ResponseWriter, r *http.Request) {
+		path, _ := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), "/echo/"))
+		paths = append(paths, path)
+		queries = append(queries, r.URL.RawQuery)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	req := buildCheck(server.URL)
+	req.Type = "transaction"
+	req.Options.Transaction = types.Transaction{Steps: []types.TransactionStep{
+		{
+			URL: "/token",
+			Extract: []types.Extraction{
+				{Name: "value", Source: types.ExtractJSON, Expression: "value"},
+				{Name: "next", Source: types.ExtractJSON, Expression: "next"},
+				{Name: "path", Source: types.ExtractJSON, Expression: "path"},
+				{Name: "host", Source: types.ExtractJSON, Expression: "host"},
+			},
+		},
+		{URL: "/echo/${value}?q=${value}&page=1"},
+		{URL: "/echo/${secret:value}?q=${secret:value}&page=1"},
+
+		// URLs and paths at the start, and hosts, aren't escaped
+		{URL: "${next}"},
+		{URL: "${path}?q=${value}"},
+		{URL: "http://${host}/echo/host"},
+	}}
+
+	c := checker.Init(req)
+	c.Perform()
+
+	a.Equal(t, "", c.Res.Error)
+	a.Equal(t, []string{value, value, "next", "path", "host"}, paths)
+
+	for _, query := range []string{queries[0], queries[1]} {
+		values, _ := url.ParseQuery(query)
+		a.Equal(t, url.Values{"q": {value}, "page": {"1"}}, values)
+	}
+
+	values, _ := url.ParseQuery(queries[3])
+	a.Equal(t, url.Values{"q": {value}}, values)
+}
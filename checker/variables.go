@@ -0,0 +1,93 @@
+package checker
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	variablePattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+)
+
+// undefinedVariableError is returned when a variable can't be expanded
+type undefinedVariableError struct {
+	name string
+}
+
+func (e *undefinedVariableError) Error() string {
+	return fmt.Sprintf("Undefined variable %s", e.name)
+}
+
+// expandVariables replaces ${name} with the value returned by lookup
+func expandVariables(s string, lookup func(name string) (string, bool)) (string, error) {
+	var err error
+
+	res := variablePattern.ReplaceAllStringFunc(s, func(match string) string {
+		name := variablePattern.FindStringSubmatch(match)[1]
+
+		value, ok := lookup(name)
+		if !ok && err == nil {
+			err = &undefinedVariableError{name: name}
+		}
+
+		return value
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return res, nil
+}
+
+// expandURLVariables replaces ${name} in a URL with the value returned by
+// lookup, escaped for where it is in the URL
+func expandURLVariables(s string, lookup func(name string) (string, bool)) (string, error) {
+	var b strings.Builder
+	var err error
+
+	last := 0
+	for _, match := range variablePattern.FindAllStringSubmatchIndex(s, -1) {
+		name := s[match[2]:match[3]]
+
+		value, ok := lookup(name)
+		if !ok && err == nil {
+			err = &undefinedVariableError{name: name}
+		}
+
+		b.WriteString(s[last:match[0]])
+		b.WriteString(escapeURLVariable(s[:match[0]], value))
+		last = match[1]
+	}
+	if err != nil {
+		return "", err
+	}
+
+	b.WriteString(s[last:])
+
+	return b.String(), nil
+}
+
+// escapeURLVariable escapes a value following the prefix of a URL. Values in
+// path segments, the query and the fragment may contain characters which are
+// part of the URL syntax, such as / or &, so they are escaped. At the start
+// of the URL the value is a URL or path, and in the host it can't be escaped,
+// so it's used as it is.
+func escapeURLVariable(prefix, value string) string {
+	switch {
+	case prefix == "":
+		return value
+
+	case strings.ContainsAny(prefix, "?#"):
+		// Spaces are escaped as %20 rather than +, so the value is the same
+		// wherever it's used
+		return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+	}
+
+	if i := strings.Index(prefix, "//"); i >= 0 && !strings.Contains(prefix[i+2:], "/") {
+		return value
+	}
+
+	return url.PathEscape(value)
+}
@@ -0,0 +1,196 @@
+package checker
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"regexp"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+const (
+	// Time to wait for the server to acknowledge the close frame
+	closeTimeout = time.Second
+)
+
+// performWebSocketCheck performs the WebSocket handshake, and optionally
+// sends a message and waits for the reply
+func (c *Checker) performWebSocketCheck() bool {
+	opts := c.Req.Options.WebSocketCheck
+
+	if err := ValidateResolver(c.resolver()); err != nil {
+		return c.handleFailure("Invalid resolver configuration", types.ResolverInvalid.ToString())
+	}
+
+	tlsConfig, configErr := c.tlsConfig()
+	if configErr != "" {
+		return c.handleFailure("Invalid TLS configuration", configErr.ToString())
+	}
+
+	var pattern *regexp.Regexp
+	if opts.ExpectReply != "" {
+		var err error
+		if pattern, err = regexp.Compile(opts.ExpectReply); err != nil {
+			return c.handleFailure("Invalid reply pattern", types.WebSocketInvalidPattern.ToString())
+		}
+	}
+
+	dialer := &websocket.Dialer{
+		NetDialContext:   c.dialContext,
+		TLSClientConfig:  tlsConfig,
+		HandshakeTimeout: c.timeout(),
+		Subprotocols:     opts.Subprotocols,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout())
+	defer cancel()
+
+	// Instrument the handshake, and extract timings at various points
+	ctx, trace := withConnTrace(ctx)
+
+	var firstByte time.Time
+	ctx = httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GotFirstResponseByte: func() { firstByte = time.Now() },
+	})
+
+	header := http.Header{}
+	header.Set("User-Agent", userAgent)
+
+	conn, resp, err := dialer.DialContext(ctx, c.Req.URL.String(), header)
+	done := time.Now() // after the response has been read
+
+	if resp != nil {
+		c.Res.Proto = resp.Proto
+		c.Res.StatusText = resp.Status
+		c.Res.StatusCode = resp.StatusCode
+		c.Res.Headers = resp.Header
+	}
+
+	if err != nil {
+		if resp != nil && resp.StatusCode != http.StatusSwitchingProtocols {
+			return c.handleFailure("Unsuccessful status code", fmt.Sprintf("%d", resp.StatusCode))
+		}
+
+		if err == websocket.ErrBadHandshake {
+			return c.handleFailure("Invalid handshake", types.WebSocketHandshakeFailed.ToString())
+		}
+
+		return c.handleError("Error performing handshake", err)
+	}
+	defer conn.Close()
+
+	// The request is written in a single call, so sending isn't measured
+	var sending time.Duration
+	waiting := firstByte.Sub(trace.ready()).Truncate(time.Millisecond)
+	receiving := done.Sub(firstByte).Truncate(time.Millisecond)
+
+	c.Res.Timing = trace.timing()
+	c.Res.Timing.Sending = &sending
+	c.Res.Timing.Waiting = &waiting
+	c.Res.Timing.Receiving = &receiving
+
+	var state *tls.ConnectionState
+	if tlsConn, ok := conn.UnderlyingConn().(*tls.Conn); ok {
+		s := tlsConn.ConnectionState()
+		state = &s
+		c.Res.Certificate = certInfoFromTLSConnectionState(state)
+	}
+
+	report := &types.WebSocketReport{
+		Subprotocol: conn.Subprotocol(),
+	}
+	c.Res.WebSocket = report
+
+	deadline, _ := ctx.Deadline()
+
+	if opts.Message != "" {
+		replyDeadline := deadline
+		if opts.ReplyTimeout > 0 && time.Now().Add(opts.ReplyTimeout).Before(deadline) {
+			replyDeadline = time.Now().Add(opts.ReplyTimeout)
+		}
+
+		message, err := expandSecrets(opts.Message)
+		if err != nil {
+			return c.handleFailure(err.Error(), types.SecretNotFound.ToString())
+		}
+
+		sent := time.Now()
+		conn.SetWriteDeadline(deadline)
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(message)); err != nil {
+			return c.handleError("Error sending message", err)
+		}
+
+		conn.SetReadDeadline(replyDeadline)
+		_, reply, err := conn.ReadMessage()
+		if err != nil {
+			return c.handleReadError(err)
+		}
+
+		replyTime := time.Since(sent).Truncate(time.Millisecond)
+		report.Reply = string(reply)
+		report.ReplyTime = &replyTime
+		c.Res.Body = report.Reply
+
+		if pattern != nil && !pattern.Match(reply) {
+			return c.handleFailure("Reply didn't match", types.WebSocketReplyMismatch.ToString())
+		}
+	}
+
+	report.CleanClose = closeWebSocket(conn, deadline)
+	if !report.CleanClose {
+		c.addWarning(types.WebSocketUncleanClose)
+	}
+
+	// Perform optional checks on the certificate
+	if state != nil {
+		if err := c.checkCertificate(state); err != "" {
+			return c.handleFailure("Certificate check failed", err.ToString())
+		}
+	}
+
+	return c.handleSuccessSince(trace.start())
+}
+
+// handleReadError classifies errors waiting for a reply
+func (c *Checker) handleReadError(err error) bool {
+	var closeErr *websocket.CloseError
+	if errors.As(err, &closeErr) {
+		return c.handleFailure("Connection closed by server", types.Closed.ToString())
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return c.handleFailure("No reply received", types.WebSocketReplyTimeout.ToString())
+	}
+
+	return c.handleError("Error reading reply", err)
+}
+
+// closeWebSocket sends a close frame, and waits for the server to
+// acknowledge it
+func closeWebSocket(conn *websocket.Conn, deadline time.Time) bool {
+	if d := time.Now().Add(closeTimeout); d.Before(deadline) {
+		deadline = d
+	}
+
+	message := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	if err := conn.WriteControl(websocket.CloseMessage, message, deadline); err != nil {
+		return false
+	}
+
+	// Discard any messages until the close frame is received
+	conn.SetReadDeadline(deadline)
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseNoStatusReceived)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package checker_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	a "github.com/stretchr/testify/assert"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+)
+
+// webSocketHandler echoes messages, except "silent" which isn't replied to,
+// and "close" which closes the connection
+func webSocketHandler(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{Subprotocols: []string{"echo"}}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	for {
+		messageType, message, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		switch string(message) {
+		case "silent":
+		case "close":
+			conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, ""))
+			return
+		default:
+			conn.WriteMessage(messageType, message)
+		}
+	}
+}
+
+func webSocketMux() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws", webSocketHandler)
+	return mux
+}
+
+func TestWebSocketCheck(t *testing.T) {
+	server := httptest.NewServer(webSocketMux())
+	t.Cleanup(server.Close)
+
+	url := strings.Replace(server.URL, "http://", "ws://", 1)
+
+	tests := []struct {
+		name       string
+		url        string
+		check      types.WebSocketCheck
+		error      string
+		statusCode int
+		reply      string
+	}{
+		{"handshake", url + "/ws", types.WebSocketCheck{}, "", 101, ""},
+		{"not found", url + "/missing", types.WebSocketCheck{}, "404", 404, ""},
+		{"echo", url + "/ws", types.WebSocketCheck{Message: "ping"}, "", 101, "ping"},
+		{"reply match", url + "/ws", types.WebSocketCheck{Message: `{"type":"ping"}`, ExpectReply: `"ping"`}, "", 101, `{"type":"ping"}`},
+		{"reply mismatch", url + "/ws", types.WebSocketCheck{Message: "ping", ExpectReply: "^pong$"}, types.WebSocketReplyMismatch.ToString(), 101, "ping"},
+		{"invalid pattern", url + "/ws", types.WebSocketCheck{Message: "ping", ExpectReply: "("}, types.WebSocketInvalidPattern.ToString(), 0, ""},
+		{"reply timeout", url + "/ws", types.WebSocketCheck{Message: "silent", ReplyTimeout: 100 * time.Millisecond}, types.WebSocketReplyTimeout.ToString(), 101, ""},
+		{"closed", url + "/ws", types.WebSocketCheck{Message: "close"}, types.Closed.ToString(), 101, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := buildCheck(tt.url)
+			req.Options.WebSocketCheck = tt.check
+
+			c := checker.Init(req)
+			c.Perform()
+
+			a.Equal(t, tt.error, c.Res.Error)
+			a.Equal(t, tt.error == "", c.Success)
+			a.Equal(t, tt.statusCode, c.Res.StatusCode)
+
+			if tt.error == "" {
+				a.Equal(t, tt.reply, c.Res.WebSocket.Reply)
+				a.True(t, c.Res.WebSocket.CleanClose)
+				a.Empty(t, c.Res.Warnings)
+				a.NotNil(t, c.Res.Timing.Waiting)
+			}
+		})
+	}
+}
+
+func TestWebSocketCheckSubprotocol(t *testing.T) {
+	server := httptest.NewServer(webSocketMux())
+	t.Cleanup(server.Close)
+
+	url := strings.Replace(server.URL, "http://", "ws://", 1)
+
+	req := buildCheck(url + "/ws")
+	req.Options.WebSocketCheck = types.WebSocketCheck{Subprotocols: []string{"chat", "echo"}}
+
+	c := checker.Init(req)
+	c.Perform()
+	a.Equal(t, "", c.Res.Error)
+	a.Equal(t, "echo", c.Res.WebSocket.Subprotocol)
+}
+
+func TestWebSocketCheckUncleanClose(t *testing.T) {
+	// Close the connection without replying to the close frame
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := websocket.Upgrader{}
+		if conn, err := upgrader.Upgrade(w, r, nil); err == nil {
+			conn.UnderlyingConn().Close()
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	url := strings.Replace(server.URL, "http://", "ws://", 1)
+
+	req := buildCheck(url)
+
+	c := checker.Init(req)
+	c.Perform()
+	a.Equal(t, "", c.Res.Error)
+	a.False(t, c.Res.WebSocket.CleanClose)
+	a.Equal(t, []string{types.WebSocketUncleanClose.ToString()}, c.Res.Warnings)
+}
+
+func TestWebSocketCheckTLS(t *testing.T) {
+	ca := issueCert(t, caTemplate("Test CA"), nil, nil)
+	cert := issueCert(t, certTemplate(), nil, ca)
+	caPEM, _ := ca.PEM(t)
+
+	server := startTLSServer(t, cert.TLSCertificate(ca), webSocketMux())
+	url := strings.Replace(server.URL, "https://", "wss://", 1) + "/ws"
+
+	req := buildCheck(url)
+	req.Options.TrustedCAs = types.TrustedCAs{PEM: caPEM}
+	req.Options.WebSocketCheck = types.WebSocketCheck{Message: "ping"}
+
+	c := checker.Init(req)
+	c.Perform()
+
+	a.Equal(t, "", c.Res.Error)
+	a.Equal(t, "ping", c.Res.WebSocket.Reply)
+	a.Equal(t, cert.Cert.SerialNumber.Bytes(), c.Res.Certificate.Serial)
+	a.NotNil(t, c.Res.Timing.TLS)
+
+	// Certificate errors are classified the same as for HTTPS
+	req = buildCheck(url)
+
+	c = checker.Init(req)
+	c.Perform()
+	a.Equal(t, types.CertUntrustedAuthority.ToString(), c.Res.Error)
+	a.NotNil(t, c.Res.Certificate)
+}
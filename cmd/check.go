@@ -1,7 +1,14 @@
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
@@ -16,6 +23,124 @@ var (
 	Check = &cli.Command{
 		Name:   "check",
 		Action: runCheck,
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:  "type",
+				Usage: "type of check, by default the URL scheme is used",
+			},
+			&cli.StringSliceFlag{
+				Name:  "resolve",
+				Usage: "connect to an address instead of resolving the host (host:port:address)",
+			},
+			&cli.StringFlag{
+				Name:  "ip_family",
+				Usage: "address family to connect with (ipv4, ipv6 or both)",
+			},
+			&cli.StringFlag{
+				Name:  "transaction",
+				Usage: "JSON file with the steps for a transaction check, relative URLs are resolved against the URL",
+			},
+			&cli.StringFlag{
+				Name:  "http_protocol",
+				Usage: "HTTP version to require (http1, h2, h2c or h3)",
+			},
+			&cli.StringFlag{
+				Name:  "dns_type",
+				Usage: "record type for dns:// checks",
+			},
+			&cli.StringSliceFlag{
+				Name:  "dns_expect",
+				Usage: "value which must be in the answer for dns:// checks",
+			},
+			&cli.BoolFlag{
+				Name:  "dns_check_nameservers",
+				Usage: "check every authoritative nameserver gives the same answer",
+			},
+			&cli.BoolFlag{
+				Name:  "dns_check_serial",
+				Usage: "check every authoritative nameserver has the same SOA serial",
+			},
+			&cli.BoolFlag{
+				Name:  "dnssec",
+				Usage: "validate the records with DNSSEC",
+			},
+			&cli.StringFlag{
+				Name:  "tcp_payload",
+				Usage: "data sent by tcp:// checks, with Go escape sequences such as \\r\\n",
+			},
+			&cli.StringFlag{
+				Name:  "tcp_expect",
+				Usage: "regular expression the data received by tcp:// checks must match",
+			},
+			&cli.BoolFlag{
+				Name:  "tcp_tls",
+				Usage: "perform a TLS handshake after connecting for tcp:// checks",
+			},
+			&cli.BoolFlag{
+				Name:  "mail_starttls",
+				Usage: "upgrade smtp://, imap:// and pop3:// connections with STARTTLS",
+			},
+			&cli.StringFlag{
+				Name:  "mail_hostname",
+				Usage: "hostname sent in the SMTP EHLO command",
+			},
+			&cli.StringSliceFlag{
+				Name:  "mail_capability",
+				Usage: "capability the mail server must advertise, may be repeated",
+			},
+			&cli.StringFlag{
+				Name:  "ws_message",
+				Usage: "text message sent by ws:// checks after the handshake",
+			},
+			&cli.StringFlag{
+				Name:  "ws_expect",
+				Usage: "regular expression the reply to the ws:// message must match",
+			},
+			&cli.StringSliceFlag{
+				Name:  "ws_subprotocol",
+				Usage: "subprotocol requested by ws:// checks, may be repeated",
+			},
+			&cli.StringFlag{
+				Name:  "grpc_service",
+				Usage: "service checked by grpc:// checks, by default the overall server health",
+			},
+			&cli.BoolFlag{
+				Name:  "grpc_tls",
+				Usage: "connect to grpc:// services with TLS",
+			},
+			&cli.StringSliceFlag{
+				Name:  "header",
+				Usage: "request header as \"Name: value\", may be repeated and contain template values such as ${timestamp}",
+			},
+			&cli.StringFlag{
+				Name:  "body",
+				Usage: "request body, which may contain template values",
+			},
+			&cli.BoolFlag{
+				Name:  "cookie_jar",
+				Usage: "store cookies set by responses and send them with redirects",
+			},
+			&cli.StringSliceFlag{
+				Name:  "cookie",
+				Usage: "cookie sent with the request as name=value, may be repeated",
+			},
+			&cli.StringFlag{
+				Name:  "auth",
+				Usage: "authentication scheme (basic, digest or oauth2)",
+			},
+			&cli.StringFlag{
+				Name:  "auth_credentials",
+				Usage: "name of the secret containing the credentials as username:password",
+			},
+			&cli.StringFlag{
+				Name:  "auth_token_url",
+				Usage: "OAuth2 token endpoint",
+			},
+			&cli.StringSliceFlag{
+				Name:  "auth_scope",
+				Usage: "OAuth2 scope requested, may be repeated",
+			},
+		}, configFlags...),
 	}
 )
 
@@ -23,23 +148,105 @@ func runCheck(c *cli.Context) error {
 	// Enable debugging
 	log.SetLevel(log.DebugLevel)
 
+	if err := configure(); err != nil {
+		return cli.Exit(fmt.Sprintf("Invalid configuration: %v", err), 1)
+	}
+
 	// Parse URL
 	url, err := url.Parse(c.Args().First())
 	if err != nil || url.Host == "" {
 		return cli.Exit("Invalid URL", 1)
 	}
 
+	resolveOverrides, err := parseResolveOverrides(c.StringSlice("resolve"))
+	if err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+
+	family := types.IPFamily(c.String("ip_family"))
+	switch family {
+	case types.IPFamilyAny, types.IPFamilyIPv4, types.IPFamilyIPv6, types.IPFamilyBoth:
+	default:
+		return cli.Exit("Invalid IP family", 1)
+	}
+
+	payload, err := strconv.Unquote(`"` + c.String("tcp_payload") + `"`)
+	if err != nil {
+		return cli.Exit("Invalid TCP payload", 1)
+	}
+
+	headers, err := parseHeaders(c.StringSlice("header"))
+	if err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+
+	cookies, err := parseCookies(c.StringSlice("cookie"))
+	if err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+
+	var transaction types.Transaction
+	if path := c.String("transaction"); path != "" {
+		if transaction, err = loadTransaction(path); err != nil {
+			return cli.Exit(fmt.Sprintf("Invalid transaction: %v", err), 1)
+		}
+	}
+
+	checkType := c.String("type")
+	if checkType == "" && len(transaction.Steps) > 0 {
+		checkType = "transaction"
+	}
+
 	checkRequest := &types.CheckRequest{
-		Ref:    "-1",
-		Method: "GET",
-		URL:    url,
-		//Headers:      make(map[string][]string),
-		//Body:         "",
+		Ref:     types.AdHocRef,
+		Type:    checkType,
+		Method:  "GET",
+		URL:     url,
+		Headers: headers,
+		Body:    c.String("body"),
 		Timeout: 15 * time.Second,
 		Options: types.CheckOptions{
-			GetFallback:     true,
-			IgnoreTLSErrors: false,
-			FollowRedirects: true,
+			GetFallback:      true,
+			IgnoreTLSErrors:  false,
+			FollowRedirects:  true,
+			ResolveOverrides: resolveOverrides,
+			IPFamily:         family,
+			HTTPProtocol:     types.HTTPProtocol(c.String("http_protocol")),
+			DNSCheck: types.DNSCheck{
+				Type:             c.String("dns_type"),
+				Expected:         c.StringSlice("dns_expect"),
+				CheckNameservers: c.Bool("dns_check_nameservers"),
+				CheckSOASerial:   c.Bool("dns_check_serial"),
+				DNSSEC:           c.Bool("dnssec"),
+			},
+			TCPCheck: types.TCPCheck{
+				Payload:      []byte(payload),
+				ExpectBanner: c.String("tcp_expect"),
+				TLS:          c.Bool("tcp_tls"),
+			},
+			MailCheck: types.MailCheck{
+				StartTLS:             c.Bool("mail_starttls"),
+				Hostname:             c.String("mail_hostname"),
+				RequiredCapabilities: c.StringSlice("mail_capability"),
+			},
+			WebSocketCheck: types.WebSocketCheck{
+				Message:      c.String("ws_message"),
+				ExpectReply:  c.String("ws_expect"),
+				Subprotocols: c.StringSlice("ws_subprotocol"),
+			},
+			GRPCCheck: types.GRPCCheck{
+				Service: c.String("grpc_service"),
+				TLS:     c.Bool("grpc_tls"),
+			},
+			Transaction: transaction,
+			CookieJar:   c.Bool("cookie_jar"),
+			Cookies:     cookies,
+			HTTPAuth: types.HTTPAuth{
+				Scheme:      types.AuthScheme(c.String("auth")),
+				Credentials: c.String("auth_credentials"),
+				TokenURL:    c.String("auth_token_url"),
+				Scopes:      c.StringSlice("auth_scope"),
+			},
 		},
 	}
 
@@ -61,3 +268,80 @@ func runCheck(c *cli.Context) error {
 
 	return nil
 }
+
+// parseResolveOverrides parses overrides in the same format as curl's
+// --resolve option, host:port:address, where port may be * for any port
+func parseResolveOverrides(values []string) ([]types.ResolveOverride, error) {
+	var res []types.ResolveOverride
+	for _, value := range values {
+		parts := strings.SplitN(value, ":", 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("Invalid resolve override %s", value)
+		}
+
+		override := types.ResolveOverride{
+			Host:    parts[0],
+			Address: strings.Trim(parts[2], "[]"),
+		}
+
+		if parts[1] != "*" {
+			port, err := strconv.Atoi(parts[1])
+			if err != nil {
+				return nil, fmt.Errorf("Invalid resolve override %s", value)
+			}
+
+			override.Port = port
+		}
+
+		if net.ParseIP(override.Address) == nil {
+			return nil, fmt.Errorf("Invalid resolve override %s", value)
+		}
+
+		res = append(res, override)
+	}
+
+	return res, nil
+}
+
+// parseHeaders parses headers in the format Name: value
+func parseHeaders(values []string) (http.Header, error) {
+	res := http.Header{}
+	for _, value := range values {
+		parts := strings.SplitN(value, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("Invalid header %s", value)
+		}
+
+		res.Add(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+	}
+
+	return res, nil
+}
+
+// parseCookies parses cookies in the format name=value
+func parseCookies(values []string) ([]types.Cookie, error) {
+	var res []types.Cookie
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("Invalid cookie %s", value)
+		}
+
+		res = append(res, types.Cookie{Name: parts[0], Value: parts[1]})
+	}
+
+	return res, nil
+}
+
+// loadTransaction reads the steps for a transaction from a JSON file
+func loadTransaction(path string) (types.Transaction, error) {
+	var transaction types.Transaction
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return transaction, err
+	}
+
+	err = json.Unmarshal(data, &transaction)
+	return transaction, err
+}
@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+	log "github.com/sirupsen/logrus"
+	cli "github.com/urfave/cli/v2"
+)
+
+var (
+	ctLogsPath         string
+	clientCertsDir     string
+	secretsDir         string
+	secretsEnvPrefix   string
+	secretsFile        string
+	secretsKey         string
+	caBundle           string
+	replaceSystemRoots bool
+	nameservers        cli.StringSlice
+	resolverTimeout    time.Duration
+	trustAnchorsPath   string
+	proxyURL           string
+	proxyCredentials   string
+	bindAddress        string
+
+	// Agent wide configuration flags, shared by all commands
+	configFlags = []cli.Flag{
+		&cli.StringFlag{
+			Name:        "ct_logs",
+			Usage:       "CT log list (JSON) used to verify SCTs",
+			EnvVars:     []string{"CT_LOGS"},
+			Destination: &ctLogsPath,
+		},
+		&cli.StringFlag{
+			Name:        "client_certs",
+			Usage:       "directory of named client certificates (<name>.crt and <name>.key)",
+			EnvVars:     []string{"CLIENT_CERTS"},
+			Destination: &clientCertsDir,
+		},
+		&cli.StringFlag{
+			Name:        "secrets",
+			Usage:       "directory of named secrets (one file per secret) which checks can reference",
+			EnvVars:     []string{"SECRETS"},
+			Destination: &secretsDir,
+		},
+		&cli.StringFlag{
+			Name:        "secrets_env_prefix",
+			Usage:       "load environment variables with this prefix as named secrets, e.g. SECRET_",
+			EnvVars:     []string{"SECRETS_ENV_PREFIX"},
+			Destination: &secretsEnvPrefix,
+		},
+		&cli.StringFlag{
+			Name:        "secrets_file",
+			Usage:       "file of named secrets created with encrypt-secrets",
+			EnvVars:     []string{"SECRETS_FILE"},
+			Destination: &secretsFile,
+		},
+		&cli.StringFlag{
+			Name:        "secrets_key",
+			Usage:       "key for secrets_file (32 bytes, base64 encoded)",
+			EnvVars:     []string{"SECRETS_KEY"},
+			Destination: &secretsKey,
+		},
+		&cli.StringFlag{
+			Name:        "ca_bundle",
+			Usage:       "additional trusted CAs, as a path or PEM",
+			EnvVars:     []string{"CA_BUNDLE"},
+			Destination: &caBundle,
+		},
+		&cli.BoolFlag{
+			Name:        "replace_system_roots",
+			Usage:       "only trust the CAs in ca_bundle",
+			EnvVars:     []string{"REPLACE_SYSTEM_ROOTS"},
+			Destination: &replaceSystemRoots,
+		},
+		&cli.StringSliceFlag{
+			Name:        "nameserver",
+			Usage:       "nameserver used instead of the system resolver (udp://, tcp://, tls:// or https://)",
+			EnvVars:     []string{"NAMESERVERS"},
+			Destination: &nameservers,
+		},
+		&cli.DurationFlag{
+			Name:        "resolver_timeout",
+			Usage:       "timeout for each DNS query",
+			EnvVars:     []string{"RESOLVER_TIMEOUT"},
+			Destination: &resolverTimeout,
+		},
+		&cli.StringFlag{
+			Name:        "trust_anchors",
+			Usage:       "DNSSEC trust anchors (DS or DNSKEY records), instead of the root zone anchors",
+			EnvVars:     []string{"TRUST_ANCHORS"},
+			Destination: &trustAnchorsPath,
+		},
+		&cli.StringFlag{
+			Name:        "proxy",
+			Usage:       "proxy used for all checks (http://, https://, socks5:// or socks5h://)",
+			EnvVars:     []string{"PROXY"},
+			Destination: &proxyURL,
+		},
+		&cli.StringFlag{
+			Name:        "proxy_credentials",
+			Usage:       "name of the secret with the proxy credentials, as username:password",
+			EnvVars:     []string{"PROXY_CREDENTIALS"},
+			Destination: &proxyCredentials,
+		},
+		&cli.StringFlag{
+			Name:        "bind_address",
+			Usage:       "local IP address or network interface all checks connect from",
+			EnvVars:     []string{"BIND_ADDRESS"},
+			Destination: &bindAddress,
+		},
+	}
+)
+
+// loadSecrets loads the secrets from each store, later stores override
+// secrets with the same name
+func loadSecrets() (map[string]string, error) {
+	secrets := map[string]string{}
+
+	if secretsDir != "" {
+		dirSecrets, err := checker.LoadSecrets(secretsDir)
+		if err != nil {
+			return nil, err
+		}
+
+		mergeSecrets(secrets, dirSecrets)
+	}
+
+	if secretsFile != "" {
+		key, err := checker.ParseSecretsKey(secretsKey)
+		if err != nil {
+			return nil, err
+		}
+
+		fileSecrets, err := checker.LoadEncryptedSecrets(secretsFile, key)
+		if err != nil {
+			return nil, err
+		}
+
+		mergeSecrets(secrets, fileSecrets)
+	}
+
+	if secretsEnvPrefix != "" {
+		mergeSecrets(secrets, checker.SecretsFromEnv(secretsEnvPrefix, os.Environ()))
+	}
+
+	if err := checker.ValidateSecrets(secrets); err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
+
+func mergeSecrets(secrets, other map[string]string) {
+	for name, secret := range other {
+		secrets[name] = secret
+	}
+}
+
+// configure loads the agent wide configuration from the flags
+func configure() error {
+	config := checker.Config{}
+
+	if ctLogsPath != "" {
+		logs, err := checker.LoadCTLogs(ctLogsPath)
+		if err != nil {
+			return err
+		}
+
+		config.CTLogs = logs
+	}
+
+	if clientCertsDir != "" {
+		certs, err := checker.LoadClientCertificates(clientCertsDir)
+		if err != nil {
+			return err
+		}
+
+		config.ClientCertificates = certs
+	}
+
+	secrets, err := loadSecrets()
+	if err != nil {
+		return err
+	}
+	config.Secrets = secrets
+
+	// Secrets must never be logged, even if they're echoed by the server
+	log.AddHook(checker.RedactHook{})
+
+	if caBundle != "" {
+		data := []byte(caBundle)
+
+		// Value is either a path or the PEM itself
+		if !strings.HasPrefix(strings.TrimSpace(caBundle), "-----BEGIN") {
+			var err error
+			if data, err = ioutil.ReadFile(caBundle); err != nil {
+				return err
+			}
+		}
+
+		pool, err := checker.ParseCAPool(data)
+		if err != nil {
+			return err
+		}
+
+		config.CAs = pool
+	}
+	config.ReplaceSystemRoots = replaceSystemRoots
+
+	config.Resolver = types.Resolver{
+		Nameservers: nameservers.Value(),
+		Timeout:     resolverTimeout,
+	}
+	if err := checker.ValidateResolver(config.Resolver); err != nil {
+		return err
+	}
+
+	if trustAnchorsPath != "" {
+		anchors, err := checker.LoadTrustAnchors(trustAnchorsPath)
+		if err != nil {
+			return err
+		}
+
+		config.TrustAnchors = anchors
+	}
+
+	config.Proxy = types.Proxy{
+		URL:         proxyURL,
+		Credentials: proxyCredentials,
+	}
+	config.BindAddress = bindAddress
+
+	checker.Configure(config)
+
+	return nil
+}
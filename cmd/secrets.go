@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	cli "github.com/urfave/cli/v2"
+)
+
+var (
+	// EncryptSecrets command
+	EncryptSecrets = &cli.Command{
+		Name:      "encrypt-secrets",
+		Usage:     "encrypt a JSON object of named secrets for use with secrets_file",
+		ArgsUsage: "secrets.json",
+		Action:    runEncryptSecrets,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "key",
+				Usage:   "key used to encrypt the secrets (32 bytes, base64 encoded)",
+				EnvVars: []string{"SECRETS_KEY"},
+			},
+			&cli.BoolFlag{
+				Name:  "generate_key",
+				Usage: "generate a new key, which is written to stderr",
+			},
+		},
+	}
+)
+
+func runEncryptSecrets(c *cli.Context) error {
+	data, err := ioutil.ReadFile(c.Args().First())
+	if err != nil {
+		return cli.Exit(fmt.Sprintf("Unable to read secrets: %v", err), 1)
+	}
+
+	var secrets map[string]string
+	if err := json.Unmarshal(data, &secrets); err != nil {
+		return cli.Exit(fmt.Sprintf("Invalid secrets: %v", err), 1)
+	}
+
+	if err := checker.ValidateSecrets(secrets); err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+
+	var key []byte
+	if c.Bool("generate_key") {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return cli.Exit(fmt.Sprintf("Unable to generate key: %v", err), 1)
+		}
+
+		fmt.Fprintln(os.Stderr, base64.StdEncoding.EncodeToString(key))
+	} else if key, err = checker.ParseSecretsKey(c.String("key")); err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+
+	encrypted, err := checker.EncryptSecrets(secrets, key)
+	if err != nil {
+		return cli.Exit(fmt.Sprintf("Unable to encrypt secrets: %v", err), 1)
+	}
+
+	fmt.Println(string(encrypted))
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/lucaspiller/watchsumo-checker/checker"
 	"github.com/lucaspiller/watchsumo-checker/metrics"
 	pb "github.com/lucaspiller/watchsumo-checker/proto"
@@ -22,6 +23,9 @@ import (
 )
 
 const (
+	// Replacement for private values when logging requests
+	redacted = "[REDACTED]"
+
 	// MaxHeaderKeyLength truncate header keys to this size
 	MaxHeaderKeyLength = 50
 
@@ -43,7 +47,7 @@ var (
 	Start = &cli.Command{
 		Name:   "start",
 		Action: runStart,
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			&cli.StringFlag{
 				Name:        "server",
 				Usage:       "grpc server to connect to",
@@ -74,7 +78,7 @@ var (
 				EnvVars:     []string{"APP_ENV"},
 				Destination: &env,
 			},
-		},
+		}, configFlags...),
 	}
 )
 
@@ -87,6 +91,10 @@ func truncate(s string, length int) string {
 }
 
 func durationToMs(d *time.Duration) int32 {
+	if d == nil {
+		return 0
+	}
+
 	return int32(d.Milliseconds())
 }
 
@@ -103,10 +111,629 @@ func encodeHeaders(headers http.Header) []*pb.Header {
 	return res
 }
 
+func encodeRenderedRequest(request *types.RenderedRequest) *pb.CheckResponse_Request {
+	if request == nil {
+		return nil
+	}
+
+	return &pb.CheckResponse_Request{
+		Method:  request.Method,
+		Url:     request.URL,
+		Headers: encodeHeaders(request.Headers),
+		Body:    truncate(request.Body, MaxBodyLength),
+	}
+}
+
 func encodeTimestamp(timestamp *time.Time) string {
+	if timestamp == nil {
+		return ""
+	}
+
 	return timestamp.Format(time.RFC3339)
 }
 
+func decodeCertPolicy(policy *pb.CheckRequest_CertPolicy) types.CertPolicy {
+	if policy == nil {
+		return types.CertPolicy{}
+	}
+
+	return types.CertPolicy{
+		ExpiryWarningDays:            int(policy.ExpiryWarningDays),
+		MinRSAKeySize:                int(policy.MinRsaKeySize),
+		MinECDSAKeySize:              int(policy.MinEcdsaKeySize),
+		ForbiddenSignatureAlgorithms: policy.ForbiddenSignatureAlgorithms,
+		RequiredIssuer:               policy.RequiredIssuer,
+		MaxValidityDays:              int(policy.MaxValidityDays),
+		MinSCTs:                      int(policy.MinScts),
+	}
+}
+
+func decodeCertPins(pins *pb.CheckRequest_CertPins) types.CertPins {
+	if pins == nil {
+		return types.CertPins{}
+	}
+
+	return types.CertPins{
+		LeafSHA256:     pins.LeafSHA256,
+		SPKISHA256:     pins.SpkiSHA256,
+		NotifyChange:   pins.NotifyChange,
+		PreviousSHA256: pins.PreviousSHA256,
+	}
+}
+
+func decodeTLSPolicy(policy *pb.CheckRequest_TLSPolicy) types.TLSPolicy {
+	if policy == nil {
+		return types.TLSPolicy{}
+	}
+
+	var suites []uint16
+	for _, suite := range policy.ForbiddenCipherSuites {
+		suites = append(suites, uint16(suite))
+	}
+
+	return types.TLSPolicy{
+		MinVersion:                 uint16(policy.MinVersion),
+		ForbiddenCipherSuites:      suites,
+		ForbidInsecureCipherSuites: policy.ForbidInsecureCipherSuites,
+		RequireALPN:                policy.RequireAlpn,
+		RequireOCSPStapling:        policy.RequireOcspStapling,
+		RequireSessionResumption:   policy.RequireSessionResumption,
+	}
+}
+
+func decodeClientCertificate(cert *pb.CheckRequest_ClientCertificate) types.ClientCertificate {
+	if cert == nil {
+		return types.ClientCertificate{}
+	}
+
+	return types.ClientCertificate{
+		Name:    cert.Name,
+		CertPEM: cert.Cert,
+		KeyPEM:  cert.Key,
+	}
+}
+
+func decodeTrustedCAs(cas *pb.CheckRequest_TrustedCAs) types.TrustedCAs {
+	if cas == nil {
+		return types.TrustedCAs{}
+	}
+
+	return types.TrustedCAs{
+		PEM:                cas.Pem,
+		ReplaceSystemRoots: cas.ReplaceSystemRoots,
+	}
+}
+
+func decodeResolveOverrides(overrides []*pb.CheckRequest_ResolveOverride) []types.ResolveOverride {
+	var res []types.ResolveOverride
+	for _, override := range overrides {
+		res = append(res, types.ResolveOverride{
+			Host:    override.Host,
+			Port:    int(override.Port),
+			Address: override.Address,
+		})
+	}
+
+	return res
+}
+
+func decodeIPFamily(family pb.CheckRequest_IPFamily) types.IPFamily {
+	switch family {
+	case pb.CheckRequest_IPV4:
+		return types.IPFamilyIPv4
+	case pb.CheckRequest_IPV6:
+		return types.IPFamilyIPv6
+	case pb.CheckRequest_BOTH:
+		return types.IPFamilyBoth
+	default:
+		return types.IPFamilyAny
+	}
+}
+
+func decodeHTTPProtocol(protocol pb.CheckRequest_HTTPProtocol) types.HTTPProtocol {
+	switch protocol {
+	case pb.CheckRequest_HTTP1:
+		return types.HTTPProtocolHTTP1
+	case pb.CheckRequest_H2:
+		return types.HTTPProtocolHTTP2
+	case pb.CheckRequest_H2C:
+		return types.HTTPProtocolH2C
+	case pb.CheckRequest_H3:
+		return types.HTTPProtocolHTTP3
+	default:
+		return types.HTTPProtocolAny
+	}
+}
+
+func decodeResolver(resolver *pb.CheckRequest_Resolver) types.Resolver {
+	if resolver == nil {
+		return types.Resolver{}
+	}
+
+	return types.Resolver{
+		Nameservers: resolver.Nameservers,
+		Timeout:     time.Duration(resolver.Timeout) * time.Millisecond,
+	}
+}
+
+func decodeDNSCheck(check *pb.CheckRequest_DNSCheck) types.DNSCheck {
+	if check == nil {
+		return types.DNSCheck{}
+	}
+
+	return types.DNSCheck{
+		Type:             check.Type,
+		Expected:         check.Expected,
+		ExactMatch:       check.ExactMatch,
+		CheckNameservers: check.CheckNameservers,
+		CheckSOASerial:   check.CheckSoaSerial,
+		Nameservers:      check.Nameservers,
+		DNSSEC:           check.Dnssec,
+		TrustAnchors:     check.TrustAnchors,
+
+		SignatureExpiryWarningDays: int(check.SignatureExpiryWarningDays),
+	}
+}
+
+func decodeTCPCheck(check *pb.CheckRequest_TCPCheck) types.TCPCheck {
+	if check == nil {
+		return types.TCPCheck{}
+	}
+
+	return types.TCPCheck{
+		Payload:      check.Payload,
+		ExpectBanner: check.ExpectBanner,
+		TLS:          check.Tls,
+	}
+}
+
+func decodeMailCheck(check *pb.CheckRequest_MailCheck) types.MailCheck {
+	if check == nil {
+		return types.MailCheck{}
+	}
+
+	return types.MailCheck{
+		StartTLS:             check.StartTls,
+		Hostname:             check.Hostname,
+		RequiredCapabilities: check.RequiredCapabilities,
+	}
+}
+
+func encodeMailReport(report *types.MailReport) *pb.CheckResponse_MailReport {
+	if report == nil {
+		return nil
+	}
+
+	return &pb.CheckResponse_MailReport{
+		Greeting:     report.Greeting,
+		Capabilities: report.Capabilities,
+		StartTls:     report.StartTLS,
+	}
+}
+
+func decodeWebSocketCheck(check *pb.CheckRequest_WebSocketCheck) types.WebSocketCheck {
+	if check == nil {
+		return types.WebSocketCheck{}
+	}
+
+	return types.WebSocketCheck{
+		Message:      check.Message,
+		ExpectReply:  check.ExpectReply,
+		ReplyTimeout: time.Duration(check.ReplyTimeout) * time.Millisecond,
+		Subprotocols: check.Subprotocols,
+	}
+}
+
+func encodeWebSocketReport(report *types.WebSocketReport) *pb.CheckResponse_WebSocketReport {
+	if report == nil {
+		return nil
+	}
+
+	return &pb.CheckResponse_WebSocketReport{
+		Subprotocol: report.Subprotocol,
+		Reply:       truncate(report.Reply, MaxBodyLength),
+		ReplyTime:   durationToMs(report.ReplyTime),
+		CleanClose:  report.CleanClose,
+	}
+}
+
+func decodeGRPCCheck(check *pb.CheckRequest_GRPCCheck) types.GRPCCheck {
+	if check == nil {
+		return types.GRPCCheck{}
+	}
+
+	return types.GRPCCheck{
+		Service: check.Service,
+		TLS:     check.Tls,
+	}
+}
+
+func decodeHTTPAuth(auth *pb.CheckRequest_HTTPAuth) types.HTTPAuth {
+	if auth == nil {
+		return types.HTTPAuth{}
+	}
+
+	return types.HTTPAuth{
+		Scheme:      types.AuthScheme(auth.Scheme),
+		Credentials: auth.Credentials,
+		TokenURL:    auth.TokenUrl,
+		Scopes:      auth.Scopes,
+	}
+}
+
+func decodeProxy(proxy *pb.CheckRequest_Proxy) types.Proxy {
+	if proxy == nil {
+		return types.Proxy{}
+	}
+
+	return types.Proxy{
+		URL:         proxy.Url,
+		Credentials: proxy.Credentials,
+	}
+}
+
+func encodeGRPCReport(report *types.GRPCReport) *pb.CheckResponse_GRPCReport {
+	if report == nil {
+		return nil
+	}
+
+	return &pb.CheckResponse_GRPCReport{
+		Service: report.Service,
+		Status:  report.Status,
+		Code:    report.Code,
+	}
+}
+
+func decodeHeaders(headers []*pb.Header) http.Header {
+	res := http.Header{}
+
+	for _, header := range headers {
+		res.Add(header.Key, header.Value)
+	}
+
+	return res
+}
+
+func decodeCookies(cookies []*pb.Cookie) []types.Cookie {
+	var res []types.Cookie
+
+	for _, cookie := range cookies {
+		res = append(res, types.Cookie{
+			Name:  cookie.Name,
+			Value: cookie.Value,
+		})
+	}
+
+	return res
+}
+
+func encodeCookies(cookies []types.SetCookie) []*pb.CheckResponse_SetCookie {
+	var res []*pb.CheckResponse_SetCookie
+
+	for _, cookie := range cookies {
+		res = append(res, &pb.CheckResponse_SetCookie{
+			Url:      cookie.URL,
+			Name:     cookie.Name,
+			Domain:   cookie.Domain,
+			Path:     cookie.Path,
+			Expires:  encodeTimestamp(cookie.Expires),
+			MaxAge:   int32(cookie.MaxAge),
+			Secure:   cookie.Secure,
+			HttpOnly: cookie.HTTPOnly,
+			SameSite: cookie.SameSite,
+		})
+	}
+
+	return res
+}
+
+func decodeAssertions(assertions []*pb.Assertion) []types.Assertion {
+	var res []types.Assertion
+
+	for _, assertion := range assertions {
+		res = append(res, types.Assertion{
+			Source:     types.AssertionSource(assertion.Source),
+			Expression: assertion.Expression,
+			Pattern:    assertion.Pattern,
+		})
+	}
+
+	return res
+}
+
+func decodeTransaction(transaction *pb.CheckRequest_Transaction) types.Transaction {
+	if transaction == nil {
+		return types.Transaction{}
+	}
+
+	var steps []types.TransactionStep
+	for _, step := range transaction.Steps {
+		var extract []types.Extraction
+		for _, extraction := range step.Extract {
+			extract = append(extract, types.Extraction{
+				Name:       extraction.Name,
+				Source:     types.ExtractionSource(extraction.Source),
+				Expression: extraction.Expression,
+			})
+		}
+
+		steps = append(steps, types.TransactionStep{
+			Name:       step.Name,
+			Method:     step.Method,
+			URL:        step.Url,
+			Headers:    decodeHeaders(step.Headers),
+			Body:       step.Body,
+			Extract:    extract,
+			Assertions: decodeAssertions(step.Assertions),
+		})
+	}
+
+	return types.Transaction{Steps: steps}
+}
+
+func encodeTransaction(steps []types.TransactionStepResult) []*pb.CheckResponse_TransactionStep {
+	var res []*pb.CheckResponse_TransactionStep
+
+	for _, step := range steps {
+		var assertions []*pb.CheckResponse_AssertionResult
+		for _, assertion := range step.Assertions {
+			assertions = append(assertions, &pb.CheckResponse_AssertionResult{
+				Assertion: &pb.Assertion{
+					Source:     string(assertion.Source),
+					Expression: assertion.Expression,
+					Pattern:    assertion.Pattern,
+				},
+				Value:  truncate(assertion.Value, MaxHeaderValueLength),
+				Passed: assertion.Passed,
+			})
+		}
+
+		res = append(res, &pb.CheckResponse_TransactionStep{
+			Name:       step.Name,
+			Method:     step.Method,
+			Url:        step.URL,
+			StatusCode: int32(step.StatusCode),
+			Time:       durationToMs(step.Time),
+			Timing:     encodeTiming(step.Timing),
+			Assertions: assertions,
+			Error:      step.Error,
+		})
+	}
+
+	return res
+}
+
+func encodeDNSReport(report *types.DNSReport) *pb.CheckResponse_DNSReport {
+	if report == nil {
+		return nil
+	}
+
+	res := &pb.CheckResponse_DNSReport{
+		Name:     report.Name,
+		Type:     report.Type,
+		Resolver: report.Resolver,
+		Records:  report.Records,
+	}
+
+	if report.DNSSEC != nil {
+		res.Dnssec = &pb.CheckResponse_DNSReport_DNSSEC{
+			Status: report.DNSSEC.Status,
+		}
+
+		for _, sig := range report.DNSSEC.Signatures {
+			res.Dnssec.Signatures = append(res.Dnssec.Signatures, &pb.CheckResponse_DNSReport_DNSSEC_Signature{
+				Name:       sig.Name,
+				Type:       sig.Type,
+				Signer:     sig.Signer,
+				KeyTag:     uint32(sig.KeyTag),
+				Inception:  encodeTimestamp(&sig.Inception),
+				Expiration: encodeTimestamp(&sig.Expiration),
+			})
+		}
+	}
+
+	for _, ns := range report.Nameservers {
+		res.Nameservers = append(res.Nameservers, &pb.CheckResponse_DNSReport_Nameserver{
+			Name:    ns.Name,
+			Address: ns.Address,
+			Records: ns.Records,
+			Serial:  ns.Serial,
+			Error:   ns.Error,
+		})
+	}
+
+	return res
+}
+
+func encodeDNS(dns *types.DNSResolution) *pb.CheckResponse_DNS {
+	if dns == nil {
+		return nil
+	}
+
+	return &pb.CheckResponse_DNS{
+		Host:      dns.Host,
+		Resolver:  dns.Resolver,
+		Rcode:     dns.Rcode,
+		Addresses: dns.Addresses,
+	}
+}
+
+func encodeTLSScan(report *types.TLSScanReport) *pb.CheckResponse_TLSScan {
+	if report == nil {
+		return nil
+	}
+
+	res := &pb.CheckResponse_TLSScan{
+		Alpn:              report.ALPN,
+		OcspStapling:      report.OCSPStapling,
+		SessionResumption: report.SessionResumption,
+	}
+
+	for _, version := range report.Versions {
+		res.Versions = append(res.Versions, &pb.CheckResponse_TLSScan_Version{
+			Version:      version.Version,
+			Supported:    version.Supported,
+			CipherSuites: version.CipherSuites,
+		})
+	}
+
+	return res
+}
+
+// encodeResult converts the result to a response, without the reference
+func encodeResult(res *types.CheckResult) *pb.CheckResponse {
+	if res == nil {
+		return nil
+	}
+
+	var responseStatus pb.Status
+	switch res.Status {
+	case types.StatusUp:
+		responseStatus = pb.Status_UP
+	case types.StatusDown:
+		responseStatus = pb.Status_DOWN
+	default:
+		responseStatus = pb.Status_UNKNOWN
+	}
+
+	response := &pb.CheckResponse{
+		Status:        responseStatus,
+		Method:        res.Method,
+		Url:           res.URL.String(),
+		StatusCode:    int32(res.StatusCode),
+		Headers:       encodeHeaders(res.Headers),
+		Body:          truncate(res.Body, MaxBodyLength),
+		Time:          durationToMs(res.Time),
+		Error:         res.Error,
+		Timestamp:     encodeTimestamp(res.Timestamp),
+		Proto:         res.Proto,
+		StatusText:    res.StatusText,
+		Warnings:      res.Warnings,
+		TlsScan:       encodeTLSScan(res.TLSScan),
+		Dns:           encodeDNS(res.DNS),
+		DnsReport:     encodeDNSReport(res.DNSReport),
+		Mail:          encodeMailReport(res.Mail),
+		WebSocket:     encodeWebSocketReport(res.WebSocket),
+		Grpc:          encodeGRPCReport(res.GRPC),
+		Alpn:          res.ALPN,
+		AltSvc:        res.AltSvc,
+		Transaction:   encodeTransaction(res.Transaction),
+		Cookies:       encodeCookies(res.Cookies),
+		Request:       encodeRenderedRequest(res.Request),
+		Family:        string(res.Family),
+		SourceAddress: res.SourceAddress,
+		Ipv4:          encodeResult(res.IPv4),
+		Ipv6:          encodeResult(res.IPv6),
+	}
+
+	if res.Certificate != nil {
+		response.Certificate = &pb.CheckResponse_Certificate{
+			SerialString:      res.Certificate.SerialString,
+			Algorithm:         int32(res.Certificate.Algorithm),
+			ValidFrom:         encodeTimestamp(&res.Certificate.ValidFrom),
+			ValidTo:           encodeTimestamp(&res.Certificate.ValidTo),
+			Subject:           res.Certificate.Subject,
+			Issuer:            res.Certificate.Issuer,
+			FingerprintSHA256: res.Certificate.FingerprintSHA256,
+			Serial:            res.Certificate.Serial,
+			RevocationStatus:  string(res.Certificate.RevocationStatus),
+			RevocationSource:  string(res.Certificate.RevocationSource),
+			SpkiSHA256:        res.Certificate.SPKISHA256,
+
+			PreviousFingerprintSHA256: res.Certificate.PreviousFingerprintSHA256,
+			Scts:                      encodeSCTs(res.Certificate.SCTs),
+		}
+	}
+
+	response.Timing = encodeTiming(res.Timing)
+
+	return response
+}
+
+func encodeTiming(timing *types.RequestTiming) *pb.CheckResponse_Timing {
+	if timing == nil {
+		return nil
+	}
+
+	return &pb.CheckResponse_Timing{
+		Dns:        durationToMs(timing.DNS),
+		Connecting: durationToMs(timing.Connecting),
+		Proxy:      durationToMs(timing.Proxy),
+		Tls:        durationToMs(timing.TLS),
+		Sending:    durationToMs(timing.Sending),
+		Waiting:    durationToMs(timing.Waiting),
+		Receiving:  durationToMs(timing.Receiving),
+	}
+}
+
+func encodeSCTs(scts []types.SCTInfo) []*pb.CheckResponse_SCT {
+	var res []*pb.CheckResponse_SCT
+
+	for _, sct := range scts {
+		res = append(res, &pb.CheckResponse_SCT{
+			LogId:     sct.LogID,
+			Log:       sct.Log,
+			Timestamp: encodeTimestamp(&sct.Timestamp),
+			Source:    sct.Source,
+			Verified:  sct.Verified,
+		})
+	}
+
+	return res
+}
+
+// redactRequest returns a copy of the request for logging, without private
+// keys, credentials, or header, body and cookie values, which may contain
+// credentials given literally. Secrets from the store are redacted by the log
+// hook.
+func redactRequest(request *pb.CheckRequest) *pb.CheckRequest {
+	res := proto.Clone(request).(*pb.CheckRequest)
+
+	redactHeaders(res.RequestHeaders)
+	redactString(&res.RequestBody)
+
+	if res.Options == nil {
+		return res
+	}
+
+	if res.Options.ClientCertificate != nil && len(res.Options.ClientCertificate.Key) > 0 {
+		res.Options.ClientCertificate.Key = []byte(redacted)
+	}
+
+	for _, cookie := range res.Options.Cookies {
+		cookie.Value = redacted
+	}
+
+	if res.Options.HttpAuth != nil {
+		redactString(&res.Options.HttpAuth.Credentials)
+	}
+
+	if res.Options.Proxy != nil {
+		redactString(&res.Options.Proxy.Credentials)
+	}
+
+	if res.Options.Transaction != nil {
+		for _, step := range res.Options.Transaction.Steps {
+			redactHeaders(step.Headers)
+			redactString(&step.Body)
+		}
+	}
+
+	return res
+}
+
+func redactHeaders(headers []*pb.Header) {
+	for _, header := range headers {
+		header.Value = redacted
+	}
+}
+
+func redactString(s *string) {
+	if *s != "" {
+		*s = redacted
+	}
+}
+
 func startClient(client pb.CheckerServiceClient) {
 	ctx := context.Background()
 	stream, err := client.Listen(ctx, &pb.CheckerHello{
@@ -129,7 +756,7 @@ func startClient(client pb.CheckerServiceClient) {
 			break
 		}
 
-		log.Debug(fmt.Sprintf("< %+v", request))
+		log.Debug(fmt.Sprintf("< %+v", redactRequest(request)))
 
 		go func() {
 			var ref string
@@ -151,68 +778,47 @@ func startClient(client pb.CheckerServiceClient) {
 
 			checkRequest := &types.CheckRequest{
 				Ref:     ref,
+				Type:    request.Type,
 				Method:  request.Method,
 				URL:     url,
+				Headers: decodeHeaders(request.RequestHeaders),
+				Body:    request.RequestBody,
 				Timeout: time.Duration(request.Timeout) * time.Millisecond,
 				Options: types.CheckOptions{
-					GetFallback:     request.Options.GetFallback,
-					IgnoreTLSErrors: request.Options.IgnoreTlsErrors,
-					FollowRedirects: request.Options.FollowRedirects,
+					GetFallback:       request.Options.GetFallback,
+					IgnoreTLSErrors:   request.Options.IgnoreTlsErrors,
+					FollowRedirects:   request.Options.FollowRedirects,
+					CertPolicy:        decodeCertPolicy(request.Options.CertPolicy),
+					CheckRevocation:   request.Options.CheckRevocation,
+					CertPins:          decodeCertPins(request.Options.CertPins),
+					CheckSCTs:         request.Options.CheckScts,
+					TLSPolicy:         decodeTLSPolicy(request.Options.TlsPolicy),
+					ClientCertificate: decodeClientCertificate(request.Options.ClientCertificate),
+					TrustedCAs:        decodeTrustedCAs(request.Options.TrustedCas),
+					ResolveOverrides:  decodeResolveOverrides(request.Options.ResolveOverrides),
+					Resolver:          decodeResolver(request.Options.Resolver),
+					IPFamily:          decodeIPFamily(request.Options.IpFamily),
+					HTTPProtocol:      decodeHTTPProtocol(request.Options.HttpProtocol),
+					Transaction:       decodeTransaction(request.Options.Transaction),
+					CookieJar:         request.Options.CookieJar,
+					Cookies:           decodeCookies(request.Options.Cookies),
+					HTTPAuth:          decodeHTTPAuth(request.Options.HttpAuth),
+					Proxy:             decodeProxy(request.Options.Proxy),
+					BindAddress:       request.Options.BindAddress,
+					DNSCheck:          decodeDNSCheck(request.Options.DnsCheck),
+					TCPCheck:          decodeTCPCheck(request.Options.TcpCheck),
+					MailCheck:         decodeMailCheck(request.Options.MailCheck),
+					WebSocketCheck:    decodeWebSocketCheck(request.Options.WebSocketCheck),
+					GRPCCheck:         decodeGRPCCheck(request.Options.GrpcCheck),
 				},
 			}
 
 			checker := checker.Init(checkRequest)
 			checker.Perform()
 
-			var responseStatus pb.Status
-			switch checker.Res.Status {
-			case types.StatusUp:
-				responseStatus = pb.Status_UP
-			case types.StatusDown:
-				responseStatus = pb.Status_DOWN
-			default:
-				responseStatus = pb.Status_UNKNOWN
-			}
-
-			response := &pb.CheckResponse{
-				MonitoringId: request.MonitoringId,
-				Caller:       request.Caller,
-				Status:       responseStatus,
-				Method:       checker.Res.Method,
-				Url:          checker.Res.URL.String(),
-				StatusCode:   int32(checker.Res.StatusCode),
-				Headers:      encodeHeaders(checker.Res.Headers),
-				Body:         truncate(checker.Res.Body, MaxBodyLength),
-				Time:         durationToMs(checker.Res.Time),
-				Error:        checker.Res.Error,
-				Timestamp:    encodeTimestamp(checker.Res.Timestamp),
-				Proto:        checker.Res.Proto,
-				StatusText:   checker.Res.StatusText,
-			}
-
-			if checker.Res.Certificate != nil {
-				response.Certificate = &pb.CheckResponse_Certificate{
-					SerialString:      checker.Res.Certificate.SerialString,
-					Algorithm:         int32(checker.Res.Certificate.Algorithm),
-					ValidFrom:         encodeTimestamp(&checker.Res.Certificate.ValidFrom),
-					ValidTo:           encodeTimestamp(&checker.Res.Certificate.ValidTo),
-					Subject:           checker.Res.Certificate.Subject,
-					Issuer:            checker.Res.Certificate.Issuer,
-					FingerprintSHA256: checker.Res.Certificate.FingerprintSHA256,
-					Serial:            checker.Res.Certificate.Serial,
-				}
-			}
-
-			if checker.Res.Timing != nil {
-				response.Timing = &pb.CheckResponse_Timing{
-					Dns:        durationToMs(checker.Res.Timing.DNS),
-					Connecting: durationToMs(checker.Res.Timing.Connecting),
-					Tls:        durationToMs(checker.Res.Timing.TLS),
-					Sending:    durationToMs(checker.Res.Timing.Sending),
-					Waiting:    durationToMs(checker.Res.Timing.Waiting),
-					Receiving:  durationToMs(checker.Res.Timing.Receiving),
-				}
-			}
+			response := encodeResult(checker.Res)
+			response.MonitoringId = request.MonitoringId
+			response.Caller = request.Caller
 
 			log.WithFields(log.Fields{
 				"Ref":    ref,
@@ -233,6 +839,10 @@ func startClient(client pb.CheckerServiceClient) {
 }
 
 func runStart(c *cli.Context) error {
+	if err := configure(); err != nil {
+		return cli.Exit(fmt.Sprintf("Invalid configuration: %v", err), 1)
+	}
+
 	if env == "development" {
 		log.SetLevel(log.DebugLevel)
 
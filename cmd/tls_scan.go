@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lucaspiller/watchsumo-checker/checker"
+	"github.com/lucaspiller/watchsumo-checker/types"
+	log "github.com/sirupsen/logrus"
+	cli "github.com/urfave/cli/v2"
+)
+
+var (
+	tlsVersionNames = map[string]uint16{
+		"1.0": tls.VersionTLS10,
+		"1.1": tls.VersionTLS11,
+		"1.2": tls.VersionTLS12,
+		"1.3": tls.VersionTLS13,
+	}
+
+	// TLSScan command
+	TLSScan = &cli.Command{
+		Name:      "tls-scan",
+		Usage:     "audit the TLS configuration of a server",
+		ArgsUsage: "host[:port]",
+		Action:    runTLSScan,
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:  "min_version",
+				Usage: "minimum TLS version the server may accept, e.g. 1.2",
+			},
+			&cli.BoolFlag{
+				Name:  "forbid_insecure_ciphers",
+				Usage: "fail if the server accepts insecure cipher suites",
+			},
+			&cli.StringSliceFlag{
+				Name:  "require_alpn",
+				Usage: "ALPN protocol the server must support, e.g. h2",
+			},
+			&cli.BoolFlag{
+				Name:  "require_ocsp_stapling",
+				Usage: "fail if the server doesn't staple an OCSP response",
+			},
+			&cli.BoolFlag{
+				Name:  "require_session_resumption",
+				Usage: "fail if the server doesn't support session resumption",
+			},
+		}, configFlags...),
+	}
+)
+
+func runTLSScan(c *cli.Context) error {
+	// Enable debugging
+	log.SetLevel(log.DebugLevel)
+
+	if err := configure(); err != nil {
+		return cli.Exit(fmt.Sprintf("Invalid configuration: %v", err), 1)
+	}
+
+	// Parse host, which may also be given as a URL
+	target := c.Args().First()
+	if !strings.Contains(target, "://") {
+		target = "tls://" + target
+	}
+
+	url, err := url.Parse(target)
+	if err != nil || url.Host == "" {
+		return cli.Exit("Invalid host", 1)
+	}
+	url.Scheme = "tls"
+
+	policy := types.TLSPolicy{
+		ForbidInsecureCipherSuites: c.Bool("forbid_insecure_ciphers"),
+		RequireALPN:                c.StringSlice("require_alpn"),
+		RequireOCSPStapling:        c.Bool("require_ocsp_stapling"),
+		RequireSessionResumption:   c.Bool("require_session_resumption"),
+	}
+
+	if name := c.String("min_version"); name != "" {
+		version, ok := tlsVersionNames[name]
+		if !ok {
+			return cli.Exit("Invalid TLS version", 1)
+		}
+
+		policy.MinVersion = version
+	}
+
+	checkRequest := &types.CheckRequest{
+		Ref:     types.AdHocRef,
+		URL:     url,
+		Timeout: 15 * time.Second,
+		Options: types.CheckOptions{
+			TLSPolicy: policy,
+		},
+	}
+
+	checker := checker.Init(checkRequest)
+	checker.Perform()
+
+	if checker.Success {
+		log.Info("TLS configuration PASSED")
+	} else {
+		log.WithFields(log.Fields{
+			"error": checker.Res.Error,
+		}).Info("TLS configuration FAILED")
+	}
+
+	spew.Dump(checker.Res)
+
+	return nil
+}
@@ -28,6 +28,8 @@ func main() {
 	app.Commands = []*cli.Command{
 		cmd.Check,
 		cmd.Start,
+		cmd.TLSScan,
+		cmd.EncryptSecrets,
 	}
 	app.Run(os.Args)
 }
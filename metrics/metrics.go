@@ -11,6 +11,7 @@ import (
 var (
 	atomicUp        uint32
 	atomicDown      uint32
+	atomicUnknown   uint32
 	atomicCheckTime uint64
 )
 
@@ -24,6 +25,11 @@ func AddDown() {
 	atomic.AddUint32(&atomicDown, 1)
 }
 
+// AddUnknown increments unknown counter
+func AddUnknown() {
+	atomic.AddUint32(&atomicUnknown, 1)
+}
+
 // AddCheckTime adds check time
 func AddCheckTime(d time.Duration) {
 	atomic.AddUint64(&atomicCheckTime, uint64(d.Milliseconds()))
@@ -46,9 +52,10 @@ func Start(interval time.Duration) {
 func logMetrics() {
 	up := atomic.SwapUint32(&atomicUp, 0)
 	down := atomic.SwapUint32(&atomicDown, 0)
+	unknown := atomic.SwapUint32(&atomicUnknown, 0)
 	checkTime := atomic.SwapUint64(&atomicCheckTime, 0)
 
-	checks := up + down
+	checks := up + down + unknown
 	var avg uint64
 	if checks > 0 {
 		avg = checkTime / uint64(checks)
@@ -56,5 +63,5 @@ func logMetrics() {
 
 	goroutines := runtime.NumGoroutine()
 
-	log.Info(fmt.Sprintf("metrics checks=%d up=%d down=%d avg=%dms goroutines=%d", checks, up, down, avg, goroutines))
+	log.Info(fmt.Sprintf("metrics checks=%d up=%d down=%d unknown=%d avg=%dms goroutines=%d", checks, up, down, unknown, avg, goroutines))
 }
@@ -52,6 +52,71 @@ func (Status) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_3493b4c64b96a37f, []int{0}
 }
 
+type CheckRequest_IPFamily int32
+
+const (
+	CheckRequest_ANY  CheckRequest_IPFamily = 0
+	CheckRequest_IPV4 CheckRequest_IPFamily = 1
+	CheckRequest_IPV6 CheckRequest_IPFamily = 2
+	CheckRequest_BOTH CheckRequest_IPFamily = 3
+)
+
+var CheckRequest_IPFamily_name = map[int32]string{
+	0: "ANY",
+	1: "IPV4",
+	2: "IPV6",
+	3: "BOTH",
+}
+
+var CheckRequest_IPFamily_value = map[string]int32{
+	"ANY":  0,
+	"IPV4": 1,
+	"IPV6": 2,
+	"BOTH": 3,
+}
+
+func (x CheckRequest_IPFamily) String() string {
+	return proto.EnumName(CheckRequest_IPFamily_name, int32(x))
+}
+
+func (CheckRequest_IPFamily) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{5, 0}
+}
+
+type CheckRequest_HTTPProtocol int32
+
+const (
+	CheckRequest_ANY_PROTOCOL CheckRequest_HTTPProtocol = 0
+	CheckRequest_HTTP1        CheckRequest_HTTPProtocol = 1
+	CheckRequest_H2           CheckRequest_HTTPProtocol = 2
+	CheckRequest_H2C          CheckRequest_HTTPProtocol = 3
+	CheckRequest_H3           CheckRequest_HTTPProtocol = 4
+)
+
+var CheckRequest_HTTPProtocol_name = map[int32]string{
+	0: "ANY_PROTOCOL",
+	1: "HTTP1",
+	2: "H2",
+	3: "H2C",
+	4: "H3",
+}
+
+var CheckRequest_HTTPProtocol_value = map[string]int32{
+	"ANY_PROTOCOL": 0,
+	"HTTP1":        1,
+	"H2":           2,
+	"H2C":          3,
+	"H3":           4,
+}
+
+func (x CheckRequest_HTTPProtocol) String() string {
+	return proto.EnumName(CheckRequest_HTTPProtocol_name, int32(x))
+}
+
+func (CheckRequest_HTTPProtocol) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{5, 1}
+}
+
 type Void struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -185,13 +250,118 @@ func (m *Header) GetValue() string {
 	return ""
 }
 
+type Cookie struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Cookie) Reset()         { *m = Cookie{} }
+func (m *Cookie) String() string { return proto.CompactTextString(m) }
+func (*Cookie) ProtoMessage()    {}
+func (*Cookie) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{3}
+}
+
+func (m *Cookie) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Cookie.Unmarshal(m, b)
+}
+func (m *Cookie) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Cookie.Marshal(b, m, deterministic)
+}
+func (m *Cookie) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Cookie.Merge(m, src)
+}
+func (m *Cookie) XXX_Size() int {
+	return xxx_messageInfo_Cookie.Size(m)
+}
+func (m *Cookie) XXX_DiscardUnknown() {
+	xxx_messageInfo_Cookie.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Cookie proto.InternalMessageInfo
+
+func (m *Cookie) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Cookie) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type Assertion struct {
+	Source               string   `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Expression           string   `protobuf:"bytes,2,opt,name=expression,proto3" json:"expression,omitempty"`
+	Pattern              string   `protobuf:"bytes,3,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Assertion) Reset()         { *m = Assertion{} }
+func (m *Assertion) String() string { return proto.CompactTextString(m) }
+func (*Assertion) ProtoMessage()    {}
+func (*Assertion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{4}
+}
+
+func (m *Assertion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Assertion.Unmarshal(m, b)
+}
+func (m *Assertion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Assertion.Marshal(b, m, deterministic)
+}
+func (m *Assertion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Assertion.Merge(m, src)
+}
+func (m *Assertion) XXX_Size() int {
+	return xxx_messageInfo_Assertion.Size(m)
+}
+func (m *Assertion) XXX_DiscardUnknown() {
+	xxx_messageInfo_Assertion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Assertion proto.InternalMessageInfo
+
+func (m *Assertion) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *Assertion) GetExpression() string {
+	if m != nil {
+		return m.Expression
+	}
+	return ""
+}
+
+func (m *Assertion) GetPattern() string {
+	if m != nil {
+		return m.Pattern
+	}
+	return ""
+}
+
 type CheckRequest struct {
 	Caller               string                `protobuf:"bytes,1,opt,name=caller,proto3" json:"caller,omitempty"`
 	MonitoringId         string                `protobuf:"bytes,2,opt,name=monitoringId,proto3" json:"monitoringId,omitempty"`
 	Method               string                `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
 	Url                  string                `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	RequestHeaders       []*Header             `protobuf:"bytes,5,rep,name=requestHeaders,proto3" json:"requestHeaders,omitempty"`
+	RequestBody          string                `protobuf:"bytes,6,opt,name=requestBody,proto3" json:"requestBody,omitempty"`
 	Timeout              int32                 `protobuf:"varint,7,opt,name=timeout,proto3" json:"timeout,omitempty"`
 	Options              *CheckRequest_Options `protobuf:"bytes,9,opt,name=options,proto3" json:"options,omitempty"`
+	Type                 string                `protobuf:"bytes,10,opt,name=type,proto3" json:"type,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
 	XXX_sizecache        int32                 `json:"-"`
@@ -201,7 +371,7 @@ func (m *CheckRequest) Reset()         { *m = CheckRequest{} }
 func (m *CheckRequest) String() string { return proto.CompactTextString(m) }
 func (*CheckRequest) ProtoMessage()    {}
 func (*CheckRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3493b4c64b96a37f, []int{3}
+	return fileDescriptor_3493b4c64b96a37f, []int{5}
 }
 
 func (m *CheckRequest) XXX_Unmarshal(b []byte) error {
@@ -250,6 +420,20 @@ func (m *CheckRequest) GetUrl() string {
 	return ""
 }
 
+func (m *CheckRequest) GetRequestHeaders() []*Header {
+	if m != nil {
+		return m.RequestHeaders
+	}
+	return nil
+}
+
+func (m *CheckRequest) GetRequestBody() string {
+	if m != nil {
+		return m.RequestBody
+	}
+	return ""
+}
+
 func (m *CheckRequest) GetTimeout() int32 {
 	if m != nil {
 		return m.Timeout
@@ -264,20 +448,49 @@ func (m *CheckRequest) GetOptions() *CheckRequest_Options {
 	return nil
 }
 
+func (m *CheckRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
 type CheckRequest_Options struct {
-	GetFallback          bool     `protobuf:"varint,1,opt,name=getFallback,proto3" json:"getFallback,omitempty"`
-	IgnoreTlsErrors      bool     `protobuf:"varint,2,opt,name=ignoreTlsErrors,proto3" json:"ignoreTlsErrors,omitempty"`
-	FollowRedirects      bool     `protobuf:"varint,3,opt,name=followRedirects,proto3" json:"followRedirects,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	GetFallback          bool                            `protobuf:"varint,1,opt,name=getFallback,proto3" json:"getFallback,omitempty"`
+	IgnoreTlsErrors      bool                            `protobuf:"varint,2,opt,name=ignoreTlsErrors,proto3" json:"ignoreTlsErrors,omitempty"`
+	FollowRedirects      bool                            `protobuf:"varint,3,opt,name=followRedirects,proto3" json:"followRedirects,omitempty"`
+	CertPolicy           *CheckRequest_CertPolicy        `protobuf:"bytes,4,opt,name=certPolicy,proto3" json:"certPolicy,omitempty"`
+	CheckRevocation      bool                            `protobuf:"varint,5,opt,name=checkRevocation,proto3" json:"checkRevocation,omitempty"`
+	CertPins             *CheckRequest_CertPins          `protobuf:"bytes,6,opt,name=certPins,proto3" json:"certPins,omitempty"`
+	CheckScts            bool                            `protobuf:"varint,7,opt,name=checkScts,proto3" json:"checkScts,omitempty"`
+	TlsPolicy            *CheckRequest_TLSPolicy         `protobuf:"bytes,8,opt,name=tlsPolicy,proto3" json:"tlsPolicy,omitempty"`
+	ClientCertificate    *CheckRequest_ClientCertificate `protobuf:"bytes,9,opt,name=clientCertificate,proto3" json:"clientCertificate,omitempty"`
+	TrustedCas           *CheckRequest_TrustedCAs        `protobuf:"bytes,10,opt,name=trustedCas,proto3" json:"trustedCas,omitempty"`
+	ResolveOverrides     []*CheckRequest_ResolveOverride `protobuf:"bytes,11,rep,name=resolveOverrides,proto3" json:"resolveOverrides,omitempty"`
+	Resolver             *CheckRequest_Resolver          `protobuf:"bytes,12,opt,name=resolver,proto3" json:"resolver,omitempty"`
+	IpFamily             CheckRequest_IPFamily           `protobuf:"varint,13,opt,name=ipFamily,proto3,enum=ws.grpc.CheckRequest_IPFamily" json:"ipFamily,omitempty"`
+	DnsCheck             *CheckRequest_DNSCheck          `protobuf:"bytes,14,opt,name=dnsCheck,proto3" json:"dnsCheck,omitempty"`
+	TcpCheck             *CheckRequest_TCPCheck          `protobuf:"bytes,15,opt,name=tcpCheck,proto3" json:"tcpCheck,omitempty"`
+	MailCheck            *CheckRequest_MailCheck         `protobuf:"bytes,16,opt,name=mailCheck,proto3" json:"mailCheck,omitempty"`
+	WebSocketCheck       *CheckRequest_WebSocketCheck    `protobuf:"bytes,17,opt,name=webSocketCheck,proto3" json:"webSocketCheck,omitempty"`
+	GrpcCheck            *CheckRequest_GRPCCheck         `protobuf:"bytes,18,opt,name=grpcCheck,proto3" json:"grpcCheck,omitempty"`
+	HttpProtocol         CheckRequest_HTTPProtocol       `protobuf:"varint,19,opt,name=httpProtocol,proto3,enum=ws.grpc.CheckRequest_HTTPProtocol" json:"httpProtocol,omitempty"`
+	Transaction          *CheckRequest_Transaction       `protobuf:"bytes,20,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	CookieJar            bool                            `protobuf:"varint,21,opt,name=cookieJar,proto3" json:"cookieJar,omitempty"`
+	Cookies              []*Cookie                       `protobuf:"bytes,22,rep,name=cookies,proto3" json:"cookies,omitempty"`
+	HttpAuth             *CheckRequest_HTTPAuth          `protobuf:"bytes,23,opt,name=httpAuth,proto3" json:"httpAuth,omitempty"`
+	Proxy                *CheckRequest_Proxy             `protobuf:"bytes,24,opt,name=proxy,proto3" json:"proxy,omitempty"`
+	BindAddress          string                          `protobuf:"bytes,25,opt,name=bindAddress,proto3" json:"bindAddress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
 }
 
 func (m *CheckRequest_Options) Reset()         { *m = CheckRequest_Options{} }
 func (m *CheckRequest_Options) String() string { return proto.CompactTextString(m) }
 func (*CheckRequest_Options) ProtoMessage()    {}
 func (*CheckRequest_Options) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3493b4c64b96a37f, []int{3, 0}
+	return fileDescriptor_3493b4c64b96a37f, []int{5, 0}
 }
 
 func (m *CheckRequest_Options) XXX_Unmarshal(b []byte) error {
@@ -319,397 +532,2977 @@ func (m *CheckRequest_Options) GetFollowRedirects() bool {
 	return false
 }
 
-type CheckResponse struct {
-	Caller       string `protobuf:"bytes,1,opt,name=caller,proto3" json:"caller,omitempty"`
-	MonitoringId string `protobuf:"bytes,2,opt,name=monitoringId,proto3" json:"monitoringId,omitempty"`
-	//string regionId = 3;
-	Status               Status                     `protobuf:"varint,4,opt,name=status,proto3,enum=ws.grpc.Status" json:"status,omitempty"`
-	Method               string                     `protobuf:"bytes,5,opt,name=method,proto3" json:"method,omitempty"`
-	Url                  string                     `protobuf:"bytes,6,opt,name=url,proto3" json:"url,omitempty"`
-	StatusCode           int32                      `protobuf:"varint,7,opt,name=statusCode,proto3" json:"statusCode,omitempty"`
-	Headers              []*Header                  `protobuf:"bytes,8,rep,name=headers,proto3" json:"headers,omitempty"`
-	Body                 string                     `protobuf:"bytes,9,opt,name=body,proto3" json:"body,omitempty"`
-	Time                 int32                      `protobuf:"varint,10,opt,name=time,proto3" json:"time,omitempty"`
-	Certificate          *CheckResponse_Certificate `protobuf:"bytes,11,opt,name=certificate,proto3" json:"certificate,omitempty"`
-	Timing               *CheckResponse_Timing      `protobuf:"bytes,12,opt,name=timing,proto3" json:"timing,omitempty"`
-	Error                string                     `protobuf:"bytes,14,opt,name=error,proto3" json:"error,omitempty"`
-	Timestamp            string                     `protobuf:"bytes,15,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Proto                string                     `protobuf:"bytes,16,opt,name=proto,proto3" json:"proto,omitempty"`
-	StatusText           string                     `protobuf:"bytes,17,opt,name=statusText,proto3" json:"statusText,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
-	XXX_unrecognized     []byte                     `json:"-"`
-	XXX_sizecache        int32                      `json:"-"`
+func (m *CheckRequest_Options) GetCertPolicy() *CheckRequest_CertPolicy {
+	if m != nil {
+		return m.CertPolicy
+	}
+	return nil
 }
 
-func (m *CheckResponse) Reset()         { *m = CheckResponse{} }
-func (m *CheckResponse) String() string { return proto.CompactTextString(m) }
-func (*CheckResponse) ProtoMessage()    {}
-func (*CheckResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3493b4c64b96a37f, []int{4}
+func (m *CheckRequest_Options) GetCheckRevocation() bool {
+	if m != nil {
+		return m.CheckRevocation
+	}
+	return false
 }
 
-func (m *CheckResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CheckResponse.Unmarshal(m, b)
-}
-func (m *CheckResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CheckResponse.Marshal(b, m, deterministic)
+func (m *CheckRequest_Options) GetCertPins() *CheckRequest_CertPins {
+	if m != nil {
+		return m.CertPins
+	}
+	return nil
 }
-func (m *CheckResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CheckResponse.Merge(m, src)
+
+func (m *CheckRequest_Options) GetCheckScts() bool {
+	if m != nil {
+		return m.CheckScts
+	}
+	return false
 }
-func (m *CheckResponse) XXX_Size() int {
-	return xxx_messageInfo_CheckResponse.Size(m)
+
+func (m *CheckRequest_Options) GetTlsPolicy() *CheckRequest_TLSPolicy {
+	if m != nil {
+		return m.TlsPolicy
+	}
+	return nil
 }
-func (m *CheckResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_CheckResponse.DiscardUnknown(m)
+
+func (m *CheckRequest_Options) GetClientCertificate() *CheckRequest_ClientCertificate {
+	if m != nil {
+		return m.ClientCertificate
+	}
+	return nil
 }
 
-var xxx_messageInfo_CheckResponse proto.InternalMessageInfo
+func (m *CheckRequest_Options) GetTrustedCas() *CheckRequest_TrustedCAs {
+	if m != nil {
+		return m.TrustedCas
+	}
+	return nil
+}
 
-func (m *CheckResponse) GetCaller() string {
+func (m *CheckRequest_Options) GetResolveOverrides() []*CheckRequest_ResolveOverride {
 	if m != nil {
-		return m.Caller
+		return m.ResolveOverrides
 	}
-	return ""
+	return nil
 }
 
-func (m *CheckResponse) GetMonitoringId() string {
+func (m *CheckRequest_Options) GetResolver() *CheckRequest_Resolver {
 	if m != nil {
-		return m.MonitoringId
+		return m.Resolver
 	}
-	return ""
+	return nil
 }
 
-func (m *CheckResponse) GetStatus() Status {
+func (m *CheckRequest_Options) GetIpFamily() CheckRequest_IPFamily {
 	if m != nil {
-		return m.Status
+		return m.IpFamily
 	}
-	return Status_UP
+	return CheckRequest_ANY
 }
 
-func (m *CheckResponse) GetMethod() string {
+func (m *CheckRequest_Options) GetDnsCheck() *CheckRequest_DNSCheck {
 	if m != nil {
-		return m.Method
+		return m.DnsCheck
 	}
-	return ""
+	return nil
 }
 
-func (m *CheckResponse) GetUrl() string {
+func (m *CheckRequest_Options) GetTcpCheck() *CheckRequest_TCPCheck {
 	if m != nil {
-		return m.Url
+		return m.TcpCheck
 	}
-	return ""
+	return nil
 }
 
-func (m *CheckResponse) GetStatusCode() int32 {
+func (m *CheckRequest_Options) GetMailCheck() *CheckRequest_MailCheck {
 	if m != nil {
-		return m.StatusCode
+		return m.MailCheck
 	}
-	return 0
+	return nil
 }
 
-func (m *CheckResponse) GetHeaders() []*Header {
+func (m *CheckRequest_Options) GetWebSocketCheck() *CheckRequest_WebSocketCheck {
 	if m != nil {
-		return m.Headers
+		return m.WebSocketCheck
 	}
 	return nil
 }
 
-func (m *CheckResponse) GetBody() string {
+func (m *CheckRequest_Options) GetGrpcCheck() *CheckRequest_GRPCCheck {
 	if m != nil {
-		return m.Body
+		return m.GrpcCheck
 	}
-	return ""
+	return nil
 }
 
-func (m *CheckResponse) GetTime() int32 {
+func (m *CheckRequest_Options) GetHttpProtocol() CheckRequest_HTTPProtocol {
 	if m != nil {
-		return m.Time
+		return m.HttpProtocol
 	}
-	return 0
+	return CheckRequest_ANY_PROTOCOL
 }
 
-func (m *CheckResponse) GetCertificate() *CheckResponse_Certificate {
+func (m *CheckRequest_Options) GetTransaction() *CheckRequest_Transaction {
 	if m != nil {
-		return m.Certificate
+		return m.Transaction
 	}
 	return nil
 }
 
-func (m *CheckResponse) GetTiming() *CheckResponse_Timing {
+func (m *CheckRequest_Options) GetCookieJar() bool {
 	if m != nil {
-		return m.Timing
+		return m.CookieJar
+	}
+	return false
+}
+
+func (m *CheckRequest_Options) GetCookies() []*Cookie {
+	if m != nil {
+		return m.Cookies
 	}
 	return nil
 }
 
-func (m *CheckResponse) GetError() string {
+func (m *CheckRequest_Options) GetHttpAuth() *CheckRequest_HTTPAuth {
 	if m != nil {
-		return m.Error
+		return m.HttpAuth
 	}
-	return ""
+	return nil
 }
 
-func (m *CheckResponse) GetTimestamp() string {
+func (m *CheckRequest_Options) GetProxy() *CheckRequest_Proxy {
 	if m != nil {
-		return m.Timestamp
+		return m.Proxy
 	}
-	return ""
+	return nil
 }
 
-func (m *CheckResponse) GetProto() string {
+func (m *CheckRequest_Options) GetBindAddress() string {
 	if m != nil {
-		return m.Proto
+		return m.BindAddress
 	}
 	return ""
 }
 
-func (m *CheckResponse) GetStatusText() string {
+type CheckRequest_Transaction struct {
+	Steps                []*CheckRequest_Transaction_Step `protobuf:"bytes,1,rep,name=steps,proto3" json:"steps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
+}
+
+func (m *CheckRequest_Transaction) Reset()         { *m = CheckRequest_Transaction{} }
+func (m *CheckRequest_Transaction) String() string { return proto.CompactTextString(m) }
+func (*CheckRequest_Transaction) ProtoMessage()    {}
+func (*CheckRequest_Transaction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{5, 1}
+}
+
+func (m *CheckRequest_Transaction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckRequest_Transaction.Unmarshal(m, b)
+}
+func (m *CheckRequest_Transaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckRequest_Transaction.Marshal(b, m, deterministic)
+}
+func (m *CheckRequest_Transaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckRequest_Transaction.Merge(m, src)
+}
+func (m *CheckRequest_Transaction) XXX_Size() int {
+	return xxx_messageInfo_CheckRequest_Transaction.Size(m)
+}
+func (m *CheckRequest_Transaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckRequest_Transaction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckRequest_Transaction proto.InternalMessageInfo
+
+func (m *CheckRequest_Transaction) GetSteps() []*CheckRequest_Transaction_Step {
 	if m != nil {
-		return m.StatusText
+		return m.Steps
 	}
-	return ""
+	return nil
 }
 
-type CheckResponse_Certificate struct {
-	SerialString         string   `protobuf:"bytes,1,opt,name=serialString,proto3" json:"serialString,omitempty"`
-	Algorithm            int32    `protobuf:"varint,2,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
-	ValidFrom            string   `protobuf:"bytes,3,opt,name=validFrom,proto3" json:"validFrom,omitempty"`
-	ValidTo              string   `protobuf:"bytes,4,opt,name=validTo,proto3" json:"validTo,omitempty"`
-	Subject              string   `protobuf:"bytes,5,opt,name=subject,proto3" json:"subject,omitempty"`
-	Issuer               string   `protobuf:"bytes,6,opt,name=issuer,proto3" json:"issuer,omitempty"`
-	FingerprintSHA256    []byte   `protobuf:"bytes,7,opt,name=fingerprintSHA256,proto3" json:"fingerprintSHA256,omitempty"`
-	Serial               []byte   `protobuf:"bytes,8,opt,name=serial,proto3" json:"serial,omitempty"`
+type CheckRequest_Transaction_Extraction struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Source               string   `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Expression           string   `protobuf:"bytes,3,opt,name=expression,proto3" json:"expression,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CheckResponse_Certificate) Reset()         { *m = CheckResponse_Certificate{} }
-func (m *CheckResponse_Certificate) String() string { return proto.CompactTextString(m) }
-func (*CheckResponse_Certificate) ProtoMessage()    {}
-func (*CheckResponse_Certificate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3493b4c64b96a37f, []int{4, 0}
+func (m *CheckRequest_Transaction_Extraction) Reset()         { *m = CheckRequest_Transaction_Extraction{} }
+func (m *CheckRequest_Transaction_Extraction) String() string { return proto.CompactTextString(m) }
+func (*CheckRequest_Transaction_Extraction) ProtoMessage()    {}
+func (*CheckRequest_Transaction_Extraction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{5, 1, 0}
 }
 
-func (m *CheckResponse_Certificate) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CheckResponse_Certificate.Unmarshal(m, b)
+func (m *CheckRequest_Transaction_Extraction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckRequest_Transaction_Extraction.Unmarshal(m, b)
 }
-func (m *CheckResponse_Certificate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CheckResponse_Certificate.Marshal(b, m, deterministic)
+func (m *CheckRequest_Transaction_Extraction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckRequest_Transaction_Extraction.Marshal(b, m, deterministic)
 }
-func (m *CheckResponse_Certificate) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CheckResponse_Certificate.Merge(m, src)
+func (m *CheckRequest_Transaction_Extraction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckRequest_Transaction_Extraction.Merge(m, src)
 }
-func (m *CheckResponse_Certificate) XXX_Size() int {
-	return xxx_messageInfo_CheckResponse_Certificate.Size(m)
+func (m *CheckRequest_Transaction_Extraction) XXX_Size() int {
+	return xxx_messageInfo_CheckRequest_Transaction_Extraction.Size(m)
 }
-func (m *CheckResponse_Certificate) XXX_DiscardUnknown() {
-	xxx_messageInfo_CheckResponse_Certificate.DiscardUnknown(m)
+func (m *CheckRequest_Transaction_Extraction) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckRequest_Transaction_Extraction.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CheckResponse_Certificate proto.InternalMessageInfo
+var xxx_messageInfo_CheckRequest_Transaction_Extraction proto.InternalMessageInfo
 
-func (m *CheckResponse_Certificate) GetSerialString() string {
+func (m *CheckRequest_Transaction_Extraction) GetName() string {
 	if m != nil {
-		return m.SerialString
+		return m.Name
 	}
 	return ""
 }
 
-func (m *CheckResponse_Certificate) GetAlgorithm() int32 {
+func (m *CheckRequest_Transaction_Extraction) GetSource() string {
 	if m != nil {
-		return m.Algorithm
+		return m.Source
 	}
-	return 0
+	return ""
 }
 
-func (m *CheckResponse_Certificate) GetValidFrom() string {
+func (m *CheckRequest_Transaction_Extraction) GetExpression() string {
 	if m != nil {
-		return m.ValidFrom
+		return m.Expression
 	}
 	return ""
 }
 
-func (m *CheckResponse_Certificate) GetValidTo() string {
+type CheckRequest_Transaction_Step struct {
+	Name                 string                                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Method               string                                 `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Url                  string                                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	Headers              []*Header                              `protobuf:"bytes,4,rep,name=headers,proto3" json:"headers,omitempty"`
+	Body                 string                                 `protobuf:"bytes,5,opt,name=body,proto3" json:"body,omitempty"`
+	Extract              []*CheckRequest_Transaction_Extraction `protobuf:"bytes,6,rep,name=extract,proto3" json:"extract,omitempty"`
+	Assertions           []*Assertion                           `protobuf:"bytes,7,rep,name=assertions,proto3" json:"assertions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                               `json:"-"`
+	XXX_unrecognized     []byte                                 `json:"-"`
+	XXX_sizecache        int32                                  `json:"-"`
+}
+
+func (m *CheckRequest_Transaction_Step) Reset()         { *m = CheckRequest_Transaction_Step{} }
+func (m *CheckRequest_Transaction_Step) String() string { return proto.CompactTextString(m) }
+func (*CheckRequest_Transaction_Step) ProtoMessage()    {}
+func (*CheckRequest_Transaction_Step) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{5, 1, 1}
+}
+
+func (m *CheckRequest_Transaction_Step) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckRequest_Transaction_Step.Unmarshal(m, b)
+}
+func (m *CheckRequest_Transaction_Step) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckRequest_Transaction_Step.Marshal(b, m, deterministic)
+}
+func (m *CheckRequest_Transaction_Step) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckRequest_Transaction_Step.Merge(m, src)
+}
+func (m *CheckRequest_Transaction_Step) XXX_Size() int {
+	return xxx_messageInfo_CheckRequest_Transaction_Step.Size(m)
+}
+func (m *CheckRequest_Transaction_Step) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckRequest_Transaction_Step.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckRequest_Transaction_Step proto.InternalMessageInfo
+
+func (m *CheckRequest_Transaction_Step) GetName() string {
 	if m != nil {
-		return m.ValidTo
+		return m.Name
 	}
 	return ""
 }
 
-func (m *CheckResponse_Certificate) GetSubject() string {
+func (m *CheckRequest_Transaction_Step) GetMethod() string {
 	if m != nil {
-		return m.Subject
+		return m.Method
 	}
 	return ""
 }
 
-func (m *CheckResponse_Certificate) GetIssuer() string {
+func (m *CheckRequest_Transaction_Step) GetUrl() string {
 	if m != nil {
-		return m.Issuer
+		return m.Url
 	}
 	return ""
 }
 
-func (m *CheckResponse_Certificate) GetFingerprintSHA256() []byte {
+func (m *CheckRequest_Transaction_Step) GetHeaders() []*Header {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *CheckRequest_Transaction_Step) GetBody() string {
+	if m != nil {
+		return m.Body
+	}
+	return ""
+}
+
+func (m *CheckRequest_Transaction_Step) GetExtract() []*CheckRequest_Transaction_Extraction {
+	if m != nil {
+		return m.Extract
+	}
+	return nil
+}
+
+func (m *CheckRequest_Transaction_Step) GetAssertions() []*Assertion {
+	if m != nil {
+		return m.Assertions
+	}
+	return nil
+}
+
+type CheckRequest_GRPCCheck struct {
+	Service              string   `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Tls                  bool     `protobuf:"varint,2,opt,name=tls,proto3" json:"tls,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckRequest_GRPCCheck) Reset()         { *m = CheckRequest_GRPCCheck{} }
+func (m *CheckRequest_GRPCCheck) String() string { return proto.CompactTextString(m) }
+func (*CheckRequest_GRPCCheck) ProtoMessage()    {}
+func (*CheckRequest_GRPCCheck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{5, 2}
+}
+
+func (m *CheckRequest_GRPCCheck) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckRequest_GRPCCheck.Unmarshal(m, b)
+}
+func (m *CheckRequest_GRPCCheck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckRequest_GRPCCheck.Marshal(b, m, deterministic)
+}
+func (m *CheckRequest_GRPCCheck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckRequest_GRPCCheck.Merge(m, src)
+}
+func (m *CheckRequest_GRPCCheck) XXX_Size() int {
+	return xxx_messageInfo_CheckRequest_GRPCCheck.Size(m)
+}
+func (m *CheckRequest_GRPCCheck) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckRequest_GRPCCheck.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckRequest_GRPCCheck proto.InternalMessageInfo
+
+func (m *CheckRequest_GRPCCheck) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *CheckRequest_GRPCCheck) GetTls() bool {
+	if m != nil {
+		return m.Tls
+	}
+	return false
+}
+
+type CheckRequest_WebSocketCheck struct {
+	Message              string   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	ExpectReply          string   `protobuf:"bytes,2,opt,name=expectReply,proto3" json:"expectReply,omitempty"`
+	ReplyTimeout         int32    `protobuf:"varint,3,opt,name=replyTimeout,proto3" json:"replyTimeout,omitempty"`
+	Subprotocols         []string `protobuf:"bytes,4,rep,name=subprotocols,proto3" json:"subprotocols,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckRequest_WebSocketCheck) Reset()         { *m = CheckRequest_WebSocketCheck{} }
+func (m *CheckRequest_WebSocketCheck) String() string { return proto.CompactTextString(m) }
+func (*CheckRequest_WebSocketCheck) ProtoMessage()    {}
+func (*CheckRequest_WebSocketCheck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{5, 3}
+}
+
+func (m *CheckRequest_WebSocketCheck) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckRequest_WebSocketCheck.Unmarshal(m, b)
+}
+func (m *CheckRequest_WebSocketCheck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckRequest_WebSocketCheck.Marshal(b, m, deterministic)
+}
+func (m *CheckRequest_WebSocketCheck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckRequest_WebSocketCheck.Merge(m, src)
+}
+func (m *CheckRequest_WebSocketCheck) XXX_Size() int {
+	return xxx_messageInfo_CheckRequest_WebSocketCheck.Size(m)
+}
+func (m *CheckRequest_WebSocketCheck) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckRequest_WebSocketCheck.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckRequest_WebSocketCheck proto.InternalMessageInfo
+
+func (m *CheckRequest_WebSocketCheck) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *CheckRequest_WebSocketCheck) GetExpectReply() string {
+	if m != nil {
+		return m.ExpectReply
+	}
+	return ""
+}
+
+func (m *CheckRequest_WebSocketCheck) GetReplyTimeout() int32 {
+	if m != nil {
+		return m.ReplyTimeout
+	}
+	return 0
+}
+
+func (m *CheckRequest_WebSocketCheck) GetSubprotocols() []string {
+	if m != nil {
+		return m.Subprotocols
+	}
+	return nil
+}
+
+type CheckRequest_MailCheck struct {
+	StartTls             bool     `protobuf:"varint,1,opt,name=startTls,proto3" json:"startTls,omitempty"`
+	Hostname             string   `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	RequiredCapabilities []string `protobuf:"bytes,3,rep,name=requiredCapabilities,proto3" json:"requiredCapabilities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckRequest_MailCheck) Reset()         { *m = CheckRequest_MailCheck{} }
+func (m *CheckRequest_MailCheck) String() string { return proto.CompactTextString(m) }
+func (*CheckRequest_MailCheck) ProtoMessage()    {}
+func (*CheckRequest_MailCheck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{5, 4}
+}
+
+func (m *CheckRequest_MailCheck) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckRequest_MailCheck.Unmarshal(m, b)
+}
+func (m *CheckRequest_MailCheck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckRequest_MailCheck.Marshal(b, m, deterministic)
+}
+func (m *CheckRequest_MailCheck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckRequest_MailCheck.Merge(m, src)
+}
+func (m *CheckRequest_MailCheck) XXX_Size() int {
+	return xxx_messageInfo_CheckRequest_MailCheck.Size(m)
+}
+func (m *CheckRequest_MailCheck) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckRequest_MailCheck.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckRequest_MailCheck proto.InternalMessageInfo
+
+func (m *CheckRequest_MailCheck) GetStartTls() bool {
+	if m != nil {
+		return m.StartTls
+	}
+	return false
+}
+
+func (m *CheckRequest_MailCheck) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *CheckRequest_MailCheck) GetRequiredCapabilities() []string {
+	if m != nil {
+		return m.RequiredCapabilities
+	}
+	return nil
+}
+
+type CheckRequest_TCPCheck struct {
+	Payload              []byte   `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	ExpectBanner         string   `protobuf:"bytes,2,opt,name=expectBanner,proto3" json:"expectBanner,omitempty"`
+	Tls                  bool     `protobuf:"varint,3,opt,name=tls,proto3" json:"tls,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckRequest_TCPCheck) Reset()         { *m = CheckRequest_TCPCheck{} }
+func (m *CheckRequest_TCPCheck) String() string { return proto.CompactTextString(m) }
+func (*CheckRequest_TCPCheck) ProtoMessage()    {}
+func (*CheckRequest_TCPCheck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{5, 5}
+}
+
+func (m *CheckRequest_TCPCheck) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckRequest_TCPCheck.Unmarshal(m, b)
+}
+func (m *CheckRequest_TCPCheck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckRequest_TCPCheck.Marshal(b, m, deterministic)
+}
+func (m *CheckRequest_TCPCheck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckRequest_TCPCheck.Merge(m, src)
+}
+func (m *CheckRequest_TCPCheck) XXX_Size() int {
+	return xxx_messageInfo_CheckRequest_TCPCheck.Size(m)
+}
+func (m *CheckRequest_TCPCheck) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckRequest_TCPCheck.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckRequest_TCPCheck proto.InternalMessageInfo
+
+func (m *CheckRequest_TCPCheck) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *CheckRequest_TCPCheck) GetExpectBanner() string {
+	if m != nil {
+		return m.ExpectBanner
+	}
+	return ""
+}
+
+func (m *CheckRequest_TCPCheck) GetTls() bool {
+	if m != nil {
+		return m.Tls
+	}
+	return false
+}
+
+type CheckRequest_DNSCheck struct {
+	Type                       string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Expected                   []string `protobuf:"bytes,2,rep,name=expected,proto3" json:"expected,omitempty"`
+	ExactMatch                 bool     `protobuf:"varint,3,opt,name=exactMatch,proto3" json:"exactMatch,omitempty"`
+	CheckNameservers           bool     `protobuf:"varint,4,opt,name=checkNameservers,proto3" json:"checkNameservers,omitempty"`
+	CheckSoaSerial             bool     `protobuf:"varint,5,opt,name=checkSoaSerial,proto3" json:"checkSoaSerial,omitempty"`
+	Nameservers                []string `protobuf:"bytes,6,rep,name=nameservers,proto3" json:"nameservers,omitempty"`
+	Dnssec                     bool     `protobuf:"varint,7,opt,name=dnssec,proto3" json:"dnssec,omitempty"`
+	TrustAnchors               []string `protobuf:"bytes,8,rep,name=trustAnchors,proto3" json:"trustAnchors,omitempty"`
+	SignatureExpiryWarningDays int32    `protobuf:"varint,9,opt,name=signatureExpiryWarningDays,proto3" json:"signatureExpiryWarningDays,omitempty"`
+	XXX_NoUnkeyedLiteral       struct{} `json:"-"`
+	XXX_unrecognized           []byte   `json:"-"`
+	XXX_sizecache              int32    `json:"-"`
+}
+
+func (m *CheckRequest_DNSCheck) Reset()         { *m = CheckRequest_DNSCheck{} }
+func (m *CheckRequest_DNSCheck) String() string { return proto.CompactTextString(m) }
+func (*CheckRequest_DNSCheck) ProtoMessage()    {}
+func (*CheckRequest_DNSCheck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{5, 6}
+}
+
+func (m *CheckRequest_DNSCheck) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckRequest_DNSCheck.Unmarshal(m, b)
+}
+func (m *CheckRequest_DNSCheck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckRequest_DNSCheck.Marshal(b, m, deterministic)
+}
+func (m *CheckRequest_DNSCheck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckRequest_DNSCheck.Merge(m, src)
+}
+func (m *CheckRequest_DNSCheck) XXX_Size() int {
+	return xxx_messageInfo_CheckRequest_DNSCheck.Size(m)
+}
+func (m *CheckRequest_DNSCheck) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckRequest_DNSCheck.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckRequest_DNSCheck proto.InternalMessageInfo
+
+func (m *CheckRequest_DNSCheck) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *CheckRequest_DNSCheck) GetExpected() []string {
+	if m != nil {
+		return m.Expected
+	}
+	return nil
+}
+
+func (m *CheckRequest_DNSCheck) GetExactMatch() bool {
+	if m != nil {
+		return m.ExactMatch
+	}
+	return false
+}
+
+func (m *CheckRequest_DNSCheck) GetCheckNameservers() bool {
+	if m != nil {
+		return m.CheckNameservers
+	}
+	return false
+}
+
+func (m *CheckRequest_DNSCheck) GetCheckSoaSerial() bool {
+	if m != nil {
+		return m.CheckSoaSerial
+	}
+	return false
+}
+
+func (m *CheckRequest_DNSCheck) GetNameservers() []string {
+	if m != nil {
+		return m.Nameservers
+	}
+	return nil
+}
+
+func (m *CheckRequest_DNSCheck) GetDnssec() bool {
+	if m != nil {
+		return m.Dnssec
+	}
+	return false
+}
+
+func (m *CheckRequest_DNSCheck) GetTrustAnchors() []string {
+	if m != nil {
+		return m.TrustAnchors
+	}
+	return nil
+}
+
+func (m *CheckRequest_DNSCheck) GetSignatureExpiryWarningDays() int32 {
+	if m != nil {
+		return m.SignatureExpiryWarningDays
+	}
+	return 0
+}
+
+type CheckRequest_ClientCertificate struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Cert                 []byte   `protobuf:"bytes,2,opt,name=cert,proto3" json:"cert,omitempty"`
+	Key                  []byte   `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckRequest_ClientCertificate) Reset()         { *m = CheckRequest_ClientCertificate{} }
+func (m *CheckRequest_ClientCertificate) String() string { return proto.CompactTextString(m) }
+func (*CheckRequest_ClientCertificate) ProtoMessage()    {}
+func (*CheckRequest_ClientCertificate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{5, 7}
+}
+
+func (m *CheckRequest_ClientCertificate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckRequest_ClientCertificate.Unmarshal(m, b)
+}
+func (m *CheckRequest_ClientCertificate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckRequest_ClientCertificate.Marshal(b, m, deterministic)
+}
+func (m *CheckRequest_ClientCertificate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckRequest_ClientCertificate.Merge(m, src)
+}
+func (m *CheckRequest_ClientCertificate) XXX_Size() int {
+	return xxx_messageInfo_CheckRequest_ClientCertificate.Size(m)
+}
+func (m *CheckRequest_ClientCertificate) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckRequest_ClientCertificate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckRequest_ClientCertificate proto.InternalMessageInfo
+
+func (m *CheckRequest_ClientCertificate) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CheckRequest_ClientCertificate) GetCert() []byte {
+	if m != nil {
+		return m.Cert
+	}
+	return nil
+}
+
+func (m *CheckRequest_ClientCertificate) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+type CheckRequest_TrustedCAs struct {
+	Pem                  []byte   `protobuf:"bytes,1,opt,name=pem,proto3" json:"pem,omitempty"`
+	ReplaceSystemRoots   bool     `protobuf:"varint,2,opt,name=replaceSystemRoots,proto3" json:"replaceSystemRoots,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckRequest_TrustedCAs) Reset()         { *m = CheckRequest_TrustedCAs{} }
+func (m *CheckRequest_TrustedCAs) String() string { return proto.CompactTextString(m) }
+func (*CheckRequest_TrustedCAs) ProtoMessage()    {}
+func (*CheckRequest_TrustedCAs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{5, 8}
+}
+
+func (m *CheckRequest_TrustedCAs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckRequest_TrustedCAs.Unmarshal(m, b)
+}
+func (m *CheckRequest_TrustedCAs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckRequest_TrustedCAs.Marshal(b, m, deterministic)
+}
+func (m *CheckRequest_TrustedCAs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckRequest_TrustedCAs.Merge(m, src)
+}
+func (m *CheckRequest_TrustedCAs) XXX_Size() int {
+	return xxx_messageInfo_CheckRequest_TrustedCAs.Size(m)
+}
+func (m *CheckRequest_TrustedCAs) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckRequest_TrustedCAs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckRequest_TrustedCAs proto.InternalMessageInfo
+
+func (m *CheckRequest_TrustedCAs) GetPem() []byte {
+	if m != nil {
+		return m.Pem
+	}
+	return nil
+}
+
+func (m *CheckRequest_TrustedCAs) GetReplaceSystemRoots() bool {
+	if m != nil {
+		return m.ReplaceSystemRoots
+	}
+	return false
+}
+
+type CheckRequest_ResolveOverride struct {
+	Host                 string   `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Port                 uint32   `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	Address              string   `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckRequest_ResolveOverride) Reset()         { *m = CheckRequest_ResolveOverride{} }
+func (m *CheckRequest_ResolveOverride) String() string { return proto.CompactTextString(m) }
+func (*CheckRequest_ResolveOverride) ProtoMessage()    {}
+func (*CheckRequest_ResolveOverride) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{5, 9}
+}
+
+func (m *CheckRequest_ResolveOverride) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckRequest_ResolveOverride.Unmarshal(m, b)
+}
+func (m *CheckRequest_ResolveOverride) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckRequest_ResolveOverride.Marshal(b, m, deterministic)
+}
+func (m *CheckRequest_ResolveOverride) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckRequest_ResolveOverride.Merge(m, src)
+}
+func (m *CheckRequest_ResolveOverride) XXX_Size() int {
+	return xxx_messageInfo_CheckRequest_ResolveOverride.Size(m)
+}
+func (m *CheckRequest_ResolveOverride) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckRequest_ResolveOverride.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckRequest_ResolveOverride proto.InternalMessageInfo
+
+func (m *CheckRequest_ResolveOverride) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *CheckRequest_ResolveOverride) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *CheckRequest_ResolveOverride) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type CheckRequest_Resolver struct {
+	Nameservers          []string `protobuf:"bytes,1,rep,name=nameservers,proto3" json:"nameservers,omitempty"`
+	Timeout              int32    `protobuf:"varint,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckRequest_Resolver) Reset()         { *m = CheckRequest_Resolver{} }
+func (m *CheckRequest_Resolver) String() string { return proto.CompactTextString(m) }
+func (*CheckRequest_Resolver) ProtoMessage()    {}
+func (*CheckRequest_Resolver) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{5, 10}
+}
+
+func (m *CheckRequest_Resolver) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckRequest_Resolver.Unmarshal(m, b)
+}
+func (m *CheckRequest_Resolver) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckRequest_Resolver.Marshal(b, m, deterministic)
+}
+func (m *CheckRequest_Resolver) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckRequest_Resolver.Merge(m, src)
+}
+func (m *CheckRequest_Resolver) XXX_Size() int {
+	return xxx_messageInfo_CheckRequest_Resolver.Size(m)
+}
+func (m *CheckRequest_Resolver) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckRequest_Resolver.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckRequest_Resolver proto.InternalMessageInfo
+
+func (m *CheckRequest_Resolver) GetNameservers() []string {
+	if m != nil {
+		return m.Nameservers
+	}
+	return nil
+}
+
+func (m *CheckRequest_Resolver) GetTimeout() int32 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+type CheckRequest_CertPolicy struct {
+	ExpiryWarningDays            int32    `protobuf:"varint,1,opt,name=expiryWarningDays,proto3" json:"expiryWarningDays,omitempty"`
+	MinRsaKeySize                int32    `protobuf:"varint,2,opt,name=minRsaKeySize,proto3" json:"minRsaKeySize,omitempty"`
+	MinEcdsaKeySize              int32    `protobuf:"varint,3,opt,name=minEcdsaKeySize,proto3" json:"minEcdsaKeySize,omitempty"`
+	ForbiddenSignatureAlgorithms []string `protobuf:"bytes,4,rep,name=forbiddenSignatureAlgorithms,proto3" json:"forbiddenSignatureAlgorithms,omitempty"`
+	RequiredIssuer               string   `protobuf:"bytes,5,opt,name=requiredIssuer,proto3" json:"requiredIssuer,omitempty"`
+	MaxValidityDays              int32    `protobuf:"varint,6,opt,name=maxValidityDays,proto3" json:"maxValidityDays,omitempty"`
+	MinScts                      int32    `protobuf:"varint,7,opt,name=minScts,proto3" json:"minScts,omitempty"`
+	XXX_NoUnkeyedLiteral         struct{} `json:"-"`
+	XXX_unrecognized             []byte   `json:"-"`
+	XXX_sizecache                int32    `json:"-"`
+}
+
+func (m *CheckRequest_CertPolicy) Reset()         { *m = CheckRequest_CertPolicy{} }
+func (m *CheckRequest_CertPolicy) String() string { return proto.CompactTextString(m) }
+func (*CheckRequest_CertPolicy) ProtoMessage()    {}
+func (*CheckRequest_CertPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{5, 11}
+}
+
+func (m *CheckRequest_CertPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckRequest_CertPolicy.Unmarshal(m, b)
+}
+func (m *CheckRequest_CertPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckRequest_CertPolicy.Marshal(b, m, deterministic)
+}
+func (m *CheckRequest_CertPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckRequest_CertPolicy.Merge(m, src)
+}
+func (m *CheckRequest_CertPolicy) XXX_Size() int {
+	return xxx_messageInfo_CheckRequest_CertPolicy.Size(m)
+}
+func (m *CheckRequest_CertPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckRequest_CertPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckRequest_CertPolicy proto.InternalMessageInfo
+
+func (m *CheckRequest_CertPolicy) GetExpiryWarningDays() int32 {
+	if m != nil {
+		return m.ExpiryWarningDays
+	}
+	return 0
+}
+
+func (m *CheckRequest_CertPolicy) GetMinRsaKeySize() int32 {
+	if m != nil {
+		return m.MinRsaKeySize
+	}
+	return 0
+}
+
+func (m *CheckRequest_CertPolicy) GetMinEcdsaKeySize() int32 {
+	if m != nil {
+		return m.MinEcdsaKeySize
+	}
+	return 0
+}
+
+func (m *CheckRequest_CertPolicy) GetForbiddenSignatureAlgorithms() []string {
+	if m != nil {
+		return m.ForbiddenSignatureAlgorithms
+	}
+	return nil
+}
+
+func (m *CheckRequest_CertPolicy) GetRequiredIssuer() string {
+	if m != nil {
+		return m.RequiredIssuer
+	}
+	return ""
+}
+
+func (m *CheckRequest_CertPolicy) GetMaxValidityDays() int32 {
+	if m != nil {
+		return m.MaxValidityDays
+	}
+	return 0
+}
+
+func (m *CheckRequest_CertPolicy) GetMinScts() int32 {
+	if m != nil {
+		return m.MinScts
+	}
+	return 0
+}
+
+type CheckRequest_CertPins struct {
+	LeafSHA256           [][]byte `protobuf:"bytes,1,rep,name=leafSHA256,proto3" json:"leafSHA256,omitempty"`
+	SpkiSHA256           [][]byte `protobuf:"bytes,2,rep,name=spkiSHA256,proto3" json:"spkiSHA256,omitempty"`
+	NotifyChange         bool     `protobuf:"varint,3,opt,name=notifyChange,proto3" json:"notifyChange,omitempty"`
+	PreviousSHA256       []byte   `protobuf:"bytes,4,opt,name=previousSHA256,proto3" json:"previousSHA256,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckRequest_CertPins) Reset()         { *m = CheckRequest_CertPins{} }
+func (m *CheckRequest_CertPins) String() string { return proto.CompactTextString(m) }
+func (*CheckRequest_CertPins) ProtoMessage()    {}
+func (*CheckRequest_CertPins) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{5, 12}
+}
+
+func (m *CheckRequest_CertPins) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckRequest_CertPins.Unmarshal(m, b)
+}
+func (m *CheckRequest_CertPins) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckRequest_CertPins.Marshal(b, m, deterministic)
+}
+func (m *CheckRequest_CertPins) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckRequest_CertPins.Merge(m, src)
+}
+func (m *CheckRequest_CertPins) XXX_Size() int {
+	return xxx_messageInfo_CheckRequest_CertPins.Size(m)
+}
+func (m *CheckRequest_CertPins) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckRequest_CertPins.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckRequest_CertPins proto.InternalMessageInfo
+
+func (m *CheckRequest_CertPins) GetLeafSHA256() [][]byte {
+	if m != nil {
+		return m.LeafSHA256
+	}
+	return nil
+}
+
+func (m *CheckRequest_CertPins) GetSpkiSHA256() [][]byte {
+	if m != nil {
+		return m.SpkiSHA256
+	}
+	return nil
+}
+
+func (m *CheckRequest_CertPins) GetNotifyChange() bool {
+	if m != nil {
+		return m.NotifyChange
+	}
+	return false
+}
+
+func (m *CheckRequest_CertPins) GetPreviousSHA256() []byte {
+	if m != nil {
+		return m.PreviousSHA256
+	}
+	return nil
+}
+
+type CheckRequest_TLSPolicy struct {
+	MinVersion                 int32    `protobuf:"varint,1,opt,name=minVersion,proto3" json:"minVersion,omitempty"`
+	ForbiddenCipherSuites      []int32  `protobuf:"varint,2,rep,packed,name=forbiddenCipherSuites,proto3" json:"forbiddenCipherSuites,omitempty"`
+	ForbidInsecureCipherSuites bool     `protobuf:"varint,3,opt,name=forbidInsecureCipherSuites,proto3" json:"forbidInsecureCipherSuites,omitempty"`
+	RequireAlpn                []string `protobuf:"bytes,4,rep,name=requireAlpn,proto3" json:"requireAlpn,omitempty"`
+	RequireOcspStapling        bool     `protobuf:"varint,5,opt,name=requireOcspStapling,proto3" json:"requireOcspStapling,omitempty"`
+	RequireSessionResumption   bool     `protobuf:"varint,6,opt,name=requireSessionResumption,proto3" json:"requireSessionResumption,omitempty"`
+	XXX_NoUnkeyedLiteral       struct{} `json:"-"`
+	XXX_unrecognized           []byte   `json:"-"`
+	XXX_sizecache              int32    `json:"-"`
+}
+
+func (m *CheckRequest_TLSPolicy) Reset()         { *m = CheckRequest_TLSPolicy{} }
+func (m *CheckRequest_TLSPolicy) String() string { return proto.CompactTextString(m) }
+func (*CheckRequest_TLSPolicy) ProtoMessage()    {}
+func (*CheckRequest_TLSPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{5, 13}
+}
+
+func (m *CheckRequest_TLSPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckRequest_TLSPolicy.Unmarshal(m, b)
+}
+func (m *CheckRequest_TLSPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckRequest_TLSPolicy.Marshal(b, m, deterministic)
+}
+func (m *CheckRequest_TLSPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckRequest_TLSPolicy.Merge(m, src)
+}
+func (m *CheckRequest_TLSPolicy) XXX_Size() int {
+	return xxx_messageInfo_CheckRequest_TLSPolicy.Size(m)
+}
+func (m *CheckRequest_TLSPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckRequest_TLSPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckRequest_TLSPolicy proto.InternalMessageInfo
+
+func (m *CheckRequest_TLSPolicy) GetMinVersion() int32 {
+	if m != nil {
+		return m.MinVersion
+	}
+	return 0
+}
+
+func (m *CheckRequest_TLSPolicy) GetForbiddenCipherSuites() []int32 {
+	if m != nil {
+		return m.ForbiddenCipherSuites
+	}
+	return nil
+}
+
+func (m *CheckRequest_TLSPolicy) GetForbidInsecureCipherSuites() bool {
+	if m != nil {
+		return m.ForbidInsecureCipherSuites
+	}
+	return false
+}
+
+func (m *CheckRequest_TLSPolicy) GetRequireAlpn() []string {
+	if m != nil {
+		return m.RequireAlpn
+	}
+	return nil
+}
+
+func (m *CheckRequest_TLSPolicy) GetRequireOcspStapling() bool {
+	if m != nil {
+		return m.RequireOcspStapling
+	}
+	return false
+}
+
+func (m *CheckRequest_TLSPolicy) GetRequireSessionResumption() bool {
+	if m != nil {
+		return m.RequireSessionResumption
+	}
+	return false
+}
+
+type CheckRequest_HTTPAuth struct {
+	Scheme               string   `protobuf:"bytes,1,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	Credentials          string   `protobuf:"bytes,2,opt,name=credentials,proto3" json:"credentials,omitempty"`
+	TokenUrl             string   `protobuf:"bytes,3,opt,name=tokenUrl,proto3" json:"tokenUrl,omitempty"`
+	Scopes               []string `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckRequest_HTTPAuth) Reset()         { *m = CheckRequest_HTTPAuth{} }
+func (m *CheckRequest_HTTPAuth) String() string { return proto.CompactTextString(m) }
+func (*CheckRequest_HTTPAuth) ProtoMessage()    {}
+func (*CheckRequest_HTTPAuth) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{5, 14}
+}
+
+func (m *CheckRequest_HTTPAuth) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckRequest_HTTPAuth.Unmarshal(m, b)
+}
+func (m *CheckRequest_HTTPAuth) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckRequest_HTTPAuth.Marshal(b, m, deterministic)
+}
+func (m *CheckRequest_HTTPAuth) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckRequest_HTTPAuth.Merge(m, src)
+}
+func (m *CheckRequest_HTTPAuth) XXX_Size() int {
+	return xxx_messageInfo_CheckRequest_HTTPAuth.Size(m)
+}
+func (m *CheckRequest_HTTPAuth) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckRequest_HTTPAuth.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckRequest_HTTPAuth proto.InternalMessageInfo
+
+func (m *CheckRequest_HTTPAuth) GetScheme() string {
+	if m != nil {
+		return m.Scheme
+	}
+	return ""
+}
+
+func (m *CheckRequest_HTTPAuth) GetCredentials() string {
+	if m != nil {
+		return m.Credentials
+	}
+	return ""
+}
+
+func (m *CheckRequest_HTTPAuth) GetTokenUrl() string {
+	if m != nil {
+		return m.TokenUrl
+	}
+	return ""
+}
+
+func (m *CheckRequest_HTTPAuth) GetScopes() []string {
+	if m != nil {
+		return m.Scopes
+	}
+	return nil
+}
+
+type CheckRequest_Proxy struct {
+	Url                  string   `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Credentials          string   `protobuf:"bytes,2,opt,name=credentials,proto3" json:"credentials,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckRequest_Proxy) Reset()         { *m = CheckRequest_Proxy{} }
+func (m *CheckRequest_Proxy) String() string { return proto.CompactTextString(m) }
+func (*CheckRequest_Proxy) ProtoMessage()    {}
+func (*CheckRequest_Proxy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{5, 15}
+}
+
+func (m *CheckRequest_Proxy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckRequest_Proxy.Unmarshal(m, b)
+}
+func (m *CheckRequest_Proxy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckRequest_Proxy.Marshal(b, m, deterministic)
+}
+func (m *CheckRequest_Proxy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckRequest_Proxy.Merge(m, src)
+}
+func (m *CheckRequest_Proxy) XXX_Size() int {
+	return xxx_messageInfo_CheckRequest_Proxy.Size(m)
+}
+func (m *CheckRequest_Proxy) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckRequest_Proxy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckRequest_Proxy proto.InternalMessageInfo
+
+func (m *CheckRequest_Proxy) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *CheckRequest_Proxy) GetCredentials() string {
+	if m != nil {
+		return m.Credentials
+	}
+	return ""
+}
+
+type CheckResponse struct {
+	Caller       string `protobuf:"bytes,1,opt,name=caller,proto3" json:"caller,omitempty"`
+	MonitoringId string `protobuf:"bytes,2,opt,name=monitoringId,proto3" json:"monitoringId,omitempty"`
+	//string regionId = 3;
+	Status               Status                           `protobuf:"varint,4,opt,name=status,proto3,enum=ws.grpc.Status" json:"status,omitempty"`
+	Method               string                           `protobuf:"bytes,5,opt,name=method,proto3" json:"method,omitempty"`
+	Url                  string                           `protobuf:"bytes,6,opt,name=url,proto3" json:"url,omitempty"`
+	StatusCode           int32                            `protobuf:"varint,7,opt,name=statusCode,proto3" json:"statusCode,omitempty"`
+	Headers              []*Header                        `protobuf:"bytes,8,rep,name=headers,proto3" json:"headers,omitempty"`
+	Body                 string                           `protobuf:"bytes,9,opt,name=body,proto3" json:"body,omitempty"`
+	Time                 int32                            `protobuf:"varint,10,opt,name=time,proto3" json:"time,omitempty"`
+	Certificate          *CheckResponse_Certificate       `protobuf:"bytes,11,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	Timing               *CheckResponse_Timing            `protobuf:"bytes,12,opt,name=timing,proto3" json:"timing,omitempty"`
+	Error                string                           `protobuf:"bytes,14,opt,name=error,proto3" json:"error,omitempty"`
+	Timestamp            string                           `protobuf:"bytes,15,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Proto                string                           `protobuf:"bytes,16,opt,name=proto,proto3" json:"proto,omitempty"`
+	StatusText           string                           `protobuf:"bytes,17,opt,name=statusText,proto3" json:"statusText,omitempty"`
+	Warnings             []string                         `protobuf:"bytes,18,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	TlsScan              *CheckResponse_TLSScan           `protobuf:"bytes,19,opt,name=tlsScan,proto3" json:"tlsScan,omitempty"`
+	Dns                  *CheckResponse_DNS               `protobuf:"bytes,20,opt,name=dns,proto3" json:"dns,omitempty"`
+	Family               string                           `protobuf:"bytes,21,opt,name=family,proto3" json:"family,omitempty"`
+	Ipv4                 *CheckResponse                   `protobuf:"bytes,22,opt,name=ipv4,proto3" json:"ipv4,omitempty"`
+	Ipv6                 *CheckResponse                   `protobuf:"bytes,23,opt,name=ipv6,proto3" json:"ipv6,omitempty"`
+	DnsReport            *CheckResponse_DNSReport         `protobuf:"bytes,24,opt,name=dnsReport,proto3" json:"dnsReport,omitempty"`
+	Mail                 *CheckResponse_MailReport        `protobuf:"bytes,25,opt,name=mail,proto3" json:"mail,omitempty"`
+	WebSocket            *CheckResponse_WebSocketReport   `protobuf:"bytes,26,opt,name=webSocket,proto3" json:"webSocket,omitempty"`
+	Grpc                 *CheckResponse_GRPCReport        `protobuf:"bytes,27,opt,name=grpc,proto3" json:"grpc,omitempty"`
+	Alpn                 string                           `protobuf:"bytes,28,opt,name=alpn,proto3" json:"alpn,omitempty"`
+	AltSvc               string                           `protobuf:"bytes,29,opt,name=altSvc,proto3" json:"altSvc,omitempty"`
+	Transaction          []*CheckResponse_TransactionStep `protobuf:"bytes,30,rep,name=transaction,proto3" json:"transaction,omitempty"`
+	Cookies              []*CheckResponse_SetCookie       `protobuf:"bytes,31,rep,name=cookies,proto3" json:"cookies,omitempty"`
+	Request              *CheckResponse_Request           `protobuf:"bytes,32,opt,name=request,proto3" json:"request,omitempty"`
+	SourceAddress        string                           `protobuf:"bytes,33,opt,name=sourceAddress,proto3" json:"sourceAddress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
+}
+
+func (m *CheckResponse) Reset()         { *m = CheckResponse{} }
+func (m *CheckResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckResponse) ProtoMessage()    {}
+func (*CheckResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{6}
+}
+
+func (m *CheckResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckResponse.Unmarshal(m, b)
+}
+func (m *CheckResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckResponse.Marshal(b, m, deterministic)
+}
+func (m *CheckResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckResponse.Merge(m, src)
+}
+func (m *CheckResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckResponse.Size(m)
+}
+func (m *CheckResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckResponse proto.InternalMessageInfo
+
+func (m *CheckResponse) GetCaller() string {
+	if m != nil {
+		return m.Caller
+	}
+	return ""
+}
+
+func (m *CheckResponse) GetMonitoringId() string {
+	if m != nil {
+		return m.MonitoringId
+	}
+	return ""
+}
+
+func (m *CheckResponse) GetStatus() Status {
+	if m != nil {
+		return m.Status
+	}
+	return Status_UP
+}
+
+func (m *CheckResponse) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *CheckResponse) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *CheckResponse) GetStatusCode() int32 {
+	if m != nil {
+		return m.StatusCode
+	}
+	return 0
+}
+
+func (m *CheckResponse) GetHeaders() []*Header {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *CheckResponse) GetBody() string {
+	if m != nil {
+		return m.Body
+	}
+	return ""
+}
+
+func (m *CheckResponse) GetTime() int32 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *CheckResponse) GetCertificate() *CheckResponse_Certificate {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *CheckResponse) GetTiming() *CheckResponse_Timing {
+	if m != nil {
+		return m.Timing
+	}
+	return nil
+}
+
+func (m *CheckResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *CheckResponse) GetTimestamp() string {
+	if m != nil {
+		return m.Timestamp
+	}
+	return ""
+}
+
+func (m *CheckResponse) GetProto() string {
+	if m != nil {
+		return m.Proto
+	}
+	return ""
+}
+
+func (m *CheckResponse) GetStatusText() string {
+	if m != nil {
+		return m.StatusText
+	}
+	return ""
+}
+
+func (m *CheckResponse) GetWarnings() []string {
+	if m != nil {
+		return m.Warnings
+	}
+	return nil
+}
+
+func (m *CheckResponse) GetTlsScan() *CheckResponse_TLSScan {
+	if m != nil {
+		return m.TlsScan
+	}
+	return nil
+}
+
+func (m *CheckResponse) GetDns() *CheckResponse_DNS {
+	if m != nil {
+		return m.Dns
+	}
+	return nil
+}
+
+func (m *CheckResponse) GetFamily() string {
+	if m != nil {
+		return m.Family
+	}
+	return ""
+}
+
+func (m *CheckResponse) GetIpv4() *CheckResponse {
+	if m != nil {
+		return m.Ipv4
+	}
+	return nil
+}
+
+func (m *CheckResponse) GetIpv6() *CheckResponse {
+	if m != nil {
+		return m.Ipv6
+	}
+	return nil
+}
+
+func (m *CheckResponse) GetDnsReport() *CheckResponse_DNSReport {
+	if m != nil {
+		return m.DnsReport
+	}
+	return nil
+}
+
+func (m *CheckResponse) GetMail() *CheckResponse_MailReport {
+	if m != nil {
+		return m.Mail
+	}
+	return nil
+}
+
+func (m *CheckResponse) GetWebSocket() *CheckResponse_WebSocketReport {
+	if m != nil {
+		return m.WebSocket
+	}
+	return nil
+}
+
+func (m *CheckResponse) GetGrpc() *CheckResponse_GRPCReport {
+	if m != nil {
+		return m.Grpc
+	}
+	return nil
+}
+
+func (m *CheckResponse) GetAlpn() string {
+	if m != nil {
+		return m.Alpn
+	}
+	return ""
+}
+
+func (m *CheckResponse) GetAltSvc() string {
+	if m != nil {
+		return m.AltSvc
+	}
+	return ""
+}
+
+func (m *CheckResponse) GetTransaction() []*CheckResponse_TransactionStep {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+func (m *CheckResponse) GetCookies() []*CheckResponse_SetCookie {
+	if m != nil {
+		return m.Cookies
+	}
+	return nil
+}
+
+func (m *CheckResponse) GetRequest() *CheckResponse_Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *CheckResponse) GetSourceAddress() string {
+	if m != nil {
+		return m.SourceAddress
+	}
+	return ""
+}
+
+type CheckResponse_Certificate struct {
+	SerialString              string               `protobuf:"bytes,1,opt,name=serialString,proto3" json:"serialString,omitempty"`
+	Algorithm                 int32                `protobuf:"varint,2,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	ValidFrom                 string               `protobuf:"bytes,3,opt,name=validFrom,proto3" json:"validFrom,omitempty"`
+	ValidTo                   string               `protobuf:"bytes,4,opt,name=validTo,proto3" json:"validTo,omitempty"`
+	Subject                   string               `protobuf:"bytes,5,opt,name=subject,proto3" json:"subject,omitempty"`
+	Issuer                    string               `protobuf:"bytes,6,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	FingerprintSHA256         []byte               `protobuf:"bytes,7,opt,name=fingerprintSHA256,proto3" json:"fingerprintSHA256,omitempty"`
+	Serial                    []byte               `protobuf:"bytes,8,opt,name=serial,proto3" json:"serial,omitempty"`
+	RevocationStatus          string               `protobuf:"bytes,9,opt,name=revocationStatus,proto3" json:"revocationStatus,omitempty"`
+	RevocationSource          string               `protobuf:"bytes,10,opt,name=revocationSource,proto3" json:"revocationSource,omitempty"`
+	SpkiSHA256                []byte               `protobuf:"bytes,11,opt,name=spkiSHA256,proto3" json:"spkiSHA256,omitempty"`
+	PreviousFingerprintSHA256 []byte               `protobuf:"bytes,12,opt,name=previousFingerprintSHA256,proto3" json:"previousFingerprintSHA256,omitempty"`
+	Scts                      []*CheckResponse_SCT `protobuf:"bytes,13,rep,name=scts,proto3" json:"scts,omitempty"`
+	XXX_NoUnkeyedLiteral      struct{}             `json:"-"`
+	XXX_unrecognized          []byte               `json:"-"`
+	XXX_sizecache             int32                `json:"-"`
+}
+
+func (m *CheckResponse_Certificate) Reset()         { *m = CheckResponse_Certificate{} }
+func (m *CheckResponse_Certificate) String() string { return proto.CompactTextString(m) }
+func (*CheckResponse_Certificate) ProtoMessage()    {}
+func (*CheckResponse_Certificate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{6, 0}
+}
+
+func (m *CheckResponse_Certificate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckResponse_Certificate.Unmarshal(m, b)
+}
+func (m *CheckResponse_Certificate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckResponse_Certificate.Marshal(b, m, deterministic)
+}
+func (m *CheckResponse_Certificate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckResponse_Certificate.Merge(m, src)
+}
+func (m *CheckResponse_Certificate) XXX_Size() int {
+	return xxx_messageInfo_CheckResponse_Certificate.Size(m)
+}
+func (m *CheckResponse_Certificate) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckResponse_Certificate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckResponse_Certificate proto.InternalMessageInfo
+
+func (m *CheckResponse_Certificate) GetSerialString() string {
+	if m != nil {
+		return m.SerialString
+	}
+	return ""
+}
+
+func (m *CheckResponse_Certificate) GetAlgorithm() int32 {
+	if m != nil {
+		return m.Algorithm
+	}
+	return 0
+}
+
+func (m *CheckResponse_Certificate) GetValidFrom() string {
+	if m != nil {
+		return m.ValidFrom
+	}
+	return ""
+}
+
+func (m *CheckResponse_Certificate) GetValidTo() string {
+	if m != nil {
+		return m.ValidTo
+	}
+	return ""
+}
+
+func (m *CheckResponse_Certificate) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *CheckResponse_Certificate) GetIssuer() string {
+	if m != nil {
+		return m.Issuer
+	}
+	return ""
+}
+
+func (m *CheckResponse_Certificate) GetFingerprintSHA256() []byte {
 	if m != nil {
 		return m.FingerprintSHA256
 	}
-	return nil
+	return nil
+}
+
+func (m *CheckResponse_Certificate) GetSerial() []byte {
+	if m != nil {
+		return m.Serial
+	}
+	return nil
+}
+
+func (m *CheckResponse_Certificate) GetRevocationStatus() string {
+	if m != nil {
+		return m.RevocationStatus
+	}
+	return ""
+}
+
+func (m *CheckResponse_Certificate) GetRevocationSource() string {
+	if m != nil {
+		return m.RevocationSource
+	}
+	return ""
+}
+
+func (m *CheckResponse_Certificate) GetSpkiSHA256() []byte {
+	if m != nil {
+		return m.SpkiSHA256
+	}
+	return nil
+}
+
+func (m *CheckResponse_Certificate) GetPreviousFingerprintSHA256() []byte {
+	if m != nil {
+		return m.PreviousFingerprintSHA256
+	}
+	return nil
+}
+
+func (m *CheckResponse_Certificate) GetScts() []*CheckResponse_SCT {
+	if m != nil {
+		return m.Scts
+	}
+	return nil
+}
+
+type CheckResponse_SCT struct {
+	LogId                []byte   `protobuf:"bytes,1,opt,name=logId,proto3" json:"logId,omitempty"`
+	Log                  string   `protobuf:"bytes,2,opt,name=log,proto3" json:"log,omitempty"`
+	Timestamp            string   `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Source               string   `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	Verified             bool     `protobuf:"varint,5,opt,name=verified,proto3" json:"verified,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckResponse_SCT) Reset()         { *m = CheckResponse_SCT{} }
+func (m *CheckResponse_SCT) String() string { return proto.CompactTextString(m) }
+func (*CheckResponse_SCT) ProtoMessage()    {}
+func (*CheckResponse_SCT) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{6, 1}
+}
+
+func (m *CheckResponse_SCT) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckResponse_SCT.Unmarshal(m, b)
+}
+func (m *CheckResponse_SCT) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckResponse_SCT.Marshal(b, m, deterministic)
+}
+func (m *CheckResponse_SCT) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckResponse_SCT.Merge(m, src)
+}
+func (m *CheckResponse_SCT) XXX_Size() int {
+	return xxx_messageInfo_CheckResponse_SCT.Size(m)
+}
+func (m *CheckResponse_SCT) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckResponse_SCT.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckResponse_SCT proto.InternalMessageInfo
+
+func (m *CheckResponse_SCT) GetLogId() []byte {
+	if m != nil {
+		return m.LogId
+	}
+	return nil
+}
+
+func (m *CheckResponse_SCT) GetLog() string {
+	if m != nil {
+		return m.Log
+	}
+	return ""
+}
+
+func (m *CheckResponse_SCT) GetTimestamp() string {
+	if m != nil {
+		return m.Timestamp
+	}
+	return ""
+}
+
+func (m *CheckResponse_SCT) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *CheckResponse_SCT) GetVerified() bool {
+	if m != nil {
+		return m.Verified
+	}
+	return false
+}
+
+type CheckResponse_Timing struct {
+	Dns                  int32    `protobuf:"varint,1,opt,name=dns,proto3" json:"dns,omitempty"`
+	Connecting           int32    `protobuf:"varint,2,opt,name=connecting,proto3" json:"connecting,omitempty"`
+	Tls                  int32    `protobuf:"varint,3,opt,name=tls,proto3" json:"tls,omitempty"`
+	Sending              int32    `protobuf:"varint,4,opt,name=sending,proto3" json:"sending,omitempty"`
+	Waiting              int32    `protobuf:"varint,5,opt,name=waiting,proto3" json:"waiting,omitempty"`
+	Receiving            int32    `protobuf:"varint,6,opt,name=receiving,proto3" json:"receiving,omitempty"`
+	Proxy                int32    `protobuf:"varint,7,opt,name=proxy,proto3" json:"proxy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckResponse_Timing) Reset()         { *m = CheckResponse_Timing{} }
+func (m *CheckResponse_Timing) String() string { return proto.CompactTextString(m) }
+func (*CheckResponse_Timing) ProtoMessage()    {}
+func (*CheckResponse_Timing) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{6, 2}
+}
+
+func (m *CheckResponse_Timing) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckResponse_Timing.Unmarshal(m, b)
+}
+func (m *CheckResponse_Timing) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckResponse_Timing.Marshal(b, m, deterministic)
+}
+func (m *CheckResponse_Timing) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckResponse_Timing.Merge(m, src)
+}
+func (m *CheckResponse_Timing) XXX_Size() int {
+	return xxx_messageInfo_CheckResponse_Timing.Size(m)
+}
+func (m *CheckResponse_Timing) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckResponse_Timing.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckResponse_Timing proto.InternalMessageInfo
+
+func (m *CheckResponse_Timing) GetDns() int32 {
+	if m != nil {
+		return m.Dns
+	}
+	return 0
+}
+
+func (m *CheckResponse_Timing) GetConnecting() int32 {
+	if m != nil {
+		return m.Connecting
+	}
+	return 0
+}
+
+func (m *CheckResponse_Timing) GetTls() int32 {
+	if m != nil {
+		return m.Tls
+	}
+	return 0
+}
+
+func (m *CheckResponse_Timing) GetSending() int32 {
+	if m != nil {
+		return m.Sending
+	}
+	return 0
+}
+
+func (m *CheckResponse_Timing) GetWaiting() int32 {
+	if m != nil {
+		return m.Waiting
+	}
+	return 0
+}
+
+func (m *CheckResponse_Timing) GetReceiving() int32 {
+	if m != nil {
+		return m.Receiving
+	}
+	return 0
+}
+
+func (m *CheckResponse_Timing) GetProxy() int32 {
+	if m != nil {
+		return m.Proxy
+	}
+	return 0
+}
+
+type CheckResponse_TLSScan struct {
+	Versions             []*CheckResponse_TLSScan_Version `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+	Alpn                 []string                         `protobuf:"bytes,2,rep,name=alpn,proto3" json:"alpn,omitempty"`
+	OcspStapling         bool                             `protobuf:"varint,3,opt,name=ocspStapling,proto3" json:"ocspStapling,omitempty"`
+	SessionResumption    bool                             `protobuf:"varint,4,opt,name=sessionResumption,proto3" json:"sessionResumption,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
+}
+
+func (m *CheckResponse_TLSScan) Reset()         { *m = CheckResponse_TLSScan{} }
+func (m *CheckResponse_TLSScan) String() string { return proto.CompactTextString(m) }
+func (*CheckResponse_TLSScan) ProtoMessage()    {}
+func (*CheckResponse_TLSScan) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{6, 3}
+}
+
+func (m *CheckResponse_TLSScan) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckResponse_TLSScan.Unmarshal(m, b)
+}
+func (m *CheckResponse_TLSScan) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckResponse_TLSScan.Marshal(b, m, deterministic)
+}
+func (m *CheckResponse_TLSScan) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckResponse_TLSScan.Merge(m, src)
+}
+func (m *CheckResponse_TLSScan) XXX_Size() int {
+	return xxx_messageInfo_CheckResponse_TLSScan.Size(m)
+}
+func (m *CheckResponse_TLSScan) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckResponse_TLSScan.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckResponse_TLSScan proto.InternalMessageInfo
+
+func (m *CheckResponse_TLSScan) GetVersions() []*CheckResponse_TLSScan_Version {
+	if m != nil {
+		return m.Versions
+	}
+	return nil
+}
+
+func (m *CheckResponse_TLSScan) GetAlpn() []string {
+	if m != nil {
+		return m.Alpn
+	}
+	return nil
+}
+
+func (m *CheckResponse_TLSScan) GetOcspStapling() bool {
+	if m != nil {
+		return m.OcspStapling
+	}
+	return false
+}
+
+func (m *CheckResponse_TLSScan) GetSessionResumption() bool {
+	if m != nil {
+		return m.SessionResumption
+	}
+	return false
+}
+
+type CheckResponse_TLSScan_Version struct {
+	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Supported            bool     `protobuf:"varint,2,opt,name=supported,proto3" json:"supported,omitempty"`
+	CipherSuites         []string `protobuf:"bytes,3,rep,name=cipherSuites,proto3" json:"cipherSuites,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckResponse_TLSScan_Version) Reset()         { *m = CheckResponse_TLSScan_Version{} }
+func (m *CheckResponse_TLSScan_Version) String() string { return proto.CompactTextString(m) }
+func (*CheckResponse_TLSScan_Version) ProtoMessage()    {}
+func (*CheckResponse_TLSScan_Version) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{6, 3, 0}
+}
+
+func (m *CheckResponse_TLSScan_Version) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckResponse_TLSScan_Version.Unmarshal(m, b)
+}
+func (m *CheckResponse_TLSScan_Version) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckResponse_TLSScan_Version.Marshal(b, m, deterministic)
+}
+func (m *CheckResponse_TLSScan_Version) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckResponse_TLSScan_Version.Merge(m, src)
+}
+func (m *CheckResponse_TLSScan_Version) XXX_Size() int {
+	return xxx_messageInfo_CheckResponse_TLSScan_Version.Size(m)
+}
+func (m *CheckResponse_TLSScan_Version) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckResponse_TLSScan_Version.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckResponse_TLSScan_Version proto.InternalMessageInfo
+
+func (m *CheckResponse_TLSScan_Version) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *CheckResponse_TLSScan_Version) GetSupported() bool {
+	if m != nil {
+		return m.Supported
+	}
+	return false
+}
+
+func (m *CheckResponse_TLSScan_Version) GetCipherSuites() []string {
+	if m != nil {
+		return m.CipherSuites
+	}
+	return nil
+}
+
+type CheckResponse_DNS struct {
+	Host                 string   `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Resolver             string   `protobuf:"bytes,2,opt,name=resolver,proto3" json:"resolver,omitempty"`
+	Rcode                string   `protobuf:"bytes,3,opt,name=rcode,proto3" json:"rcode,omitempty"`
+	Addresses            []string `protobuf:"bytes,4,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckResponse_DNS) Reset()         { *m = CheckResponse_DNS{} }
+func (m *CheckResponse_DNS) String() string { return proto.CompactTextString(m) }
+func (*CheckResponse_DNS) ProtoMessage()    {}
+func (*CheckResponse_DNS) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{6, 4}
+}
+
+func (m *CheckResponse_DNS) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckResponse_DNS.Unmarshal(m, b)
+}
+func (m *CheckResponse_DNS) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckResponse_DNS.Marshal(b, m, deterministic)
+}
+func (m *CheckResponse_DNS) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckResponse_DNS.Merge(m, src)
+}
+func (m *CheckResponse_DNS) XXX_Size() int {
+	return xxx_messageInfo_CheckResponse_DNS.Size(m)
+}
+func (m *CheckResponse_DNS) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckResponse_DNS.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckResponse_DNS proto.InternalMessageInfo
+
+func (m *CheckResponse_DNS) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *CheckResponse_DNS) GetResolver() string {
+	if m != nil {
+		return m.Resolver
+	}
+	return ""
+}
+
+func (m *CheckResponse_DNS) GetRcode() string {
+	if m != nil {
+		return m.Rcode
+	}
+	return ""
+}
+
+func (m *CheckResponse_DNS) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+type CheckResponse_DNSReport struct {
+	Name                 string                                `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 string                                `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Resolver             string                                `protobuf:"bytes,3,opt,name=resolver,proto3" json:"resolver,omitempty"`
+	Records              []string                              `protobuf:"bytes,4,rep,name=records,proto3" json:"records,omitempty"`
+	Nameservers          []*CheckResponse_DNSReport_Nameserver `protobuf:"bytes,5,rep,name=nameservers,proto3" json:"nameservers,omitempty"`
+	Dnssec               *CheckResponse_DNSReport_DNSSEC       `protobuf:"bytes,6,opt,name=dnssec,proto3" json:"dnssec,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                              `json:"-"`
+	XXX_unrecognized     []byte                                `json:"-"`
+	XXX_sizecache        int32                                 `json:"-"`
+}
+
+func (m *CheckResponse_DNSReport) Reset()         { *m = CheckResponse_DNSReport{} }
+func (m *CheckResponse_DNSReport) String() string { return proto.CompactTextString(m) }
+func (*CheckResponse_DNSReport) ProtoMessage()    {}
+func (*CheckResponse_DNSReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{6, 5}
+}
+
+func (m *CheckResponse_DNSReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckResponse_DNSReport.Unmarshal(m, b)
+}
+func (m *CheckResponse_DNSReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckResponse_DNSReport.Marshal(b, m, deterministic)
+}
+func (m *CheckResponse_DNSReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckResponse_DNSReport.Merge(m, src)
+}
+func (m *CheckResponse_DNSReport) XXX_Size() int {
+	return xxx_messageInfo_CheckResponse_DNSReport.Size(m)
+}
+func (m *CheckResponse_DNSReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckResponse_DNSReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckResponse_DNSReport proto.InternalMessageInfo
+
+func (m *CheckResponse_DNSReport) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CheckResponse_DNSReport) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *CheckResponse_DNSReport) GetResolver() string {
+	if m != nil {
+		return m.Resolver
+	}
+	return ""
+}
+
+func (m *CheckResponse_DNSReport) GetRecords() []string {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+func (m *CheckResponse_DNSReport) GetNameservers() []*CheckResponse_DNSReport_Nameserver {
+	if m != nil {
+		return m.Nameservers
+	}
+	return nil
+}
+
+func (m *CheckResponse_DNSReport) GetDnssec() *CheckResponse_DNSReport_DNSSEC {
+	if m != nil {
+		return m.Dnssec
+	}
+	return nil
+}
+
+type CheckResponse_DNSReport_Nameserver struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Records              []string `protobuf:"bytes,3,rep,name=records,proto3" json:"records,omitempty"`
+	Serial               uint32   `protobuf:"varint,4,opt,name=serial,proto3" json:"serial,omitempty"`
+	Error                string   `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckResponse_DNSReport_Nameserver) Reset()         { *m = CheckResponse_DNSReport_Nameserver{} }
+func (m *CheckResponse_DNSReport_Nameserver) String() string { return proto.CompactTextString(m) }
+func (*CheckResponse_DNSReport_Nameserver) ProtoMessage()    {}
+func (*CheckResponse_DNSReport_Nameserver) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{6, 5, 0}
+}
+
+func (m *CheckResponse_DNSReport_Nameserver) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckResponse_DNSReport_Nameserver.Unmarshal(m, b)
+}
+func (m *CheckResponse_DNSReport_Nameserver) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckResponse_DNSReport_Nameserver.Marshal(b, m, deterministic)
+}
+func (m *CheckResponse_DNSReport_Nameserver) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckResponse_DNSReport_Nameserver.Merge(m, src)
+}
+func (m *CheckResponse_DNSReport_Nameserver) XXX_Size() int {
+	return xxx_messageInfo_CheckResponse_DNSReport_Nameserver.Size(m)
+}
+func (m *CheckResponse_DNSReport_Nameserver) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckResponse_DNSReport_Nameserver.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckResponse_DNSReport_Nameserver proto.InternalMessageInfo
+
+func (m *CheckResponse_DNSReport_Nameserver) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CheckResponse_DNSReport_Nameserver) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *CheckResponse_DNSReport_Nameserver) GetRecords() []string {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+func (m *CheckResponse_DNSReport_Nameserver) GetSerial() uint32 {
+	if m != nil {
+		return m.Serial
+	}
+	return 0
+}
+
+func (m *CheckResponse_DNSReport_Nameserver) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type CheckResponse_DNSReport_DNSSEC struct {
+	Status               string                                      `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Signatures           []*CheckResponse_DNSReport_DNSSEC_Signature `protobuf:"bytes,2,rep,name=signatures,proto3" json:"signatures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                    `json:"-"`
+	XXX_unrecognized     []byte                                      `json:"-"`
+	XXX_sizecache        int32                                       `json:"-"`
+}
+
+func (m *CheckResponse_DNSReport_DNSSEC) Reset()         { *m = CheckResponse_DNSReport_DNSSEC{} }
+func (m *CheckResponse_DNSReport_DNSSEC) String() string { return proto.CompactTextString(m) }
+func (*CheckResponse_DNSReport_DNSSEC) ProtoMessage()    {}
+func (*CheckResponse_DNSReport_DNSSEC) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{6, 5, 1}
+}
+
+func (m *CheckResponse_DNSReport_DNSSEC) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckResponse_DNSReport_DNSSEC.Unmarshal(m, b)
+}
+func (m *CheckResponse_DNSReport_DNSSEC) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckResponse_DNSReport_DNSSEC.Marshal(b, m, deterministic)
+}
+func (m *CheckResponse_DNSReport_DNSSEC) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckResponse_DNSReport_DNSSEC.Merge(m, src)
+}
+func (m *CheckResponse_DNSReport_DNSSEC) XXX_Size() int {
+	return xxx_messageInfo_CheckResponse_DNSReport_DNSSEC.Size(m)
+}
+func (m *CheckResponse_DNSReport_DNSSEC) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckResponse_DNSReport_DNSSEC.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckResponse_DNSReport_DNSSEC proto.InternalMessageInfo
+
+func (m *CheckResponse_DNSReport_DNSSEC) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *CheckResponse_DNSReport_DNSSEC) GetSignatures() []*CheckResponse_DNSReport_DNSSEC_Signature {
+	if m != nil {
+		return m.Signatures
+	}
+	return nil
+}
+
+type CheckResponse_DNSReport_DNSSEC_Signature struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Signer               string   `protobuf:"bytes,3,opt,name=signer,proto3" json:"signer,omitempty"`
+	KeyTag               uint32   `protobuf:"varint,4,opt,name=keyTag,proto3" json:"keyTag,omitempty"`
+	Inception            string   `protobuf:"bytes,5,opt,name=inception,proto3" json:"inception,omitempty"`
+	Expiration           string   `protobuf:"bytes,6,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckResponse_DNSReport_DNSSEC_Signature) Reset() {
+	*m = CheckResponse_DNSReport_DNSSEC_Signature{}
+}
+func (m *CheckResponse_DNSReport_DNSSEC_Signature) String() string { return proto.CompactTextString(m) }
+func (*CheckResponse_DNSReport_DNSSEC_Signature) ProtoMessage()    {}
+func (*CheckResponse_DNSReport_DNSSEC_Signature) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{6, 5, 1, 0}
+}
+
+func (m *CheckResponse_DNSReport_DNSSEC_Signature) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckResponse_DNSReport_DNSSEC_Signature.Unmarshal(m, b)
+}
+func (m *CheckResponse_DNSReport_DNSSEC_Signature) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckResponse_DNSReport_DNSSEC_Signature.Marshal(b, m, deterministic)
+}
+func (m *CheckResponse_DNSReport_DNSSEC_Signature) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckResponse_DNSReport_DNSSEC_Signature.Merge(m, src)
+}
+func (m *CheckResponse_DNSReport_DNSSEC_Signature) XXX_Size() int {
+	return xxx_messageInfo_CheckResponse_DNSReport_DNSSEC_Signature.Size(m)
+}
+func (m *CheckResponse_DNSReport_DNSSEC_Signature) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckResponse_DNSReport_DNSSEC_Signature.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckResponse_DNSReport_DNSSEC_Signature proto.InternalMessageInfo
+
+func (m *CheckResponse_DNSReport_DNSSEC_Signature) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CheckResponse_DNSReport_DNSSEC_Signature) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *CheckResponse_DNSReport_DNSSEC_Signature) GetSigner() string {
+	if m != nil {
+		return m.Signer
+	}
+	return ""
+}
+
+func (m *CheckResponse_DNSReport_DNSSEC_Signature) GetKeyTag() uint32 {
+	if m != nil {
+		return m.KeyTag
+	}
+	return 0
+}
+
+func (m *CheckResponse_DNSReport_DNSSEC_Signature) GetInception() string {
+	if m != nil {
+		return m.Inception
+	}
+	return ""
+}
+
+func (m *CheckResponse_DNSReport_DNSSEC_Signature) GetExpiration() string {
+	if m != nil {
+		return m.Expiration
+	}
+	return ""
+}
+
+type CheckResponse_MailReport struct {
+	Greeting             string   `protobuf:"bytes,1,opt,name=greeting,proto3" json:"greeting,omitempty"`
+	Capabilities         []string `protobuf:"bytes,2,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	StartTls             bool     `protobuf:"varint,3,opt,name=startTls,proto3" json:"startTls,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckResponse_MailReport) Reset()         { *m = CheckResponse_MailReport{} }
+func (m *CheckResponse_MailReport) String() string { return proto.CompactTextString(m) }
+func (*CheckResponse_MailReport) ProtoMessage()    {}
+func (*CheckResponse_MailReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{6, 6}
+}
+
+func (m *CheckResponse_MailReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckResponse_MailReport.Unmarshal(m, b)
+}
+func (m *CheckResponse_MailReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckResponse_MailReport.Marshal(b, m, deterministic)
+}
+func (m *CheckResponse_MailReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckResponse_MailReport.Merge(m, src)
+}
+func (m *CheckResponse_MailReport) XXX_Size() int {
+	return xxx_messageInfo_CheckResponse_MailReport.Size(m)
+}
+func (m *CheckResponse_MailReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckResponse_MailReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckResponse_MailReport proto.InternalMessageInfo
+
+func (m *CheckResponse_MailReport) GetGreeting() string {
+	if m != nil {
+		return m.Greeting
+	}
+	return ""
 }
 
-func (m *CheckResponse_Certificate) GetSerial() []byte {
+func (m *CheckResponse_MailReport) GetCapabilities() []string {
 	if m != nil {
-		return m.Serial
+		return m.Capabilities
 	}
 	return nil
 }
 
-type CheckResponse_Timing struct {
-	Dns                  int32    `protobuf:"varint,1,opt,name=dns,proto3" json:"dns,omitempty"`
-	Connecting           int32    `protobuf:"varint,2,opt,name=connecting,proto3" json:"connecting,omitempty"`
-	Tls                  int32    `protobuf:"varint,3,opt,name=tls,proto3" json:"tls,omitempty"`
-	Sending              int32    `protobuf:"varint,4,opt,name=sending,proto3" json:"sending,omitempty"`
-	Waiting              int32    `protobuf:"varint,5,opt,name=waiting,proto3" json:"waiting,omitempty"`
-	Receiving            int32    `protobuf:"varint,6,opt,name=receiving,proto3" json:"receiving,omitempty"`
+func (m *CheckResponse_MailReport) GetStartTls() bool {
+	if m != nil {
+		return m.StartTls
+	}
+	return false
+}
+
+type CheckResponse_WebSocketReport struct {
+	Subprotocol          string   `protobuf:"bytes,1,opt,name=subprotocol,proto3" json:"subprotocol,omitempty"`
+	Reply                string   `protobuf:"bytes,2,opt,name=reply,proto3" json:"reply,omitempty"`
+	ReplyTime            int32    `protobuf:"varint,3,opt,name=replyTime,proto3" json:"replyTime,omitempty"`
+	CleanClose           bool     `protobuf:"varint,4,opt,name=cleanClose,proto3" json:"cleanClose,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CheckResponse_Timing) Reset()         { *m = CheckResponse_Timing{} }
-func (m *CheckResponse_Timing) String() string { return proto.CompactTextString(m) }
-func (*CheckResponse_Timing) ProtoMessage()    {}
-func (*CheckResponse_Timing) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3493b4c64b96a37f, []int{4, 1}
+func (m *CheckResponse_WebSocketReport) Reset()         { *m = CheckResponse_WebSocketReport{} }
+func (m *CheckResponse_WebSocketReport) String() string { return proto.CompactTextString(m) }
+func (*CheckResponse_WebSocketReport) ProtoMessage()    {}
+func (*CheckResponse_WebSocketReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{6, 7}
 }
 
-func (m *CheckResponse_Timing) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CheckResponse_Timing.Unmarshal(m, b)
+func (m *CheckResponse_WebSocketReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckResponse_WebSocketReport.Unmarshal(m, b)
 }
-func (m *CheckResponse_Timing) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CheckResponse_Timing.Marshal(b, m, deterministic)
+func (m *CheckResponse_WebSocketReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckResponse_WebSocketReport.Marshal(b, m, deterministic)
 }
-func (m *CheckResponse_Timing) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CheckResponse_Timing.Merge(m, src)
+func (m *CheckResponse_WebSocketReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckResponse_WebSocketReport.Merge(m, src)
 }
-func (m *CheckResponse_Timing) XXX_Size() int {
-	return xxx_messageInfo_CheckResponse_Timing.Size(m)
+func (m *CheckResponse_WebSocketReport) XXX_Size() int {
+	return xxx_messageInfo_CheckResponse_WebSocketReport.Size(m)
 }
-func (m *CheckResponse_Timing) XXX_DiscardUnknown() {
-	xxx_messageInfo_CheckResponse_Timing.DiscardUnknown(m)
+func (m *CheckResponse_WebSocketReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckResponse_WebSocketReport.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CheckResponse_Timing proto.InternalMessageInfo
+var xxx_messageInfo_CheckResponse_WebSocketReport proto.InternalMessageInfo
 
-func (m *CheckResponse_Timing) GetDns() int32 {
+func (m *CheckResponse_WebSocketReport) GetSubprotocol() string {
 	if m != nil {
-		return m.Dns
+		return m.Subprotocol
 	}
-	return 0
+	return ""
 }
 
-func (m *CheckResponse_Timing) GetConnecting() int32 {
+func (m *CheckResponse_WebSocketReport) GetReply() string {
 	if m != nil {
-		return m.Connecting
+		return m.Reply
 	}
-	return 0
+	return ""
 }
 
-func (m *CheckResponse_Timing) GetTls() int32 {
+func (m *CheckResponse_WebSocketReport) GetReplyTime() int32 {
 	if m != nil {
-		return m.Tls
+		return m.ReplyTime
 	}
 	return 0
 }
 
-func (m *CheckResponse_Timing) GetSending() int32 {
+func (m *CheckResponse_WebSocketReport) GetCleanClose() bool {
 	if m != nil {
-		return m.Sending
+		return m.CleanClose
+	}
+	return false
+}
+
+type CheckResponse_GRPCReport struct {
+	Service              string   `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Code                 string   `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckResponse_GRPCReport) Reset()         { *m = CheckResponse_GRPCReport{} }
+func (m *CheckResponse_GRPCReport) String() string { return proto.CompactTextString(m) }
+func (*CheckResponse_GRPCReport) ProtoMessage()    {}
+func (*CheckResponse_GRPCReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{6, 8}
+}
+
+func (m *CheckResponse_GRPCReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckResponse_GRPCReport.Unmarshal(m, b)
+}
+func (m *CheckResponse_GRPCReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckResponse_GRPCReport.Marshal(b, m, deterministic)
+}
+func (m *CheckResponse_GRPCReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckResponse_GRPCReport.Merge(m, src)
+}
+func (m *CheckResponse_GRPCReport) XXX_Size() int {
+	return xxx_messageInfo_CheckResponse_GRPCReport.Size(m)
+}
+func (m *CheckResponse_GRPCReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckResponse_GRPCReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckResponse_GRPCReport proto.InternalMessageInfo
+
+func (m *CheckResponse_GRPCReport) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *CheckResponse_GRPCReport) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *CheckResponse_GRPCReport) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+type CheckResponse_AssertionResult struct {
+	Assertion            *Assertion `protobuf:"bytes,1,opt,name=assertion,proto3" json:"assertion,omitempty"`
+	Value                string     `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Passed               bool       `protobuf:"varint,3,opt,name=passed,proto3" json:"passed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *CheckResponse_AssertionResult) Reset()         { *m = CheckResponse_AssertionResult{} }
+func (m *CheckResponse_AssertionResult) String() string { return proto.CompactTextString(m) }
+func (*CheckResponse_AssertionResult) ProtoMessage()    {}
+func (*CheckResponse_AssertionResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{6, 9}
+}
+
+func (m *CheckResponse_AssertionResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckResponse_AssertionResult.Unmarshal(m, b)
+}
+func (m *CheckResponse_AssertionResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckResponse_AssertionResult.Marshal(b, m, deterministic)
+}
+func (m *CheckResponse_AssertionResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckResponse_AssertionResult.Merge(m, src)
+}
+func (m *CheckResponse_AssertionResult) XXX_Size() int {
+	return xxx_messageInfo_CheckResponse_AssertionResult.Size(m)
+}
+func (m *CheckResponse_AssertionResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckResponse_AssertionResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckResponse_AssertionResult proto.InternalMessageInfo
+
+func (m *CheckResponse_AssertionResult) GetAssertion() *Assertion {
+	if m != nil {
+		return m.Assertion
+	}
+	return nil
+}
+
+func (m *CheckResponse_AssertionResult) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *CheckResponse_AssertionResult) GetPassed() bool {
+	if m != nil {
+		return m.Passed
+	}
+	return false
+}
+
+type CheckResponse_TransactionStep struct {
+	Name                 string                           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Method               string                           `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Url                  string                           `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	StatusCode           int32                            `protobuf:"varint,4,opt,name=statusCode,proto3" json:"statusCode,omitempty"`
+	Time                 int32                            `protobuf:"varint,5,opt,name=time,proto3" json:"time,omitempty"`
+	Timing               *CheckResponse_Timing            `protobuf:"bytes,6,opt,name=timing,proto3" json:"timing,omitempty"`
+	Assertions           []*CheckResponse_AssertionResult `protobuf:"bytes,7,rep,name=assertions,proto3" json:"assertions,omitempty"`
+	Error                string                           `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
+}
+
+func (m *CheckResponse_TransactionStep) Reset()         { *m = CheckResponse_TransactionStep{} }
+func (m *CheckResponse_TransactionStep) String() string { return proto.CompactTextString(m) }
+func (*CheckResponse_TransactionStep) ProtoMessage()    {}
+func (*CheckResponse_TransactionStep) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{6, 10}
+}
+
+func (m *CheckResponse_TransactionStep) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckResponse_TransactionStep.Unmarshal(m, b)
+}
+func (m *CheckResponse_TransactionStep) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckResponse_TransactionStep.Marshal(b, m, deterministic)
+}
+func (m *CheckResponse_TransactionStep) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckResponse_TransactionStep.Merge(m, src)
+}
+func (m *CheckResponse_TransactionStep) XXX_Size() int {
+	return xxx_messageInfo_CheckResponse_TransactionStep.Size(m)
+}
+func (m *CheckResponse_TransactionStep) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckResponse_TransactionStep.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckResponse_TransactionStep proto.InternalMessageInfo
+
+func (m *CheckResponse_TransactionStep) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CheckResponse_TransactionStep) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *CheckResponse_TransactionStep) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *CheckResponse_TransactionStep) GetStatusCode() int32 {
+	if m != nil {
+		return m.StatusCode
 	}
 	return 0
 }
 
-func (m *CheckResponse_Timing) GetWaiting() int32 {
+func (m *CheckResponse_TransactionStep) GetTime() int32 {
 	if m != nil {
-		return m.Waiting
+		return m.Time
 	}
 	return 0
 }
 
-func (m *CheckResponse_Timing) GetReceiving() int32 {
+func (m *CheckResponse_TransactionStep) GetTiming() *CheckResponse_Timing {
 	if m != nil {
-		return m.Receiving
+		return m.Timing
+	}
+	return nil
+}
+
+func (m *CheckResponse_TransactionStep) GetAssertions() []*CheckResponse_AssertionResult {
+	if m != nil {
+		return m.Assertions
+	}
+	return nil
+}
+
+func (m *CheckResponse_TransactionStep) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type CheckResponse_SetCookie struct {
+	Url                  string   `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Domain               string   `protobuf:"bytes,3,opt,name=domain,proto3" json:"domain,omitempty"`
+	Path                 string   `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	Expires              string   `protobuf:"bytes,5,opt,name=expires,proto3" json:"expires,omitempty"`
+	MaxAge               int32    `protobuf:"varint,6,opt,name=maxAge,proto3" json:"maxAge,omitempty"`
+	Secure               bool     `protobuf:"varint,7,opt,name=secure,proto3" json:"secure,omitempty"`
+	HttpOnly             bool     `protobuf:"varint,8,opt,name=httpOnly,proto3" json:"httpOnly,omitempty"`
+	SameSite             string   `protobuf:"bytes,9,opt,name=sameSite,proto3" json:"sameSite,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckResponse_SetCookie) Reset()         { *m = CheckResponse_SetCookie{} }
+func (m *CheckResponse_SetCookie) String() string { return proto.CompactTextString(m) }
+func (*CheckResponse_SetCookie) ProtoMessage()    {}
+func (*CheckResponse_SetCookie) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{6, 11}
+}
+
+func (m *CheckResponse_SetCookie) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckResponse_SetCookie.Unmarshal(m, b)
+}
+func (m *CheckResponse_SetCookie) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckResponse_SetCookie.Marshal(b, m, deterministic)
+}
+func (m *CheckResponse_SetCookie) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckResponse_SetCookie.Merge(m, src)
+}
+func (m *CheckResponse_SetCookie) XXX_Size() int {
+	return xxx_messageInfo_CheckResponse_SetCookie.Size(m)
+}
+func (m *CheckResponse_SetCookie) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckResponse_SetCookie.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckResponse_SetCookie proto.InternalMessageInfo
+
+func (m *CheckResponse_SetCookie) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *CheckResponse_SetCookie) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CheckResponse_SetCookie) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+func (m *CheckResponse_SetCookie) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *CheckResponse_SetCookie) GetExpires() string {
+	if m != nil {
+		return m.Expires
+	}
+	return ""
+}
+
+func (m *CheckResponse_SetCookie) GetMaxAge() int32 {
+	if m != nil {
+		return m.MaxAge
 	}
 	return 0
 }
 
+func (m *CheckResponse_SetCookie) GetSecure() bool {
+	if m != nil {
+		return m.Secure
+	}
+	return false
+}
+
+func (m *CheckResponse_SetCookie) GetHttpOnly() bool {
+	if m != nil {
+		return m.HttpOnly
+	}
+	return false
+}
+
+func (m *CheckResponse_SetCookie) GetSameSite() string {
+	if m != nil {
+		return m.SameSite
+	}
+	return ""
+}
+
+type CheckResponse_Request struct {
+	Method               string    `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Url                  string    `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Headers              []*Header `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty"`
+	Body                 string    `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CheckResponse_Request) Reset()         { *m = CheckResponse_Request{} }
+func (m *CheckResponse_Request) String() string { return proto.CompactTextString(m) }
+func (*CheckResponse_Request) ProtoMessage()    {}
+func (*CheckResponse_Request) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3493b4c64b96a37f, []int{6, 12}
+}
+
+func (m *CheckResponse_Request) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckResponse_Request.Unmarshal(m, b)
+}
+func (m *CheckResponse_Request) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckResponse_Request.Marshal(b, m, deterministic)
+}
+func (m *CheckResponse_Request) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckResponse_Request.Merge(m, src)
+}
+func (m *CheckResponse_Request) XXX_Size() int {
+	return xxx_messageInfo_CheckResponse_Request.Size(m)
+}
+func (m *CheckResponse_Request) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckResponse_Request.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckResponse_Request proto.InternalMessageInfo
+
+func (m *CheckResponse_Request) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *CheckResponse_Request) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *CheckResponse_Request) GetHeaders() []*Header {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *CheckResponse_Request) GetBody() string {
+	if m != nil {
+		return m.Body
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterEnum("ws.grpc.Status", Status_name, Status_value)
+	proto.RegisterEnum("ws.grpc.CheckRequest_IPFamily", CheckRequest_IPFamily_name, CheckRequest_IPFamily_value)
+	proto.RegisterEnum("ws.grpc.CheckRequest_HTTPProtocol", CheckRequest_HTTPProtocol_name, CheckRequest_HTTPProtocol_value)
 	proto.RegisterType((*Void)(nil), "ws.grpc.Void")
 	proto.RegisterType((*CheckerHello)(nil), "ws.grpc.CheckerHello")
 	proto.RegisterType((*Header)(nil), "ws.grpc.Header")
+	proto.RegisterType((*Cookie)(nil), "ws.grpc.Cookie")
+	proto.RegisterType((*Assertion)(nil), "ws.grpc.Assertion")
 	proto.RegisterType((*CheckRequest)(nil), "ws.grpc.CheckRequest")
 	proto.RegisterType((*CheckRequest_Options)(nil), "ws.grpc.CheckRequest.Options")
+	proto.RegisterType((*CheckRequest_Transaction)(nil), "ws.grpc.CheckRequest.Transaction")
+	proto.RegisterType((*CheckRequest_Transaction_Extraction)(nil), "ws.grpc.CheckRequest.Transaction.Extraction")
+	proto.RegisterType((*CheckRequest_Transaction_Step)(nil), "ws.grpc.CheckRequest.Transaction.Step")
+	proto.RegisterType((*CheckRequest_GRPCCheck)(nil), "ws.grpc.CheckRequest.GRPCCheck")
+	proto.RegisterType((*CheckRequest_WebSocketCheck)(nil), "ws.grpc.CheckRequest.WebSocketCheck")
+	proto.RegisterType((*CheckRequest_MailCheck)(nil), "ws.grpc.CheckRequest.MailCheck")
+	proto.RegisterType((*CheckRequest_TCPCheck)(nil), "ws.grpc.CheckRequest.TCPCheck")
+	proto.RegisterType((*CheckRequest_DNSCheck)(nil), "ws.grpc.CheckRequest.DNSCheck")
+	proto.RegisterType((*CheckRequest_ClientCertificate)(nil), "ws.grpc.CheckRequest.ClientCertificate")
+	proto.RegisterType((*CheckRequest_TrustedCAs)(nil), "ws.grpc.CheckRequest.TrustedCAs")
+	proto.RegisterType((*CheckRequest_ResolveOverride)(nil), "ws.grpc.CheckRequest.ResolveOverride")
+	proto.RegisterType((*CheckRequest_Resolver)(nil), "ws.grpc.CheckRequest.Resolver")
+	proto.RegisterType((*CheckRequest_CertPolicy)(nil), "ws.grpc.CheckRequest.CertPolicy")
+	proto.RegisterType((*CheckRequest_CertPins)(nil), "ws.grpc.CheckRequest.CertPins")
+	proto.RegisterType((*CheckRequest_TLSPolicy)(nil), "ws.grpc.CheckRequest.TLSPolicy")
+	proto.RegisterType((*CheckRequest_HTTPAuth)(nil), "ws.grpc.CheckRequest.HTTPAuth")
+	proto.RegisterType((*CheckRequest_Proxy)(nil), "ws.grpc.CheckRequest.Proxy")
 	proto.RegisterType((*CheckResponse)(nil), "ws.grpc.CheckResponse")
 	proto.RegisterType((*CheckResponse_Certificate)(nil), "ws.grpc.CheckResponse.Certificate")
+	proto.RegisterType((*CheckResponse_SCT)(nil), "ws.grpc.CheckResponse.SCT")
 	proto.RegisterType((*CheckResponse_Timing)(nil), "ws.grpc.CheckResponse.Timing")
+	proto.RegisterType((*CheckResponse_TLSScan)(nil), "ws.grpc.CheckResponse.TLSScan")
+	proto.RegisterType((*CheckResponse_TLSScan_Version)(nil), "ws.grpc.CheckResponse.TLSScan.Version")
+	proto.RegisterType((*CheckResponse_DNS)(nil), "ws.grpc.CheckResponse.DNS")
+	proto.RegisterType((*CheckResponse_DNSReport)(nil), "ws.grpc.CheckResponse.DNSReport")
+	proto.RegisterType((*CheckResponse_DNSReport_Nameserver)(nil), "ws.grpc.CheckResponse.DNSReport.Nameserver")
+	proto.RegisterType((*CheckResponse_DNSReport_DNSSEC)(nil), "ws.grpc.CheckResponse.DNSReport.DNSSEC")
+	proto.RegisterType((*CheckResponse_DNSReport_DNSSEC_Signature)(nil), "ws.grpc.CheckResponse.DNSReport.DNSSEC.Signature")
+	proto.RegisterType((*CheckResponse_MailReport)(nil), "ws.grpc.CheckResponse.MailReport")
+	proto.RegisterType((*CheckResponse_WebSocketReport)(nil), "ws.grpc.CheckResponse.WebSocketReport")
+	proto.RegisterType((*CheckResponse_GRPCReport)(nil), "ws.grpc.CheckResponse.GRPCReport")
+	proto.RegisterType((*CheckResponse_AssertionResult)(nil), "ws.grpc.CheckResponse.AssertionResult")
+	proto.RegisterType((*CheckResponse_TransactionStep)(nil), "ws.grpc.CheckResponse.TransactionStep")
+	proto.RegisterType((*CheckResponse_SetCookie)(nil), "ws.grpc.CheckResponse.SetCookie")
+	proto.RegisterType((*CheckResponse_Request)(nil), "ws.grpc.CheckResponse.Request")
 }
 
 func init() { proto.RegisterFile("checker_service.proto", fileDescriptor_3493b4c64b96a37f) }
 
 var fileDescriptor_3493b4c64b96a37f = []byte{
-	// 777 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x4d, 0x6f, 0x23, 0x45,
-	0x10, 0xcd, 0x38, 0xf6, 0x8c, 0x5d, 0xf6, 0x3a, 0xde, 0x16, 0x59, 0xb5, 0x2c, 0x40, 0xd6, 0x5c,
-	0xd6, 0x20, 0x64, 0x2d, 0x46, 0x0b, 0x12, 0x37, 0x94, 0x65, 0x15, 0x04, 0x4a, 0x50, 0xdb, 0x81,
-	0x23, 0x1a, 0xcf, 0x54, 0xec, 0x26, 0xed, 0x69, 0xd3, 0xdd, 0xe3, 0x90, 0x53, 0x7e, 0x08, 0x12,
-	0xbf, 0x87, 0x7f, 0x05, 0xea, 0x0f, 0xdb, 0x93, 0xe0, 0xdb, 0xde, 0xe6, 0xbd, 0xaa, 0x6e, 0x57,
-	0xbd, 0x7a, 0xd5, 0x86, 0xf3, 0x7c, 0x85, 0xf9, 0x1d, 0xaa, 0xdf, 0x34, 0xaa, 0x2d, 0xcf, 0x71,
-	0xb2, 0x51, 0xd2, 0x48, 0x92, 0xdc, 0xeb, 0xc9, 0x52, 0x6d, 0xf2, 0x34, 0x86, 0xe6, 0x2f, 0x92,
-	0x17, 0xe9, 0x1c, 0x7a, 0x17, 0x3e, 0xf3, 0x12, 0x85, 0x90, 0xa4, 0x0f, 0x0d, 0x5e, 0xd0, 0x68,
-	0x14, 0x8d, 0x3b, 0xac, 0xc1, 0x0b, 0x32, 0x84, 0xb6, 0x90, 0x79, 0x66, 0xb8, 0x2c, 0x69, 0xc3,
-	0xb1, 0x7b, 0x4c, 0x28, 0x24, 0xb9, 0xac, 0x4a, 0xa3, 0x1e, 0xe8, 0xa9, 0x0b, 0xed, 0x60, 0xfa,
-	0x06, 0xe2, 0x4b, 0xcc, 0x0a, 0x54, 0x64, 0x00, 0xa7, 0x77, 0xf8, 0x10, 0x2e, 0xb4, 0x9f, 0xe4,
-	0x23, 0x68, 0x6d, 0x33, 0x51, 0x61, 0xb8, 0xce, 0x83, 0xf4, 0x9f, 0x46, 0x28, 0x84, 0xe1, 0x1f,
-	0x15, 0x6a, 0x43, 0x5e, 0x41, 0x9c, 0x67, 0x42, 0xa0, 0x0a, 0x67, 0x03, 0x22, 0x29, 0xf4, 0xd6,
-	0xb2, 0xe4, 0x46, 0x2a, 0x5e, 0x2e, 0x7f, 0x28, 0xc2, 0x2d, 0x4f, 0x38, 0x7b, 0x76, 0x8d, 0x66,
-	0x25, 0x8b, 0x50, 0x57, 0x40, 0xb6, 0x98, 0x4a, 0x09, 0xda, 0xf4, 0xc5, 0x54, 0x4a, 0xd8, 0x16,
-	0x0c, 0x5f, 0xa3, 0xac, 0x0c, 0x4d, 0x46, 0xd1, 0xb8, 0xc5, 0x76, 0x90, 0x7c, 0x03, 0x89, 0xdc,
-	0xd8, 0x36, 0x35, 0xed, 0x8c, 0xa2, 0x71, 0x77, 0xfa, 0xc9, 0x24, 0x68, 0x37, 0xa9, 0xd7, 0x39,
-	0xb9, 0xf6, 0x49, 0x6c, 0x97, 0x3d, 0x7c, 0x84, 0x24, 0x70, 0x64, 0x04, 0xdd, 0x25, 0x9a, 0xf7,
-	0x99, 0x10, 0x8b, 0x2c, 0xbf, 0x73, 0x8d, 0xb4, 0x59, 0x9d, 0x22, 0x63, 0x38, 0xe3, 0xcb, 0x52,
-	0x2a, 0x9c, 0x0b, 0xfd, 0xbd, 0x52, 0x52, 0x69, 0xd7, 0x50, 0x9b, 0x3d, 0xa7, 0x6d, 0xe6, 0xad,
-	0x14, 0x42, 0xde, 0x33, 0x2c, 0xb8, 0xc2, 0xdc, 0x68, 0xd7, 0x5c, 0x9b, 0x3d, 0xa7, 0xd3, 0xbf,
-	0x12, 0x78, 0x11, 0x4a, 0xd4, 0x1b, 0x59, 0x6a, 0xfc, 0x20, 0x2d, 0x5f, 0x43, 0xac, 0x4d, 0x66,
-	0x2a, 0xed, 0x64, 0xeb, 0x4f, 0xcf, 0xf6, 0x32, 0xcc, 0x1c, 0xcd, 0x42, 0xb8, 0x26, 0x7a, 0xeb,
-	0x98, 0xe8, 0xf1, 0x41, 0xf4, 0x4f, 0x01, 0xfc, 0x99, 0x0b, 0x59, 0x60, 0xd0, 0xbd, 0xc6, 0x90,
-	0xcf, 0x20, 0x59, 0x39, 0xf7, 0x68, 0xda, 0x1e, 0x9d, 0x8e, 0xbb, 0xb5, 0xdf, 0xf4, 0xae, 0x62,
-	0xbb, 0x38, 0x21, 0xd0, 0x5c, 0xc8, 0xe2, 0xc1, 0x8d, 0xa8, 0xc3, 0xdc, 0xb7, 0xe5, 0xec, 0x10,
-	0x29, 0xb8, 0x8b, 0xdd, 0x37, 0x79, 0x07, 0xdd, 0x1c, 0x95, 0xe1, 0xb7, 0x3c, 0xcf, 0x0c, 0xd2,
-	0xae, 0x9b, 0x68, 0xfa, 0x7c, 0xa2, 0x5e, 0xae, 0xc9, 0xc5, 0x21, 0x93, 0xd5, 0x8f, 0x91, 0xb7,
-	0x10, 0x1b, 0xbe, 0xe6, 0xe5, 0x92, 0xf6, 0x8e, 0x5b, 0x22, 0x5c, 0x30, 0x77, 0x49, 0x2c, 0x24,
-	0x5b, 0xc7, 0xa3, 0x1d, 0x22, 0xed, 0x7b, 0xc7, 0x3b, 0x40, 0x3e, 0x86, 0x8e, 0x2d, 0x4d, 0x9b,
-	0x6c, 0xbd, 0xa1, 0x67, 0x2e, 0x72, 0x20, 0xec, 0x19, 0xb7, 0xb1, 0x74, 0xe0, 0xcf, 0xf8, 0xf5,
-	0xdd, 0x2b, 0x37, 0xc7, 0x3f, 0x0d, 0x7d, 0xe9, 0x42, 0x35, 0x66, 0xf8, 0x6f, 0x04, 0xdd, 0x5a,
-	0xf5, 0x76, 0xc0, 0x1a, 0x15, 0xcf, 0xc4, 0xcc, 0xd8, 0x71, 0x86, 0xf1, 0x3f, 0xe1, 0x6c, 0x1d,
-	0x99, 0x58, 0x4a, 0xc5, 0xcd, 0x6a, 0xed, 0x1c, 0xd0, 0x62, 0x07, 0xc2, 0x46, 0xb7, 0x99, 0xe0,
-	0xc5, 0x7b, 0x25, 0xd7, 0x61, 0x9b, 0x0e, 0x84, 0x5d, 0x1f, 0x07, 0xe6, 0x32, 0x2c, 0xd5, 0x0e,
-	0xda, 0x88, 0xae, 0x16, 0xbf, 0x63, 0x6e, 0x82, 0x1d, 0x76, 0xd0, 0xfa, 0x84, 0x6b, 0x5d, 0xa1,
-	0x0a, 0x96, 0x08, 0x88, 0x7c, 0x01, 0x2f, 0x6f, 0x79, 0xb9, 0x44, 0xb5, 0x51, 0xbc, 0x34, 0xb3,
-	0xcb, 0xef, 0xa6, 0x6f, 0xbf, 0x76, 0xe6, 0xe8, 0xb1, 0xff, 0x07, 0xec, 0x2d, 0xbe, 0x0b, 0xda,
-	0x76, 0x29, 0x01, 0x0d, 0xff, 0x8e, 0x20, 0xf6, 0xf2, 0x5b, 0xe3, 0x15, 0xa5, 0x76, 0x3d, 0xb7,
-	0x98, 0xfd, 0xb4, 0xf2, 0xe5, 0xb2, 0x2c, 0x31, 0x37, 0x56, 0x0c, 0xdf, 0x6b, 0x8d, 0xb1, 0x27,
-	0x8c, 0xf0, 0x7b, 0xd5, 0x62, 0xf6, 0xd3, 0xb5, 0x81, 0x65, 0x61, 0xd3, 0x9b, 0xfe, 0x7d, 0x08,
-	0xd0, 0x46, 0xee, 0x33, 0xee, 0x2e, 0x6a, 0xf9, 0x48, 0x80, 0x56, 0x32, 0x85, 0x39, 0xf2, 0xad,
-	0x8d, 0xc5, 0x5e, 0xd0, 0x3d, 0xf1, 0xf9, 0x6b, 0x88, 0xfd, 0xe2, 0x90, 0x18, 0x1a, 0x37, 0x3f,
-	0x0f, 0x4e, 0x48, 0x1b, 0x9a, 0xef, 0xae, 0x7f, 0xbd, 0x1a, 0x44, 0xa4, 0x0b, 0xc9, 0xcd, 0xd5,
-	0x8f, 0x57, 0x16, 0x34, 0xa6, 0x8f, 0xd0, 0x0f, 0x2f, 0xf3, 0xcc, 0x3f, 0xe1, 0xe4, 0x5b, 0x88,
-	0x7f, 0xe2, 0xda, 0x60, 0x49, 0xce, 0x9f, 0x1a, 0x2f, 0x3c, 0xde, 0xc3, 0xf3, 0xa3, 0x4f, 0x54,
-	0x7a, 0xf2, 0x26, 0x22, 0x5f, 0x42, 0xcc, 0x50, 0x57, 0xc2, 0x90, 0x57, 0xc7, 0x4d, 0x3b, 0x7c,
-	0xb1, 0xe7, 0xdd, 0x1f, 0xc3, 0xc9, 0x22, 0x76, 0x9e, 0xfb, 0xea, 0xbf, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0x4b, 0x79, 0xa4, 0x83, 0x4b, 0x06, 0x00, 0x00,
+	// 3146 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x5a, 0x5f, 0x73, 0x1b, 0xc7,
+	0x91, 0x17, 0xfe, 0x03, 0x0d, 0x8a, 0x82, 0xc6, 0x96, 0xbc, 0x5e, 0xff, 0xa3, 0x59, 0x3e, 0x5b,
+	0xf6, 0xb9, 0x58, 0x12, 0x6d, 0xcb, 0x2e, 0x9d, 0xcf, 0x77, 0x14, 0x28, 0x1e, 0x75, 0x96, 0x48,
+	0x7a, 0x00, 0xc9, 0xe7, 0xab, 0x4a, 0xb9, 0x96, 0x8b, 0x21, 0x38, 0xe1, 0x62, 0x77, 0x3d, 0x33,
+	0xa0, 0x88, 0xbc, 0xb8, 0x2a, 0x2f, 0xf9, 0x00, 0x49, 0x25, 0xae, 0xbc, 0xe5, 0x29, 0xcf, 0xa9,
+	0xca, 0x53, 0x3e, 0x43, 0xbe, 0x43, 0x92, 0x87, 0x7c, 0x8e, 0xa4, 0x7a, 0xfe, 0xec, 0x2e, 0x80,
+	0x25, 0xa4, 0x94, 0xdf, 0xb6, 0x7b, 0xba, 0x7b, 0x66, 0x7a, 0x66, 0xba, 0x7f, 0xdd, 0x00, 0xdc,
+	0x08, 0x4f, 0x59, 0x78, 0xc6, 0xc4, 0xb7, 0x92, 0x89, 0x73, 0x1e, 0xb2, 0xad, 0x54, 0x24, 0x2a,
+	0x21, 0xad, 0x67, 0x72, 0x6b, 0x2c, 0xd2, 0x70, 0xb3, 0x09, 0xf5, 0xa7, 0x09, 0x1f, 0x6d, 0x0e,
+	0x61, 0xad, 0x6f, 0x24, 0xf7, 0x59, 0x14, 0x25, 0x64, 0x1d, 0xaa, 0x7c, 0xe4, 0x55, 0x36, 0x2a,
+	0xb7, 0x3a, 0xb4, 0xca, 0x47, 0xc4, 0x87, 0x76, 0x94, 0x84, 0x81, 0xe2, 0x49, 0xec, 0x55, 0x35,
+	0x37, 0xa3, 0x89, 0x07, 0xad, 0x30, 0x99, 0xc6, 0x4a, 0xcc, 0xbc, 0x9a, 0x1e, 0x72, 0xe4, 0xe6,
+	0x6d, 0x68, 0xee, 0xb3, 0x60, 0xc4, 0x04, 0xe9, 0x41, 0xed, 0x8c, 0xcd, 0xac, 0x41, 0xfc, 0x24,
+	0x2f, 0x43, 0xe3, 0x3c, 0x88, 0xa6, 0xcc, 0x9a, 0x33, 0xc4, 0xe6, 0x36, 0x34, 0xfb, 0x49, 0x72,
+	0xc6, 0x19, 0x21, 0x50, 0x8f, 0x83, 0x09, 0xb3, 0x2a, 0xfa, 0xfb, 0x12, 0x9d, 0x9f, 0x40, 0x67,
+	0x47, 0x4a, 0x26, 0xf4, 0x62, 0x6e, 0x42, 0x53, 0x26, 0x53, 0x11, 0x3a, 0x45, 0x4b, 0x91, 0x37,
+	0x01, 0xd8, 0x45, 0x2a, 0x98, 0x94, 0xf9, 0x16, 0x0a, 0x1c, 0xdc, 0x44, 0x1a, 0x28, 0xc5, 0x44,
+	0xec, 0x36, 0x61, 0xc9, 0xcd, 0xbf, 0xbe, 0x6d, 0x7d, 0x43, 0xd9, 0x77, 0x53, 0x26, 0x15, 0x4e,
+	0x11, 0x06, 0x51, 0xc4, 0x84, 0x9b, 0xc2, 0x50, 0x64, 0x13, 0xd6, 0x26, 0x49, 0xcc, 0x55, 0x22,
+	0x78, 0x3c, 0x7e, 0x38, 0xb2, 0x93, 0xcc, 0xf1, 0x50, 0x77, 0xc2, 0xd4, 0x69, 0x32, 0xb2, 0xb3,
+	0x58, 0x0a, 0xfd, 0x33, 0x15, 0x91, 0x57, 0x37, 0xfe, 0x99, 0x8a, 0x88, 0x7c, 0x0a, 0xeb, 0xc2,
+	0x4c, 0x68, 0x5c, 0x28, 0xbd, 0xc6, 0x46, 0xed, 0x56, 0x77, 0xfb, 0xda, 0x96, 0x3d, 0xbb, 0x2d,
+	0xc3, 0xa7, 0x0b, 0x62, 0x64, 0x03, 0xba, 0x96, 0x73, 0x3f, 0x19, 0xcd, 0xbc, 0xa6, 0x36, 0x59,
+	0x64, 0xe1, 0x5e, 0x15, 0x9f, 0xb0, 0x64, 0xaa, 0xbc, 0xd6, 0x46, 0xe5, 0x56, 0x83, 0x3a, 0x92,
+	0x7c, 0x0a, 0xad, 0x24, 0x45, 0x3f, 0x4a, 0xaf, 0xb3, 0x51, 0xb9, 0xd5, 0xdd, 0x7e, 0x23, 0x9b,
+	0xad, 0xe8, 0x82, 0xad, 0x43, 0x23, 0x44, 0x9d, 0x34, 0x9e, 0x96, 0x9a, 0xa5, 0xcc, 0x03, 0x73,
+	0x5a, 0xf8, 0xed, 0xff, 0xb2, 0x0b, 0x2d, 0x2b, 0x88, 0x8b, 0x1a, 0x33, 0xb5, 0x17, 0x44, 0xd1,
+	0x71, 0x10, 0x9e, 0x69, 0xc7, 0xb5, 0x69, 0x91, 0x45, 0x6e, 0xc1, 0x35, 0x3e, 0x8e, 0x13, 0xc1,
+	0x86, 0x91, 0x7c, 0x20, 0x44, 0x22, 0xa4, 0x76, 0x60, 0x9b, 0x2e, 0xb2, 0x51, 0xf2, 0x24, 0x89,
+	0xa2, 0xe4, 0x19, 0x65, 0x23, 0x2e, 0x58, 0xa8, 0xa4, 0x76, 0x66, 0x9b, 0x2e, 0xb2, 0xc9, 0x7f,
+	0x03, 0x84, 0x4c, 0xa8, 0xa3, 0x24, 0xe2, 0xe1, 0x4c, 0x3b, 0xb7, 0xbb, 0xbd, 0x51, 0xbe, 0xa3,
+	0x7e, 0x26, 0x47, 0x0b, 0x3a, 0x38, 0x57, 0x68, 0xc4, 0xce, 0xdd, 0xf5, 0x6f, 0x98, 0xb9, 0x16,
+	0xd8, 0xe4, 0x1e, 0xb4, 0xb5, 0x1e, 0x8f, 0xa5, 0xf6, 0x79, 0x77, 0xfb, 0xcd, 0x15, 0x33, 0xf1,
+	0x58, 0xd2, 0x4c, 0x9e, 0xbc, 0x0e, 0x1d, 0x6d, 0x6e, 0x80, 0x7b, 0x69, 0x69, 0xfb, 0x39, 0x83,
+	0xfc, 0x27, 0x74, 0x54, 0x24, 0xed, 0x26, 0xda, 0xda, 0xf4, 0x5b, 0xe5, 0xa6, 0x87, 0x8f, 0x06,
+	0x76, 0x0f, 0xb9, 0x06, 0x79, 0x02, 0xd7, 0xc3, 0x88, 0xb3, 0x58, 0xe1, 0xc4, 0xfc, 0x84, 0x87,
+	0x81, 0x62, 0xf6, 0x74, 0xdf, 0xbb, 0x64, 0x85, 0x8b, 0xe2, 0x74, 0xd9, 0x02, 0xfa, 0x56, 0x89,
+	0xa9, 0x54, 0x6c, 0xd4, 0x0f, 0xa4, 0x07, 0xab, 0x7c, 0x3b, 0xb4, 0x72, 0x3b, 0x92, 0x16, 0x74,
+	0xc8, 0x57, 0xd0, 0x13, 0x4c, 0x26, 0xd1, 0x39, 0x3b, 0x3c, 0x67, 0x42, 0xf0, 0x11, 0x93, 0x5e,
+	0x57, 0xdf, 0xf1, 0x7f, 0x2b, 0xb7, 0x43, 0xe7, 0xa5, 0xe9, 0x92, 0x3a, 0x1e, 0x82, 0xe5, 0x09,
+	0x6f, 0x6d, 0xd5, 0x21, 0x58, 0x53, 0x82, 0x66, 0xf2, 0xa8, 0xcb, 0xd3, 0xbd, 0x60, 0xc2, 0xa3,
+	0x99, 0x77, 0x75, 0xa3, 0x72, 0x6b, 0xfd, 0x32, 0xdd, 0x87, 0x47, 0x46, 0x8a, 0x66, 0xf2, 0xa8,
+	0x3b, 0x8a, 0xa5, 0x96, 0xf2, 0xd6, 0x57, 0xcd, 0xbb, 0x7b, 0x30, 0x30, 0x74, 0x26, 0x8f, 0xba,
+	0x2a, 0x4c, 0x8d, 0xee, 0xb5, 0x55, 0xba, 0xc3, 0xfe, 0x91, 0xd5, 0x75, 0xf2, 0x78, 0x35, 0x26,
+	0x01, 0x8f, 0x8c, 0x72, 0x6f, 0xd5, 0xd5, 0x78, 0xec, 0xc4, 0x68, 0xae, 0x41, 0x1e, 0xc1, 0xfa,
+	0x33, 0x76, 0x3c, 0x48, 0xc2, 0x33, 0xa6, 0x8c, 0x8d, 0xeb, 0xda, 0xc6, 0x3b, 0xe5, 0x36, 0xbe,
+	0x9e, 0x93, 0xa5, 0x0b, 0xba, 0xb8, 0x18, 0xd4, 0x31, 0x86, 0xc8, 0xaa, 0xc5, 0xfc, 0x0f, 0x3d,
+	0xea, 0xdb, 0xc5, 0x64, 0x1a, 0x64, 0x0f, 0xd6, 0x4e, 0x95, 0x4a, 0x8f, 0x30, 0x41, 0x85, 0x49,
+	0xe4, 0xbd, 0xa4, 0xcf, 0x60, 0xb3, 0xdc, 0xc2, 0xfe, 0x70, 0x78, 0xe4, 0x24, 0xe9, 0x9c, 0x1e,
+	0xe9, 0x43, 0x57, 0x89, 0x20, 0x96, 0x41, 0xa8, 0x9f, 0xeb, 0xcb, 0x7a, 0x21, 0x6f, 0x5f, 0x76,
+	0x33, 0x33, 0x41, 0x5a, 0xd4, 0xd2, 0x2f, 0x52, 0xe7, 0xa1, 0xff, 0x0d, 0x84, 0x77, 0xc3, 0xbe,
+	0x48, 0xc7, 0x20, 0xef, 0x43, 0xcb, 0x10, 0xd2, 0xbb, 0xb9, 0x10, 0x94, 0x4d, 0xf6, 0xa2, 0x6e,
+	0x1c, 0x4f, 0x17, 0x57, 0xb7, 0x33, 0x55, 0xa7, 0xde, 0x2b, 0xab, 0x4e, 0x17, 0x77, 0x84, 0x52,
+	0x34, 0x93, 0x27, 0x77, 0xa0, 0x91, 0x8a, 0xe4, 0x62, 0xe6, 0x79, 0x5a, 0xf1, 0xb5, 0x72, 0xc5,
+	0x23, 0x14, 0xa1, 0x46, 0x12, 0xe3, 0xec, 0x31, 0x8f, 0x47, 0x3b, 0xa3, 0x91, 0x60, 0x52, 0x7a,
+	0xaf, 0x9a, 0xe0, 0x5f, 0x60, 0xf9, 0xbf, 0xaf, 0x41, 0xb7, 0xb0, 0x6d, 0xf2, 0x39, 0x34, 0xa4,
+	0x62, 0xa9, 0xf4, 0x2a, 0x7a, 0x27, 0xef, 0x3e, 0xd7, 0x51, 0x5b, 0x03, 0xc5, 0x52, 0x6a, 0x94,
+	0xfc, 0xff, 0x03, 0x78, 0x70, 0xa1, 0x84, 0xb5, 0x55, 0x96, 0xb3, 0xf3, 0x84, 0x5c, 0x5d, 0x91,
+	0x90, 0x6b, 0x8b, 0x09, 0xd9, 0xff, 0x47, 0x05, 0xea, 0x38, 0xd3, 0x65, 0x46, 0x6d, 0x1a, 0xad,
+	0x96, 0xa5, 0xd1, 0x5a, 0x9e, 0x46, 0xdf, 0x87, 0xd6, 0xa9, 0xcd, 0x9f, 0xf5, 0xf2, 0xfc, 0xe9,
+	0xc6, 0x71, 0xa2, 0x63, 0xcc, 0x98, 0x0d, 0x33, 0x11, 0x7e, 0x93, 0x3d, 0x68, 0x31, 0xb3, 0x3f,
+	0xaf, 0xa9, 0xd5, 0x3f, 0x7c, 0xbe, 0x7f, 0x72, 0x87, 0x50, 0xa7, 0x4c, 0xb6, 0x01, 0x02, 0x87,
+	0x51, 0x30, 0xc4, 0xa3, 0x29, 0x92, 0x99, 0xca, 0xe0, 0x0b, 0x2d, 0x48, 0xf9, 0x9f, 0x42, 0x27,
+	0x7b, 0x28, 0x98, 0xb3, 0x2d, 0x84, 0xb3, 0x8e, 0x70, 0x24, 0xee, 0x59, 0x45, 0x2e, 0x59, 0xe2,
+	0xa7, 0xff, 0xab, 0x0a, 0xac, 0xcf, 0xbf, 0x55, 0x54, 0x9f, 0x30, 0x29, 0x83, 0x71, 0xa6, 0x6e,
+	0x49, 0xbc, 0x31, 0xec, 0x22, 0x65, 0xa1, 0xa2, 0x2c, 0x8d, 0x66, 0xd6, 0x9f, 0x45, 0x16, 0xe2,
+	0x1a, 0x81, 0x1f, 0x43, 0x8b, 0x19, 0x6a, 0x1a, 0x33, 0xcc, 0xf1, 0x50, 0x46, 0x4e, 0x8f, 0x53,
+	0xfb, 0x06, 0x8d, 0xaf, 0x3b, 0x74, 0x8e, 0xe7, 0x3f, 0x83, 0x4e, 0x16, 0x85, 0x10, 0x50, 0x4a,
+	0x15, 0x08, 0x35, 0x8c, 0xa4, 0x45, 0x03, 0x19, 0x8d, 0x63, 0xa7, 0x89, 0x54, 0xfa, 0xd4, 0x2d,
+	0xd8, 0x74, 0x34, 0xd9, 0x86, 0x97, 0x11, 0xca, 0x70, 0x81, 0x39, 0x24, 0x0d, 0x8e, 0x79, 0xc4,
+	0x15, 0xbe, 0xc3, 0x9a, 0x9e, 0xb0, 0x74, 0xcc, 0xff, 0x7f, 0x68, 0xbb, 0xd8, 0x69, 0x70, 0xde,
+	0x2c, 0x4a, 0x02, 0x83, 0x6e, 0xd7, 0xa8, 0x23, 0x71, 0x0b, 0x66, 0xd7, 0xf7, 0x83, 0x38, 0x66,
+	0xc2, 0xc1, 0xb7, 0x22, 0xcf, 0xf9, 0xba, 0x96, 0xfb, 0xfa, 0xcf, 0x55, 0x68, 0xbb, 0xa0, 0x9e,
+	0xa1, 0xa0, 0x4a, 0x8e, 0x82, 0x70, 0x33, 0xc6, 0x04, 0xc3, 0xcb, 0x8a, 0x8b, 0xcc, 0x68, 0xf3,
+	0x06, 0x82, 0x50, 0x3d, 0x0e, 0x54, 0x78, 0x6a, 0xad, 0x16, 0x38, 0xe4, 0x03, 0xe8, 0x69, 0x18,
+	0x70, 0x10, 0x4c, 0x18, 0x1e, 0xb7, 0xb9, 0xc5, 0x28, 0xb5, 0xc4, 0x27, 0xef, 0xc2, 0xba, 0xe6,
+	0x0d, 0x92, 0x60, 0xc0, 0x04, 0x0f, 0x22, 0x0b, 0x54, 0x16, 0xb8, 0x78, 0xde, 0x71, 0xc1, 0x5c,
+	0x53, 0x2f, 0xa9, 0xc8, 0xc2, 0xc7, 0x35, 0x8a, 0xa5, 0x64, 0xa1, 0x85, 0x22, 0x96, 0x42, 0x07,
+	0xe9, 0xec, 0xbd, 0x13, 0x87, 0xa7, 0x08, 0xcf, 0xda, 0xe6, 0x8c, 0x8b, 0x3c, 0xf2, 0x05, 0xf8,
+	0x92, 0x8f, 0xe3, 0x40, 0x4d, 0x05, 0x7b, 0x70, 0x91, 0x72, 0x31, 0xfb, 0x3a, 0x10, 0x31, 0x8f,
+	0xc7, 0xbb, 0xc1, 0xcc, 0x60, 0xca, 0x06, 0x5d, 0x21, 0xe1, 0x3f, 0x86, 0xeb, 0x4b, 0xe8, 0xa3,
+	0x34, 0x02, 0x10, 0xa8, 0x23, 0x7c, 0xd2, 0xa7, 0xb4, 0x46, 0xf5, 0xb7, 0x2b, 0x32, 0x6a, 0x9a,
+	0x85, 0x9f, 0xfe, 0x01, 0x40, 0x0e, 0x3e, 0x70, 0x3c, 0x65, 0x13, 0x7b, 0xee, 0xf8, 0x49, 0xb6,
+	0x80, 0xe0, 0x35, 0x0e, 0x42, 0x36, 0x98, 0x49, 0xc5, 0x26, 0x34, 0x49, 0x94, 0x7b, 0x4a, 0x25,
+	0x23, 0xfe, 0x00, 0xae, 0x2d, 0x80, 0x10, 0x5c, 0x08, 0x5e, 0x4e, 0xb7, 0x38, 0xfc, 0x46, 0x5e,
+	0x9a, 0xd8, 0xc5, 0x5d, 0xa5, 0xfa, 0x1b, 0x2f, 0x5e, 0x60, 0xa3, 0xb2, 0x2d, 0x30, 0x2c, 0xe9,
+	0xef, 0x41, 0x9b, 0x3a, 0x10, 0xb2, 0x70, 0x3a, 0x95, 0xe5, 0xd3, 0x29, 0x80, 0xf7, 0xea, 0x1c,
+	0x78, 0xf7, 0xff, 0x54, 0x05, 0xc8, 0x61, 0x2c, 0xf9, 0x10, 0xae, 0xb3, 0xa5, 0x13, 0xa8, 0x68,
+	0x95, 0xe5, 0x01, 0xf2, 0x0e, 0x5c, 0x9d, 0xf0, 0x98, 0xca, 0xe0, 0x4b, 0x36, 0x1b, 0xf0, 0x9f,
+	0x31, 0x6b, 0x7c, 0x9e, 0x89, 0x70, 0x78, 0xc2, 0xe3, 0x07, 0xe1, 0x28, 0x97, 0x33, 0xd1, 0x60,
+	0x91, 0x4d, 0xee, 0xc3, 0xeb, 0x27, 0x89, 0x38, 0xe6, 0xa3, 0x11, 0x8b, 0x07, 0xee, 0xbc, 0x77,
+	0xa2, 0x71, 0x22, 0xb8, 0x3a, 0x9d, 0xb8, 0x00, 0xb1, 0x52, 0x06, 0xaf, 0xb4, 0x7b, 0xcf, 0x0f,
+	0xa5, 0x9c, 0x32, 0x61, 0x43, 0xf3, 0x02, 0x57, 0xaf, 0x2a, 0xb8, 0x78, 0x1a, 0x44, 0x7c, 0xc4,
+	0xd5, 0x4c, 0xef, 0xb3, 0x69, 0x57, 0x35, 0xcf, 0xd6, 0x61, 0x90, 0xc7, 0x19, 0xcc, 0x6e, 0x50,
+	0x47, 0xfa, 0xbf, 0xae, 0x40, 0xdb, 0x21, 0x73, 0x7c, 0x97, 0x11, 0x0b, 0x4e, 0x06, 0xfb, 0x3b,
+	0xdb, 0x9f, 0xdc, 0xd5, 0x87, 0xb0, 0x46, 0x0b, 0x1c, 0x1c, 0x97, 0xe9, 0x19, 0xb7, 0xe3, 0x55,
+	0x33, 0x9e, 0x73, 0xf0, 0xa5, 0xc4, 0x89, 0xe2, 0x27, 0xb3, 0xfe, 0x69, 0x10, 0x8f, 0x99, 0x7d,
+	0xd9, 0x73, 0x3c, 0xdc, 0x5c, 0x2a, 0xd8, 0x39, 0x4f, 0xa6, 0xd2, 0xda, 0xa9, 0xeb, 0x7b, 0xb9,
+	0xc0, 0xf5, 0xff, 0x50, 0x85, 0x4e, 0x86, 0xeb, 0x71, 0xe6, 0x09, 0x8f, 0x9f, 0x32, 0xa1, 0xb3,
+	0xa6, 0x39, 0xcd, 0x02, 0x87, 0x7c, 0x0c, 0x37, 0x32, 0x97, 0xf6, 0x79, 0x7a, 0xca, 0xc4, 0x60,
+	0xca, 0x15, 0x93, 0x7a, 0x91, 0x0d, 0x5a, 0x3e, 0x88, 0xaf, 0xd6, 0x0c, 0x3c, 0x8c, 0x25, 0x0b,
+	0xa7, 0x82, 0xcd, 0xa9, 0x9a, 0xd5, 0xaf, 0x90, 0x70, 0x25, 0x27, 0xc7, 0xd3, 0x4b, 0x63, 0x7b,
+	0xb6, 0x45, 0x16, 0xb9, 0x0d, 0x2f, 0x59, 0xf2, 0x30, 0x94, 0xe9, 0x40, 0x05, 0x69, 0xc4, 0xe3,
+	0xb1, 0x0d, 0x51, 0x65, 0x43, 0xe4, 0x1e, 0x78, 0x96, 0x3d, 0x30, 0x88, 0x80, 0x32, 0x39, 0x9d,
+	0xe8, 0x72, 0x52, 0x9f, 0x6e, 0x9b, 0x5e, 0x3a, 0xee, 0x5f, 0x40, 0xdb, 0xc1, 0x29, 0x8d, 0x3f,
+	0xc2, 0x53, 0x36, 0xc9, 0x1b, 0x02, 0x9a, 0xc2, 0x35, 0x87, 0x82, 0x8d, 0x58, 0xac, 0x78, 0x60,
+	0xd3, 0x67, 0x87, 0x16, 0x59, 0x18, 0xb9, 0x55, 0x72, 0xc6, 0xe2, 0x27, 0x19, 0xa2, 0xc8, 0x68,
+	0x63, 0x35, 0x49, 0x99, 0xbb, 0xc8, 0x96, 0xf2, 0xff, 0x03, 0x1a, 0x1a, 0x8f, 0x39, 0x24, 0x52,
+	0xc9, 0x91, 0xc8, 0x73, 0x27, 0xdc, 0xbc, 0x03, 0x6d, 0x57, 0x5b, 0x90, 0x16, 0xd4, 0x76, 0x0e,
+	0xbe, 0xe9, 0x5d, 0x21, 0x6d, 0xa8, 0x3f, 0x3c, 0x7a, 0xfa, 0x71, 0xaf, 0x62, 0xbf, 0xee, 0xf6,
+	0xaa, 0xf8, 0x75, 0xff, 0x70, 0xb8, 0xdf, 0xab, 0x6d, 0xee, 0xc2, 0x5a, 0x11, 0x0a, 0x93, 0x1e,
+	0xac, 0xed, 0x1c, 0x7c, 0xf3, 0xed, 0x11, 0x3d, 0x1c, 0x1e, 0xf6, 0x0f, 0x1f, 0xf5, 0xae, 0x90,
+	0x0e, 0x34, 0x50, 0xe2, 0x4e, 0xaf, 0x42, 0x9a, 0x50, 0xdd, 0xdf, 0xee, 0x55, 0xd1, 0xf6, 0xfe,
+	0x76, 0xbf, 0x57, 0xd3, 0x8c, 0x8f, 0x7a, 0xf5, 0xcd, 0xbf, 0x6d, 0xc0, 0x55, 0x0b, 0x67, 0x64,
+	0x9a, 0xc4, 0x92, 0xfd, 0xa8, 0x1e, 0xc7, 0x7b, 0xd0, 0x94, 0x2a, 0x50, 0x53, 0x93, 0xab, 0xd6,
+	0x0b, 0x88, 0x6b, 0xa0, 0xd9, 0xd4, 0x0e, 0x17, 0x50, 0x5c, 0xa3, 0x0c, 0xc5, 0x35, 0x73, 0xdf,
+	0xe1, 0x83, 0xd3, 0x3a, 0xfd, 0x64, 0xc4, 0xec, 0xd3, 0x2d, 0x70, 0x8a, 0x28, 0xaf, 0xfd, 0x82,
+	0x28, 0xaf, 0x53, 0x40, 0x79, 0x98, 0xb7, 0xf9, 0xc4, 0x74, 0x2f, 0x1a, 0x54, 0x7f, 0x93, 0x5d,
+	0xe8, 0x86, 0x85, 0x82, 0xb9, 0xab, 0x21, 0xf8, 0x52, 0x35, 0x62, 0xdc, 0xb5, 0x55, 0xac, 0x95,
+	0x8b, 0x6a, 0xe4, 0x13, 0x68, 0x2a, 0x3e, 0xc1, 0xab, 0xbe, 0x56, 0xde, 0x4f, 0xb1, 0x06, 0x86,
+	0x5a, 0x88, 0x5a, 0x61, 0x6c, 0x74, 0x31, 0x21, 0x12, 0xa1, 0x8b, 0xc9, 0x0e, 0x35, 0x04, 0x16,
+	0x25, 0xb8, 0x34, 0xa9, 0x82, 0x49, 0xaa, 0x4b, 0xc5, 0x0e, 0xcd, 0x19, 0xa8, 0xa3, 0xb1, 0x96,
+	0xae, 0x03, 0x3b, 0xd4, 0x10, 0xb9, 0xe7, 0x86, 0xec, 0x42, 0xe9, 0xf2, 0xae, 0x43, 0x0b, 0x1c,
+	0xbc, 0xe4, 0xcf, 0x4c, 0x1a, 0x90, 0x1e, 0x31, 0xf0, 0xc4, 0xd1, 0xe4, 0x33, 0x68, 0xa9, 0x48,
+	0x0e, 0xc2, 0x20, 0xf6, 0x5e, 0x2a, 0x2f, 0x5d, 0xdc, 0xea, 0x1f, 0x0d, 0x50, 0x8a, 0x3a, 0x71,
+	0xf2, 0x21, 0xd4, 0x46, 0xb1, 0xb4, 0xb5, 0x97, 0x7f, 0x89, 0xd6, 0xee, 0xc1, 0x80, 0xa2, 0x18,
+	0xde, 0x83, 0x13, 0x53, 0x77, 0xdf, 0x30, 0xf7, 0xc0, 0x50, 0xe4, 0x03, 0xa8, 0xf3, 0xf4, 0xfc,
+	0x63, 0xef, 0xa6, 0x36, 0x73, 0xb3, 0xdc, 0x0c, 0xd5, 0x32, 0x56, 0xf6, 0xae, 0xf7, 0xca, 0x73,
+	0x65, 0xef, 0x92, 0x2f, 0xa0, 0x33, 0x8a, 0x25, 0x65, 0x3a, 0x47, 0x7b, 0xe5, 0x9d, 0x8b, 0xc2,
+	0x1a, 0xb5, 0x1c, 0xcd, 0x55, 0xc8, 0x27, 0x50, 0xc7, 0x1a, 0xda, 0x7b, 0xb5, 0xbc, 0xb4, 0xb4,
+	0xaa, 0x88, 0x75, 0xad, 0xae, 0x16, 0x27, 0xbb, 0xd0, 0xc9, 0x2a, 0x66, 0xcf, 0xdf, 0xa8, 0x94,
+	0x55, 0x5b, 0x56, 0x37, 0x43, 0xef, 0x6e, 0xf2, 0x4c, 0x11, 0x27, 0x47, 0x05, 0xef, 0xb5, 0x95,
+	0x93, 0x63, 0xe1, 0xe0, 0x26, 0xc7, 0x61, 0xbc, 0xe2, 0x01, 0xc6, 0xe6, 0xd7, 0xcd, 0xb5, 0xc7,
+	0x6f, 0xf4, 0x7b, 0x10, 0xa9, 0xc1, 0x79, 0xe8, 0xbd, 0x61, 0xfc, 0x6e, 0x28, 0xb2, 0x3f, 0x5f,
+	0x41, 0xbf, 0x59, 0x5e, 0x18, 0xba, 0xb3, 0xcf, 0x25, 0x75, 0x61, 0x58, 0x54, 0x25, 0xf7, 0xf2,
+	0x42, 0xf9, 0xad, 0x8d, 0xda, 0x0a, 0x3f, 0x0f, 0x98, 0x5a, 0xac, 0x9c, 0x3f, 0x83, 0x96, 0x6d,
+	0x5a, 0x7a, 0x1b, 0x2b, 0x6f, 0x9f, 0xad, 0xc1, 0xa8, 0x13, 0x47, 0x2c, 0x63, 0x8a, 0x4c, 0x57,
+	0x06, 0xbf, 0xad, 0xb7, 0x37, 0xcf, 0xf4, 0xff, 0x5e, 0x83, 0x6e, 0x11, 0x65, 0x62, 0x09, 0xa3,
+	0x21, 0xf2, 0x40, 0x61, 0x20, 0xb3, 0x81, 0x6f, 0x8e, 0x87, 0x2f, 0x30, 0x70, 0xf8, 0xc4, 0x22,
+	0xa4, 0x9c, 0x81, 0xa3, 0xe7, 0x88, 0x36, 0xf6, 0x44, 0x32, 0xb1, 0x19, 0x23, 0x67, 0x20, 0xf6,
+	0xd0, 0xc4, 0x30, 0xb1, 0x6d, 0x5e, 0x47, 0xe2, 0x88, 0x9c, 0x1e, 0xff, 0x94, 0x85, 0xca, 0x06,
+	0x42, 0x47, 0xe2, 0x09, 0x71, 0x83, 0x7c, 0x4c, 0x30, 0xb4, 0x14, 0x62, 0xbb, 0x13, 0x1e, 0x8f,
+	0x99, 0x48, 0x05, 0x8f, 0x95, 0xc5, 0x0f, 0x2d, 0x8d, 0x1f, 0x96, 0x07, 0xd0, 0x8a, 0xd9, 0x85,
+	0xee, 0x1e, 0xae, 0x51, 0x4b, 0x61, 0x79, 0x21, 0xb2, 0x06, 0xa6, 0x89, 0xcd, 0x36, 0x2c, 0x2e,
+	0xf1, 0x17, 0x64, 0x4d, 0x41, 0x0f, 0x4b, 0xb2, 0x59, 0x69, 0x5f, 0x80, 0x47, 0x5d, 0x3d, 0x67,
+	0x81, 0x43, 0x3e, 0x87, 0x57, 0x1d, 0xc8, 0xd9, 0x5b, 0xda, 0xc5, 0x9a, 0x16, 0xbf, 0x5c, 0x80,
+	0x6c, 0x41, 0x5d, 0x22, 0x80, 0xbb, 0xba, 0x51, 0x5b, 0x11, 0x5c, 0x06, 0xfd, 0x21, 0xd5, 0x72,
+	0xfe, 0xf7, 0x50, 0x1b, 0xf4, 0x87, 0x18, 0x1e, 0xa3, 0x04, 0x53, 0x96, 0x81, 0xff, 0x86, 0xc0,
+	0x54, 0x13, 0x25, 0x63, 0x9b, 0xc6, 0xf0, 0x73, 0x3e, 0xc8, 0xd6, 0x16, 0x83, 0x6c, 0xde, 0xcd,
+	0xa8, 0xcf, 0x75, 0x33, 0x7c, 0x68, 0x9f, 0x33, 0xc1, 0x4f, 0x38, 0x1b, 0x59, 0x50, 0x93, 0xd1,
+	0xfe, 0x1f, 0x2b, 0xd0, 0x34, 0xf1, 0x9d, 0xf4, 0x4c, 0x5c, 0x34, 0xb8, 0x0d, 0x3f, 0xd1, 0x57,
+	0x61, 0x12, 0xc7, 0x2c, 0x54, 0x3c, 0x36, 0xeb, 0x68, 0xd0, 0x02, 0xa7, 0x58, 0x71, 0x36, 0x74,
+	0xc5, 0x69, 0x3a, 0x01, 0xf1, 0x08, 0xc5, 0xeb, 0x9a, 0xeb, 0x48, 0x1c, 0x79, 0x16, 0x70, 0xe5,
+	0x80, 0x55, 0x83, 0x3a, 0x12, 0x37, 0x25, 0x58, 0xc8, 0xf8, 0x39, 0x8e, 0x19, 0x6c, 0x9c, 0x33,
+	0x6c, 0xe6, 0xb8, 0x98, 0xd9, 0xc4, 0x6a, 0x08, 0xff, 0xb7, 0x55, 0x68, 0xd9, 0xc0, 0x4e, 0xee,
+	0xeb, 0xed, 0x49, 0xdd, 0xbc, 0xa8, 0xac, 0x0e, 0x07, 0x46, 0x63, 0xcb, 0x02, 0x52, 0x9a, 0xe9,
+	0x65, 0x11, 0xc8, 0x14, 0xc1, 0xfa, 0x1b, 0xdf, 0x5c, 0x52, 0xc4, 0x83, 0x16, 0x28, 0x17, 0x79,
+	0x78, 0xd7, 0xe5, 0x12, 0x02, 0x34, 0x55, 0xf0, 0xf2, 0x80, 0xcf, 0xa0, 0x65, 0xa7, 0xd6, 0x0f,
+	0xae, 0x00, 0x94, 0x3b, 0xd4, 0x91, 0xe8, 0x0e, 0x39, 0x4d, 0x31, 0x3a, 0xb2, 0x91, 0xad, 0xf6,
+	0x72, 0x06, 0x2e, 0x2a, 0x9c, 0xc7, 0xbf, 0xba, 0xce, 0x2d, 0xf2, 0x7c, 0x0e, 0xb5, 0xdd, 0x83,
+	0x41, 0x69, 0xf1, 0xe7, 0x17, 0x7a, 0xd0, 0xb6, 0x7b, 0xe1, 0x68, 0xf4, 0xb4, 0x08, 0x11, 0xc2,
+	0x98, 0x8b, 0x65, 0x08, 0x1d, 0x55, 0x4c, 0x50, 0xca, 0xf0, 0x64, 0xce, 0xf0, 0x7f, 0x68, 0x40,
+	0x27, 0x4b, 0x43, 0x97, 0xd5, 0xc2, 0xba, 0xed, 0x50, 0x9d, 0x6f, 0x3b, 0x64, 0xab, 0xa8, 0x2d,
+	0xac, 0xc2, 0xc3, 0xc8, 0x1a, 0x26, 0x62, 0xe4, 0x66, 0x73, 0x24, 0x79, 0x3c, 0x5f, 0x7e, 0x9a,
+	0x5f, 0x9c, 0xfe, 0xfd, 0x79, 0xb9, 0x71, 0x2b, 0xef, 0x43, 0xcc, 0xd7, 0xaa, 0xff, 0x95, 0x75,
+	0x12, 0x9a, 0xe5, 0xbf, 0x37, 0x2c, 0x59, 0xda, 0x3d, 0x18, 0x0c, 0x1e, 0xf4, 0x5d, 0xcb, 0xc1,
+	0xff, 0x79, 0x05, 0x20, 0x37, 0x5e, 0xba, 0xf9, 0x42, 0x5d, 0x5d, 0x9d, 0xab, 0xab, 0x8b, 0xdb,
+	0xac, 0xcd, 0x6f, 0x33, 0x0f, 0x88, 0x75, 0x5d, 0xa1, 0x5b, 0x2a, 0x87, 0x5d, 0x8d, 0x02, 0xec,
+	0xf2, 0x7f, 0x53, 0x85, 0xa6, 0x59, 0x97, 0x56, 0x34, 0x71, 0xd2, 0x15, 0x13, 0x9a, 0x22, 0x5f,
+	0x01, 0x64, 0x4d, 0x0d, 0x53, 0x6b, 0x75, 0xb7, 0xef, 0xbc, 0xe0, 0x66, 0xb7, 0xb2, 0xd2, 0x97,
+	0x16, 0x8c, 0xf8, 0xbf, 0xab, 0x40, 0x27, 0x1b, 0x79, 0xe1, 0x63, 0xc7, 0x05, 0xf2, 0x71, 0x9c,
+	0x1d, 0xba, 0xa5, 0x90, 0x7f, 0xc6, 0x66, 0xc3, 0x60, 0xec, 0x76, 0x6c, 0x28, 0xbc, 0x7a, 0x3c,
+	0x0e, 0x59, 0x9a, 0xfd, 0xb2, 0xd5, 0xa1, 0x39, 0xc3, 0xf6, 0x68, 0xb9, 0x08, 0xb2, 0xaa, 0xab,
+	0x43, 0x0b, 0x1c, 0xff, 0x14, 0x20, 0x47, 0x39, 0x78, 0xe5, 0xc6, 0x82, 0x31, 0x95, 0x27, 0xcf,
+	0x8c, 0xd6, 0x6f, 0xaa, 0xd8, 0xae, 0xab, 0xda, 0x37, 0x55, 0xe0, 0xcd, 0xb5, 0x04, 0x6b, 0xf3,
+	0x2d, 0x41, 0xff, 0x17, 0x15, 0xb8, 0xb6, 0x00, 0x8a, 0xb0, 0xa0, 0x2a, 0xf4, 0x17, 0xed, 0x94,
+	0x45, 0x96, 0x7e, 0x6e, 0x85, 0xae, 0xa6, 0x21, 0x4c, 0x30, 0xb4, 0xbd, 0x4b, 0x1b, 0x58, 0x73,
+	0x86, 0x0e, 0xc8, 0x11, 0x0b, 0xe2, 0x7e, 0x94, 0x48, 0x66, 0xe3, 0x4c, 0x81, 0xe3, 0x53, 0x80,
+	0x1c, 0x5c, 0xad, 0x68, 0xcb, 0xe6, 0x57, 0xa5, 0x3a, 0x77, 0x55, 0xb0, 0x71, 0x95, 0x47, 0x00,
+	0xfd, 0xed, 0x7f, 0x07, 0xd7, 0xf2, 0x16, 0x30, 0x93, 0xd3, 0x48, 0x91, 0xdb, 0xd0, 0xc9, 0x5a,
+	0xc1, 0xda, 0x74, 0x79, 0xbf, 0x38, 0x17, 0x2a, 0xff, 0x71, 0x1c, 0x97, 0x91, 0xa2, 0xcc, 0xc8,
+	0xba, 0xd4, 0x52, 0xfe, 0x0f, 0x55, 0xb8, 0xb6, 0x00, 0xdd, 0x7e, 0x64, 0xa7, 0x7d, 0xbe, 0x46,
+	0xab, 0x2f, 0xd5, 0x68, 0xae, 0xc8, 0x6a, 0x14, 0x8a, 0xac, 0xbc, 0x3c, 0x6a, 0xfe, 0x2b, 0xe5,
+	0xd1, 0x5e, 0x49, 0x37, 0xfd, 0xb2, 0x84, 0xb4, 0xe0, 0xd8, 0x62, 0x87, 0x3d, 0x7f, 0xef, 0xed,
+	0xe2, 0x7b, 0xff, 0x0b, 0xbe, 0x3c, 0x87, 0x47, 0x4b, 0x0a, 0x79, 0xe7, 0xa6, 0xea, 0xbc, 0x9b,
+	0x46, 0xc9, 0x24, 0xe0, 0xee, 0x97, 0x0c, 0x4b, 0xa1, 0x6c, 0x1a, 0xa8, 0x53, 0x8b, 0x16, 0xf4,
+	0x37, 0xde, 0x19, 0xfd, 0x86, 0x98, 0x74, 0x70, 0xcf, 0x92, 0xda, 0xd9, 0xc1, 0xc5, 0xce, 0x98,
+	0xd9, 0x1c, 0x6d, 0x29, 0x13, 0xaf, 0xb0, 0xeb, 0xe2, 0x3a, 0xb2, 0x86, 0xd2, 0x8d, 0x72, 0xa5,
+	0xd2, 0xc3, 0x38, 0x32, 0x3f, 0x0c, 0xb7, 0x69, 0x46, 0xe3, 0x98, 0x0c, 0x26, 0x6c, 0xc0, 0xed,
+	0xaf, 0xbd, 0x1d, 0x9a, 0xd1, 0xbe, 0x80, 0x56, 0xe1, 0xcf, 0x0c, 0xf6, 0x7c, 0x2b, 0x65, 0xe7,
+	0x5b, 0x2d, 0xfd, 0x25, 0xa5, 0xf6, 0x82, 0x35, 0x76, 0x3d, 0xaf, 0xb1, 0x3f, 0x78, 0x0f, 0x9a,
+	0x16, 0x4a, 0x36, 0xa1, 0xfa, 0xe4, 0xc8, 0x74, 0x36, 0x76, 0x0f, 0xbf, 0x3e, 0xe8, 0x55, 0x48,
+	0x17, 0x5a, 0x4f, 0x0e, 0xbe, 0x3c, 0x40, 0xa2, 0xba, 0xfd, 0x3d, 0xac, 0xdb, 0xbf, 0xa2, 0x0c,
+	0xec, 0x53, 0xba, 0x07, 0xcd, 0x47, 0x5c, 0x2a, 0x16, 0x93, 0x1b, 0xf3, 0x87, 0x6c, 0xff, 0xad,
+	0xe2, 0xdf, 0x28, 0xfd, 0x51, 0x66, 0xf3, 0xca, 0xed, 0x0a, 0xb9, 0x03, 0x4d, 0xfb, 0xa2, 0x2e,
+	0xa9, 0x09, 0xfd, 0xab, 0x19, 0x5f, 0xff, 0x13, 0xe6, 0xca, 0x71, 0x53, 0xc7, 0x8f, 0x8f, 0xfe,
+	0x39, 0x00, 0x8a, 0x0c, 0x1d, 0xc8, 0x3c, 0x23, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
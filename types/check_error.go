@@ -10,6 +10,10 @@ const (
 	// NxDomain means the domain wasn't found
 	NxDomain CheckError = "nxdomain"
 
+	// NoSuitableAddress means the host has no address in the address family
+	// the check is restricted to
+	NoSuitableAddress CheckError = "no-suitable-address"
+
 	// DNSError means there was an error performing the DNS lookup
 	DNSError CheckError = "dns_error"
 
@@ -43,11 +47,253 @@ const (
 	// CertIncompleteChain means the certificate chain is incomplete
 	CertIncompleteChain CheckError = "cert-incomplete-chain"
 
+	// CertExpiringSoon means the SSL certificate expires within the warning period
+	CertExpiringSoon CheckError = "cert-expiring-soon"
+
+	// CertWeakKey means the certificate key is smaller than the policy allows
+	CertWeakKey CheckError = "cert-weak-key"
+
+	// CertWeakSignature means the certificate is signed with a forbidden algorithm
+	CertWeakSignature CheckError = "cert-weak-signature"
+
+	// CertPolicyInvalid means the certificate policy can't be used, e.g. a
+	// signature algorithm isn't known
+	CertPolicyInvalid CheckError = "cert-policy-invalid"
+
+	// CertWrongIssuer means the certificate was not issued by the required issuer
+	CertWrongIssuer CheckError = "cert-wrong-issuer"
+
+	// CertValidityTooLong means the certificate validity period exceeds the policy
+	CertValidityTooLong CheckError = "cert-validity-too-long"
+
+	// CertRevoked means the certificate has been revoked by the issuer
+	CertRevoked CheckError = "cert-revoked"
+
+	// CertRevocationUnknown means the revocation status could not be determined
+	CertRevocationUnknown CheckError = "cert-revocation-unknown"
+
+	// CertPinMismatch means the certificate didn't match any of the pins
+	CertPinMismatch CheckError = "cert-pin-mismatch"
+
+	// CertChanged means the certificate is different to the previous check
+	CertChanged CheckError = "cert-changed"
+
+	// CertInsufficientSCTs means the certificate doesn't have enough valid SCTs
+	CertInsufficientSCTs CheckError = "cert-insufficient-scts"
+
+	// TLSWeakVersion means the server accepts a TLS version below the policy
+	TLSWeakVersion CheckError = "tls-weak-version"
+
+	// TLSWeakCipher means the server accepts a forbidden cipher suite
+	TLSWeakCipher CheckError = "tls-weak-cipher"
+
+	// TLSMissingALPN means the server doesn't support a required ALPN protocol
+	TLSMissingALPN CheckError = "tls-missing-alpn"
+
+	// TLSNoOCSPStapling means the server doesn't staple an OCSP response
+	TLSNoOCSPStapling CheckError = "tls-no-ocsp-stapling"
+
+	// TLSNoSessionResumption means the server doesn't support session resumption
+	TLSNoSessionResumption CheckError = "tls-no-session-resumption"
+
+	// ClientCertInvalid means the client certificate couldn't be loaded
+	ClientCertInvalid CheckError = "client-cert-invalid"
+
+	// ClientCertRejected means the server rejected or required a client certificate
+	ClientCertRejected CheckError = "client-cert-rejected"
+
+	// CABundleInvalid means the CA bundle for the check couldn't be parsed
+	CABundleInvalid CheckError = "ca-bundle-invalid"
+
+	// ResolverInvalid means a nameserver for the check couldn't be parsed
+	ResolverInvalid CheckError = "resolver-invalid"
+
+	// DNSInvalidRecordType means the record type can't be checked
+	DNSInvalidRecordType CheckError = "dns-invalid-record-type"
+
+	// DNSNoRecords means there were no records of the type
+	DNSNoRecords CheckError = "dns-no-records"
+
+	// DNSRecordMismatch means the records didn't match the expected values
+	DNSRecordMismatch CheckError = "dns-record-mismatch"
+
+	// DNSNameserverMismatch means the authoritative nameservers disagree
+	DNSNameserverMismatch CheckError = "dns-nameserver-mismatch"
+
+	// DNSNameserverUnreachable means an authoritative nameserver couldn't be
+	// queried
+	DNSNameserverUnreachable CheckError = "dns-nameserver-unreachable"
+
+	// DNSSerialMismatch means the authoritative nameservers have different
+	// SOA serials
+	DNSSerialMismatch CheckError = "dns-serial-mismatch"
+
+	// DNSSECBogus means a signature or key in the chain of trust is invalid
+	// or missing
+	DNSSECBogus CheckError = "dnssec-bogus"
+
+	// DNSSECInsecure means the chain of trust reaches an unsigned zone
+	DNSSECInsecure CheckError = "dnssec-insecure"
+
+	// DNSSECExpired means a signature in the chain of trust has expired
+	DNSSECExpired CheckError = "dnssec-signature-expired"
+
+	// DNSSECSignatureExpiringSoon means a signature in the chain of trust
+	// will expire soon
+	DNSSECSignatureExpiringSoon CheckError = "dnssec-signature-expiring-soon"
+
+	// DNSSECInvalidTrustAnchor means the trust anchors for the check
+	// couldn't be parsed
+	DNSSECInvalidTrustAnchor CheckError = "dnssec-invalid-trust-anchor"
+
+	// TCPMissingPort means the TCP check URL doesn't have a port
+	TCPMissingPort CheckError = "tcp-missing-port"
+
+	// TCPInvalidPattern means the banner regular expression couldn't be
+	// compiled
+	TCPInvalidPattern CheckError = "tcp-invalid-pattern"
+
+	// TCPBannerMismatch means the data received didn't match the expected
+	// banner
+	TCPBannerMismatch CheckError = "tcp-banner-mismatch"
+
+	// MailBadGreeting means the mail server didn't send a valid greeting
+	MailBadGreeting CheckError = "mail-bad-greeting"
+
+	// MailCommandFailed means the mail server rejected a command
+	MailCommandFailed CheckError = "mail-command-failed"
+
+	// MailProtocolError means the response from the mail server couldn't be
+	// parsed
+	MailProtocolError CheckError = "mail-protocol-error"
+
+	// MailStartTLSUnsupported means STARTTLS was requested, but the mail
+	// server doesn't advertise it
+	MailStartTLSUnsupported CheckError = "mail-starttls-unsupported"
+
+	// MailMissingCapability means the mail server doesn't advertise a
+	// required capability
+	MailMissingCapability CheckError = "mail-missing-capability"
+
+	// WebSocketHandshakeFailed means the server didn't upgrade the connection
+	// to a WebSocket
+	WebSocketHandshakeFailed CheckError = "websocket-handshake-failed"
+
+	// WebSocketInvalidPattern means the reply regular expression couldn't be
+	// compiled
+	WebSocketInvalidPattern CheckError = "websocket-invalid-pattern"
+
+	// WebSocketReplyTimeout means no reply was received to the message
+	WebSocketReplyTimeout CheckError = "websocket-reply-timeout"
+
+	// WebSocketReplyMismatch means the reply didn't match the expected pattern
+	WebSocketReplyMismatch CheckError = "websocket-reply-mismatch"
+
+	// WebSocketUncleanClose means the server didn't acknowledge the close
+	// frame
+	WebSocketUncleanClose CheckError = "websocket-unclean-close"
+
+	// GRPCNotServing means the health service reported NOT_SERVING
+	GRPCNotServing CheckError = "grpc-not-serving"
+
+	// GRPCHealthUnknown means the health service reported UNKNOWN
+	GRPCHealthUnknown CheckError = "grpc-health-unknown"
+
+	// GRPCServiceNotFound means the health service doesn't know the service
+	GRPCServiceNotFound CheckError = "grpc-service-not-found"
+
+	// GRPCUnimplemented means the server doesn't implement the health service
+	GRPCUnimplemented CheckError = "grpc-unimplemented"
+
+	// GRPCUnavailable means the server couldn't handle the call
+	GRPCUnavailable CheckError = "grpc-unavailable"
+
+	// GRPCError means the call failed with another gRPC status code
+	GRPCError CheckError = "grpc-error"
+
+	// HTTPProtocolInvalid means the HTTP protocol preference isn't known
+	HTTPProtocolInvalid CheckError = "http-protocol-invalid"
+
+	// HTTPProtocolNotNegotiated means the server didn't use the required HTTP
+	// protocol
+	HTTPProtocolNotNegotiated CheckError = "http-protocol-not-negotiated"
+
+	// TransactionNoSteps means the transaction doesn't have any steps
+	TransactionNoSteps CheckError = "transaction-no-steps"
+
+	// TransactionInvalidStep means a step has an invalid URL, pattern or
+	// source
+	TransactionInvalidStep CheckError = "transaction-invalid-step"
+
+	// TransactionUndefinedVariable means a step uses a variable which hasn't
+	// been extracted
+	TransactionUndefinedVariable CheckError = "transaction-undefined-variable"
+
+	// TransactionExtractionFailed means a value couldn't be extracted from
+	// the response
+	TransactionExtractionFailed CheckError = "transaction-extraction-failed"
+
+	// AssertionFailed means the response didn't match an assertion
+	AssertionFailed CheckError = "assertion-failed"
+
+	// AuthInvalid means the authentication options are invalid
+	AuthInvalid CheckError = "auth-invalid"
+
+	// AuthCredentialsInvalid means the credentials weren't found in the
+	// secret store, or aren't in the expected format
+	AuthCredentialsInvalid CheckError = "auth-credentials-invalid"
+
+	// AuthChallengeInvalid means the server didn't send a supported Digest
+	// challenge
+	AuthChallengeInvalid CheckError = "auth-challenge-invalid"
+
+	// AuthFailed means the server rejected the credentials
+	AuthFailed CheckError = "auth-failed"
+
+	// OAuth2TokenFailed means an access token couldn't be fetched from the
+	// token endpoint
+	OAuth2TokenFailed CheckError = "oauth2-token-failed"
+
+	// SecretNotFound means a placeholder references a secret which isn't in
+	// the agent's secret store
+	SecretNotFound CheckError = "secret-not-found"
+
+	// TemplateInvalid means the request contains an unknown template value,
+	// or one with invalid arguments
+	TemplateInvalid CheckError = "template-invalid"
+
+	// ProxyInvalid means the proxy URL or credentials are invalid
+	ProxyInvalid CheckError = "proxy-invalid"
+
+	// ProxyConnectFailed means the connection to the proxy failed
+	ProxyConnectFailed CheckError = "proxy-connect-failed"
+
+	// ProxyTimeout means the proxy didn't respond in time
+	ProxyTimeout CheckError = "proxy-timeout"
+
+	// ProxyAuthFailed means the proxy rejected the credentials, or requires
+	// them
+	ProxyAuthFailed CheckError = "proxy-auth-failed"
+
+	// ProxyRejected means the proxy refused to connect to the target
+	ProxyRejected CheckError = "proxy-rejected"
+
+	// BindAddressInvalid means the bind address isn't an IP address or
+	// interface, or the interface has no usable address
+	BindAddressInvalid CheckError = "bind-address-invalid"
+
+	// BindAddressUnavailable means the bind address isn't assigned to this
+	// host
+	BindAddressUnavailable CheckError = "bind-address-unavailable"
+
 	// TLSAlert tls protocol or other tls certificate error
 	TLSAlert CheckError = "tls_alert"
 
 	// UnsupportedSchema schema error
 	UnsupportedSchema CheckError = "unsupported_schema"
+
+	// UnsupportedCheckType means the check type isn't registered
+	UnsupportedCheckType CheckError = "unsupported-check-type"
 )
 
 // ToString converts an error to a string
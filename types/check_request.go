@@ -1,26 +1,35 @@
 package types
 
 import (
+	"net/http"
 	"net/url"
 	"time"
 )
 
+// AdHocRef is the Ref of checks run once from the command line, rather than
+// for a monitor
+const AdHocRef = "-1"
+
 // CheckRequest is a check request
 type CheckRequest struct {
 	// ID of monitoring or caller reference
 	Ref string
 
+	// Type of check, e.g. http or dns. If empty the URL scheme is used.
+	Type string
+
 	// HTTP method to use
 	Method string
 
 	// URL to check
 	URL *url.URL
 
-	// Additional request headers for request
-	//Headers map[string][]string
+	// Additional request headers for request, these may contain template
+	// values such as ${timestamp}
+	Headers http.Header
 
-	// Request body for request
-	//Body string
+	// Request body for request, this may contain template values
+	Body string
 
 	// Request timeout
 	Timeout time.Duration
@@ -43,4 +52,149 @@ type CheckOptions struct {
 
 	// Follow redirects while performing the request
 	FollowRedirects bool
+
+	// Policy the SSL certificate must satisfy
+	CertPolicy CertPolicy
+
+	// Check if the SSL certificate has been revoked using OCSP or CRLs
+	CheckRevocation bool
+
+	// Expected certificates and change detection
+	CertPins CertPins
+
+	// Extract and verify Certificate Transparency SCTs
+	CheckSCTs bool
+
+	// Policy the TLS configuration must satisfy, only used by TLS scans
+	TLSPolicy TLSPolicy
+
+	// Client certificate presented to the server for mutual TLS
+	ClientCertificate ClientCertificate
+
+	// Additional certificate authorities trusted for this check
+	TrustedCAs TrustedCAs
+
+	// Connect to these addresses instead of resolving the hostname
+	ResolveOverrides []ResolveOverride
+
+	// Nameservers used instead of the agent or system resolver
+	Resolver Resolver
+
+	// Address family to connect with
+	IPFamily IPFamily
+
+	// HTTP version to use or require
+	HTTPProtocol HTTPProtocol
+
+	// Store cookies set by responses, and send them with redirects
+	CookieJar bool
+
+	// Cookies sent with the request
+	Cookies []Cookie
+
+	// Authentication with the server
+	HTTPAuth HTTPAuth
+
+	// Proxy used instead of the agent's proxy
+	Proxy Proxy
+
+	// Local IP address or network interface connections are made from,
+	// e.g. 192.0.2.10 or eth1, instead of the agent's. On Linux connections
+	// are also bound to the interface, on other systems only its address is
+	// used.
+	BindAddress string
+
+	// Records to check, only used for DNS checks
+	DNSCheck DNSCheck
+
+	// Payload and banner, only used for TCP checks
+	TCPCheck TCPCheck
+
+	// STARTTLS and capabilities, only used for mail checks
+	MailCheck MailCheck
+
+	// Message and reply, only used for WebSocket checks
+	WebSocketCheck WebSocketCheck
+
+	// Service name, only used for gRPC checks
+	GRPCCheck GRPCCheck
+
+	// Steps, only used for transaction checks
+	Transaction Transaction
+}
+
+// CertPolicy certificate policy options, zero values disable each check
+type CertPolicy struct {
+	// Warn if the certificate expires within this many days
+	ExpiryWarningDays int
+
+	// Minimum key size in bits for RSA keys
+	MinRSAKeySize int
+
+	// Minimum key size in bits for ECDSA keys
+	MinECDSAKeySize int
+
+	// Names of the signature algorithms that are not allowed, e.g. SHA1-RSA
+	ForbiddenSignatureAlgorithms []string
+
+	// Issuer common name or organization the certificate must be issued by
+	RequiredIssuer string
+
+	// Maximum number of days between the certificate's valid from and to dates
+	MaxValidityDays int
+
+	// Minimum number of distinct CT logs with a valid SCT, implies CheckSCTs
+	MinSCTs int
+}
+
+// CertPins certificate pinning options
+type CertPins struct {
+	// SHA-256 fingerprints of the allowed leaf certificates
+	LeafSHA256 [][]byte
+
+	// SHA-256 hashes of the allowed public keys (SPKI), matched against any
+	// certificate in the chain
+	SPKISHA256 [][]byte
+
+	// Warn if the leaf certificate is different to the previous check
+	NotifyChange bool
+
+	// Fingerprint of the leaf certificate seen by the previous check, if not
+	// set the last fingerprint seen by this checker is used
+	PreviousSHA256 []byte
+}
+
+// ClientCertificate is a client certificate for mutual TLS, either a named
+// credential stored on the agent or an inline certificate and key
+type ClientCertificate struct {
+	// Name of a credential stored on the agent
+	Name string
+
+	// PEM encoded certificate, optionally followed by intermediates
+	CertPEM []byte
+
+	// PEM encoded private key
+	KeyPEM []byte
+}
+
+// TrustedCAs are additional certificate authorities, which are merged with
+// the agent and system certificate authorities
+type TrustedCAs struct {
+	// PEM encoded root and intermediate certificates
+	PEM []byte
+
+	// Don't trust the system roots
+	ReplaceSystemRoots bool
+}
+
+// ResolveOverride sends connections for a host to a specific address, like
+// curl's --resolve option. The Host header and SNI still use the hostname.
+type ResolveOverride struct {
+	Host string
+
+	// Port the override applies to, or 0 for all ports
+	Port int
+
+	// IP address to connect to
+	Address string
 }
@@ -34,12 +34,56 @@ type CheckResult struct {
 	// Overall time for the request
 	Time *time.Duration
 
+	// Protocol negotiated with ALPN, e.g. h2 or http/1.1
+	ALPN string
+
+	// Alternative services advertised by the server
+	AltSvc string
+
+	// Request sent after template values were rendered, with secrets
+	// redacted
+	Request *RenderedRequest
+
+	// Cookies set by the responses, including redirects
+	Cookies []SetCookie
+
 	// Information about the SSL certificate
 	Certificate *CertInfo
 
 	// Detailed timings of the request
 	Timing *RequestTiming
 
+	// Address family of the connection
+	Family IPFamily
+
+	// Local IP address the connection was made from
+	SourceAddress string
+
+	// Results for each address family, only set when checking both
+	IPv4 *CheckResult
+	IPv6 *CheckResult
+
+	// Answer from the custom resolver, only set if one was used
+	DNS *DNSResolution
+
+	// Records found, only set for DNS checks
+	DNSReport *DNSReport
+
+	// Report of the TLS configuration, only set for TLS scans
+	TLSScan *TLSScanReport
+
+	// Greeting and capabilities, only set for mail checks
+	Mail *MailReport
+
+	// Reply and close status, only set for WebSocket checks
+	WebSocket *WebSocketReport
+
+	// Health service response, only set for gRPC checks
+	GRPC *GRPCReport
+
+	// Results of each step, only set for transaction checks
+	Transaction []TransactionStepResult
+
 	// TODO
 	// Results of assertions
 	// Assertions AssertionResult
@@ -47,6 +91,9 @@ type CheckResult struct {
 	// Error description
 	Error string
 
+	// Warnings which didn't cause the check to fail
+	Warnings []string
+
 	// Time the check was completed
 	Timestamp *time.Time
 }
@@ -69,10 +116,15 @@ type ResponseHeader struct {
 type RequestTiming struct {
 	DNS        *time.Duration
 	Connecting *time.Duration
-	TLS        *time.Duration
-	Sending    *time.Duration
-	Waiting    *time.Duration
-	Receiving  *time.Duration
+
+	// Handshake with the proxy after connecting to it, nil if the connection
+	// was direct
+	Proxy *time.Duration
+
+	TLS       *time.Duration
+	Sending   *time.Duration
+	Waiting   *time.Duration
+	Receiving *time.Duration
 }
 
 // CertInfo contains information about the TLS certificate used
@@ -85,4 +137,31 @@ type CertInfo struct {
 	Subject           string
 	Issuer            string
 	FingerprintSHA256 []byte
+	SPKISHA256        []byte
+	RevocationStatus  RevocationStatus
+	RevocationSource  RevocationSource
+
+	// Fingerprint of the previous certificate, only set if it has changed
+	PreviousFingerprintSHA256 []byte
+
+	// Signed Certificate Timestamps, only set if SCTs were checked
+	SCTs []SCTInfo
+}
+
+// SCTInfo contains information about a Signed Certificate Timestamp
+type SCTInfo struct {
+	// SHA-256 hash of the log's public key
+	LogID []byte
+
+	// Description of the log, empty if the log is unknown
+	Log string
+
+	// Time the log promised to include the certificate
+	Timestamp time.Time
+
+	// Where the SCT was delivered, embedded, tls or ocsp
+	Source string
+
+	// Whether the signature was verified against a known log
+	Verified bool
 }
@@ -0,0 +1,28 @@
+package types
+
+import "time"
+
+// Cookie is a cookie sent with the request
+type Cookie struct {
+	Name  string
+	Value string
+}
+
+// SetCookie is a cookie set by a response. The value isn't recorded, as it's
+// often a session token.
+type SetCookie struct {
+	// URL of the response which set the cookie
+	URL string
+
+	Name   string
+	Domain string
+	Path   string
+
+	// Expiry time, nil for session cookies
+	Expires *time.Time
+	MaxAge  int
+
+	Secure   bool
+	HTTPOnly bool
+	SameSite string
+}
@@ -0,0 +1,103 @@
+package types
+
+import (
+	"time"
+)
+
+// DNSCheck configures a DNS record check, the name queried is the host of
+// the check URL, e.g. dns://example.com
+type DNSCheck struct {
+	// Record type to query, A, AAAA, CNAME, MX, TXT, NS, CAA or SOA
+	Type string
+
+	// Values which must be in the answer, in presentation format without
+	// the trailing dot, e.g. "10 mail.example.com" for an MX record
+	Expected []string
+
+	// Fail if the answer contains values which aren't expected
+	ExactMatch bool
+
+	// Check every authoritative nameserver gives the same answer
+	CheckNameservers bool
+
+	// Check every authoritative nameserver has the same SOA serial
+	CheckSOASerial bool
+
+	// Authoritative nameservers to compare as host:port, by default these
+	// are found from the NS records of the zone
+	Nameservers []string
+
+	// Validate the records with DNSSEC
+	DNSSEC bool
+
+	// DS or DNSKEY records in zone file format where the chain of trust
+	// starts, by default the agent or root zone trust anchors are used
+	TrustAnchors []string
+
+	// Warn if a signature in the chain of trust expires within this many days
+	SignatureExpiryWarningDays int
+}
+
+// DNSReport is the answer to a DNS record check
+type DNSReport struct {
+	// Name which was queried
+	Name string
+
+	// Record type which was queried
+	Type string
+
+	// Nameserver which answered
+	Resolver string
+
+	// Values of the records in the answer
+	Records []string
+
+	// Answers from each authoritative nameserver, only set if they were
+	// compared
+	Nameservers []DNSNameserverReport
+
+	// Result of DNSSEC validation, only set if it was performed
+	DNSSEC *DNSSECReport
+}
+
+// DNSNameserverReport is the answer from an authoritative nameserver
+type DNSNameserverReport struct {
+	// Hostname of the nameserver
+	Name string
+
+	// Address the nameserver was queried on
+	Address string
+
+	// Values of the records in the answer
+	Records []string
+
+	// SOA serial of the zone, only set if serials were compared
+	Serial uint32
+
+	// Error querying the nameserver
+	Error string
+}
+
+// DNSSECReport is the result of validating the chain of trust
+type DNSSECReport struct {
+	// secure, insecure or bogus
+	Status string
+
+	// Signatures verified along the chain of trust
+	Signatures []DNSSECSignature
+}
+
+// DNSSECSignature contains information about a RRSIG record
+type DNSSECSignature struct {
+	// Name and type of the records which were signed
+	Name string
+	Type string
+
+	// Zone and key which signed the records
+	Signer string
+	KeyTag uint16
+
+	// Validity period of the signature
+	Inception  time.Time
+	Expiration time.Time
+}
@@ -0,0 +1,23 @@
+package types
+
+// GRPCCheck configures a gRPC health check, using the grpc.health.v1.Health
+// service. TLS is always used for the grpcs scheme.
+type GRPCCheck struct {
+	// Service to check, the overall server health if empty
+	Service string
+
+	// Connect with TLS
+	TLS bool
+}
+
+// GRPCReport contains the response from the health service
+type GRPCReport struct {
+	// Service which was checked
+	Service string
+
+	// Serving status, e.g. SERVING or NOT_SERVING
+	Status string
+
+	// gRPC status code of the call, e.g. OK or NotFound
+	Code string
+}
@@ -0,0 +1,34 @@
+package types
+
+// AuthScheme is the HTTP authentication scheme used by the check
+type AuthScheme string
+
+const (
+	// AuthNone doesn't authenticate
+	AuthNone AuthScheme = ""
+
+	// AuthBasic uses Basic authentication
+	AuthBasic AuthScheme = "basic"
+
+	// AuthDigest uses Digest authentication, after receiving the challenge
+	// from the server
+	AuthDigest AuthScheme = "digest"
+
+	// AuthOAuth2 uses a bearer token fetched with the OAuth2 client
+	// credentials grant
+	AuthOAuth2 AuthScheme = "oauth2"
+)
+
+// HTTPAuth is how the check authenticates with the server. Credentials are
+// only sent to the host being checked, not to other hosts after redirects.
+type HTTPAuth struct {
+	Scheme AuthScheme
+
+	// Name of the secret in the agent's secret store, this contains the
+	// username and password (or client ID and secret) as username:password
+	Credentials string
+
+	// OAuth2 token endpoint and requested scopes
+	TokenURL string
+	Scopes   []string
+}
@@ -0,0 +1,22 @@
+package types
+
+// HTTPProtocol is the HTTP version required for a check
+type HTTPProtocol string
+
+const (
+	// HTTPProtocolAny uses whichever version the server negotiates
+	HTTPProtocolAny HTTPProtocol = ""
+
+	// HTTPProtocolHTTP1 only allows HTTP/1.1
+	HTTPProtocolHTTP1 HTTPProtocol = "http1"
+
+	// HTTPProtocolHTTP2 requires HTTP/2 to be negotiated with ALPN
+	HTTPProtocolHTTP2 HTTPProtocol = "h2"
+
+	// HTTPProtocolH2C uses HTTP/2 without TLS, with prior knowledge
+	HTTPProtocolH2C HTTPProtocol = "h2c"
+
+	// HTTPProtocolHTTP3 only uses HTTP/3 over QUIC, this isn't available
+	// through a proxy
+	HTTPProtocolHTTP3 HTTPProtocol = "h3"
+)
@@ -0,0 +1,18 @@
+package types
+
+// IPFamily is the address family used to connect to the server
+type IPFamily string
+
+const (
+	// IPFamilyAny uses whichever family connects first
+	IPFamilyAny IPFamily = ""
+
+	// IPFamilyIPv4 only connects over IPv4
+	IPFamilyIPv4 IPFamily = "ipv4"
+
+	// IPFamilyIPv6 only connects over IPv6
+	IPFamilyIPv6 IPFamily = "ipv6"
+
+	// IPFamilyBoth checks over IPv4 and IPv6 separately
+	IPFamilyBoth IPFamily = "both"
+)
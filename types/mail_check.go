@@ -0,0 +1,26 @@
+package types
+
+// MailCheck configures a SMTP, IMAP or POP3 check. Implicit TLS is used for
+// the smtps, imaps and pop3s schemes.
+type MailCheck struct {
+	// Upgrade the connection with STARTTLS (STLS for POP3)
+	StartTLS bool
+
+	// Hostname sent in the SMTP EHLO command
+	Hostname string
+
+	// Capabilities the server must advertise, e.g. SIZE or IDLE
+	RequiredCapabilities []string
+}
+
+// MailReport contains information from a mail server
+type MailReport struct {
+	// Greeting sent by the server
+	Greeting string
+
+	// Capabilities advertised by the server, after STARTTLS if it was used
+	Capabilities []string
+
+	// Whether the connection was upgraded with STARTTLS
+	StartTLS bool
+}
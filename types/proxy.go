@@ -0,0 +1,15 @@
+package types
+
+// Proxy is an outbound proxy connections are made through
+type Proxy struct {
+	// URL of the proxy, the scheme is http or https for a HTTP proxy,
+	// socks5 for SOCKS5 with local name resolution, or socks5h for SOCKS5
+	// with resolution by the proxy. HTTP proxies are sent plain HTTP
+	// requests in absolute form, other connections are tunnelled with
+	// CONNECT.
+	URL string
+
+	// Name of the secret in the agent's secret store with the username and
+	// password as username:password, these may also be in the URL
+	Credentials string
+}
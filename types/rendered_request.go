@@ -0,0 +1,12 @@
+package types
+
+import "net/http"
+
+// RenderedRequest is the request sent to the server, after template values
+// were rendered
+type RenderedRequest struct {
+	Method  string
+	URL     string
+	Headers http.Header
+	Body    string
+}
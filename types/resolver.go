@@ -0,0 +1,33 @@
+package types
+
+import (
+	"time"
+)
+
+// Resolver configures the nameservers used to resolve hostnames, instead of
+// the system resolver
+type Resolver struct {
+	// Nameservers queried in order until one answers. These are in the form
+	// udp://host:port, tcp://host:port, tls://host:port for DNS over TLS, or
+	// https://host/path for DNS over HTTPS. An address without a scheme uses
+	// UDP.
+	Nameservers []string
+
+	// Timeout for each query
+	Timeout time.Duration
+}
+
+// DNSResolution is the answer given by a custom resolver
+type DNSResolution struct {
+	// Hostname which was resolved
+	Host string
+
+	// Nameserver which answered
+	Resolver string
+
+	// Response code, e.g. NOERROR or NXDOMAIN
+	Rcode string
+
+	// Addresses returned
+	Addresses []string
+}
@@ -0,0 +1,29 @@
+package types
+
+// RevocationStatus is the revocation status of a certificate
+type RevocationStatus string
+
+const (
+	// RevocationGood certificate has not been revoked
+	RevocationGood RevocationStatus = "good"
+
+	// RevocationRevoked certificate has been revoked
+	RevocationRevoked RevocationStatus = "revoked"
+
+	// RevocationUnknown revocation status could not be determined
+	RevocationUnknown RevocationStatus = "unknown"
+)
+
+// RevocationSource is where the revocation status was obtained from
+type RevocationSource string
+
+const (
+	// RevocationSourceStapled OCSP response stapled to the TLS handshake
+	RevocationSourceStapled RevocationSource = "stapled"
+
+	// RevocationSourceOCSP OCSP responder named in the certificate
+	RevocationSourceOCSP RevocationSource = "ocsp"
+
+	// RevocationSourceCRL CRL distribution point named in the certificate
+	RevocationSourceCRL RevocationSource = "crl"
+)
@@ -0,0 +1,14 @@
+package types
+
+// TCPCheck configures a TCP check, the address is the host and port of the
+// check URL, e.g. tcp://example.com:6379
+type TCPCheck struct {
+	// Data sent after connecting
+	Payload []byte
+
+	// Regular expression which the data received must match
+	ExpectBanner string
+
+	// Perform a TLS handshake after connecting, before sending the payload
+	TLS bool
+}
@@ -0,0 +1,55 @@
+package types
+
+// TLSPolicy is the policy a TLS scan must satisfy, zero values disable each
+// check
+type TLSPolicy struct {
+	// Minimum TLS version the server may accept, e.g. tls.VersionTLS12
+	MinVersion uint16
+
+	// Cipher suites the server must not accept
+	ForbiddenCipherSuites []uint16
+
+	// Fail if the server accepts any cipher suite crypto/tls doesn't consider
+	// secure, including those it doesn't implement such as DHE or CAMELLIA
+	ForbidInsecureCipherSuites bool
+
+	// ALPN protocols the server must support, e.g. h2
+	RequireALPN []string
+
+	// Fail if the server doesn't staple an OCSP response
+	RequireOCSPStapling bool
+
+	// Fail if the server doesn't support session resumption
+	RequireSessionResumption bool
+}
+
+// TLSScanReport is the result of scanning the TLS configuration of a server
+type TLSScanReport struct {
+	// Result for each TLS version
+	Versions []TLSVersionReport
+
+	// ALPN protocols supported by the server
+	ALPN []string
+
+	// Whether the server stapled an OCSP response
+	OCSPStapling bool
+
+	// Whether the server allowed the session to be resumed
+	SessionResumption bool
+}
+
+// TLSVersionReport is the result of scanning a single TLS version
+type TLSVersionReport struct {
+	// Version name, e.g. TLS 1.2
+	Version string
+
+	// Version number, e.g. tls.VersionTLS12
+	ID uint16
+
+	// Whether the server accepted the version
+	Supported bool
+
+	// Cipher suites accepted by the server for this version, ordered by ID.
+	// Suites without a known name are reported by ID, e.g. 0x0066.
+	CipherSuites []string
+}
@@ -0,0 +1,118 @@
+package types
+
+import (
+	"net/http"
+	"time"
+)
+
+// Transaction is an ordered list of requests sharing cookies and connections.
+// Values extracted from a response can be used by later steps as ${name}.
+type Transaction struct {
+	Steps []TransactionStep
+}
+
+// TransactionStep is a request in a transaction
+type TransactionStep struct {
+	// Name shown in the results
+	Name string
+
+	// HTTP method, GET if empty
+	Method string
+
+	// URL, relative to the check URL. The URL, headers and body may contain
+	// variables.
+	URL     string
+	Headers http.Header
+	Body    string
+
+	// Values extracted from the response for later steps
+	Extract []Extraction
+
+	// Assertions on the response. Unless there is an assertion on the
+	// status, any 2xx status is successful.
+	Assertions []Assertion
+}
+
+// ExtractionSource is the part of a response a value is extracted from
+type ExtractionSource string
+
+const (
+	// ExtractJSON extracts the value at a JSON path, e.g. data.items[0].id
+	ExtractJSON ExtractionSource = "json"
+
+	// ExtractRegex extracts the first group matching a regular expression in
+	// the body, or the whole match if there are no groups
+	ExtractRegex ExtractionSource = "regex"
+
+	// ExtractHeader extracts the value of a response header
+	ExtractHeader ExtractionSource = "header"
+)
+
+// Extraction stores a value from the response in a variable
+type Extraction struct {
+	// Variable name
+	Name string
+
+	Source ExtractionSource
+
+	// JSON path, regular expression or header name
+	Expression string
+}
+
+// AssertionSource is the part of a response an assertion checks
+type AssertionSource string
+
+const (
+	// AssertStatus checks the status code
+	AssertStatus AssertionSource = "status"
+
+	// AssertHeader checks the value of a response header
+	AssertHeader AssertionSource = "header"
+
+	// AssertBody checks the response body
+	AssertBody AssertionSource = "body"
+
+	// AssertJSON checks the value at a JSON path
+	AssertJSON AssertionSource = "json"
+)
+
+// Assertion checks part of a response matches a regular expression
+type Assertion struct {
+	Source AssertionSource
+
+	// Header name or JSON path, not used for the status and body
+	Expression string
+
+	// Regular expression the value must match
+	Pattern string
+}
+
+// AssertionResult is the outcome of an assertion
+type AssertionResult struct {
+	Assertion
+
+	// Value which was checked
+	Value string
+
+	Passed bool
+}
+
+// TransactionStepResult is the result of a step in a transaction
+type TransactionStepResult struct {
+	Name string
+
+	// Request after variables were replaced
+	Method string
+	URL    string
+
+	StatusCode int
+
+	// Time for the step, and detailed timings of the final request
+	Time   *time.Duration
+	Timing *RequestTiming
+
+	Assertions []AssertionResult
+
+	// Error which caused the step to fail
+	Error string
+}
@@ -0,0 +1,34 @@
+package types
+
+import "time"
+
+// WebSocketCheck configures a WebSocket check. If a message is set it's sent
+// after the handshake, and the reply must match the expected pattern.
+type WebSocketCheck struct {
+	// Text message sent after the handshake
+	Message string
+
+	// Regular expression the reply must match, any reply is accepted if empty
+	ExpectReply string
+
+	// Time to wait for a reply, by default the check timeout
+	ReplyTimeout time.Duration
+
+	// Subprotocols requested during the handshake
+	Subprotocols []string
+}
+
+// WebSocketReport contains information about the WebSocket connection
+type WebSocketReport struct {
+	// Subprotocol selected by the server
+	Subprotocol string
+
+	// Reply to the message, only set if a message was sent
+	Reply string
+
+	// Time between sending the message and receiving the reply
+	ReplyTime *time.Duration
+
+	// Whether the server acknowledged the close frame
+	CleanClose bool
+}